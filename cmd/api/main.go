@@ -1,8 +1,15 @@
 package main
 
 import (
-	"fmt"
-	"log"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -13,9 +20,17 @@ import (
 	"github.com/tukembaev/bookVisionGo/internal/config"
 	"github.com/tukembaev/bookVisionGo/internal/db"
 	"github.com/tukembaev/bookVisionGo/internal/handlers"
+	"github.com/tukembaev/bookVisionGo/internal/health"
+	"github.com/tukembaev/bookVisionGo/internal/logging"
+	"github.com/tukembaev/bookVisionGo/internal/metrics"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/ratelimit"
 	"github.com/tukembaev/bookVisionGo/internal/repositories"
 	"github.com/tukembaev/bookVisionGo/internal/services"
+	"github.com/tukembaev/bookVisionGo/internal/storage"
+	"github.com/tukembaev/bookVisionGo/internal/tracing"
 	"github.com/tukembaev/bookVisionGo/internal/utils"
+	"github.com/tukembaev/bookVisionGo/internal/verification"
 	"github.com/tukembaev/bookVisionGo/pkg/api"
 )
 
@@ -42,81 +57,316 @@ func main() {
 	// Загрузка конфигурации
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatal("Failed to load config:", err)
+		fatal("failed to load config", err)
 	}
 
-	// Вывод информации о хосте и конфигурации
-	fmt.Printf("📋 Configuration loaded:\n")
-	fmt.Printf("   - Server Port: '%s'\n", cfg.Server.Port)
-	fmt.Printf("   - Server Mode: '%s'\n", cfg.Server.Mode)
-	fmt.Printf("   - DB Host: '%s'\n", cfg.Database.Host)
-	fmt.Printf("   - DB Port: '%s'\n", cfg.Database.Port)
+	// Структурированные логи; стандартный log тоже пишет через этот логгер
+	logger, err := logging.Setup(os.Stdout, logging.Options{Level: cfg.Log.Level, Format: cfg.Log.Format})
+	if err != nil {
+		fatal("failed to configure logging", err)
+	}
+	// Действующая конфигурация (секреты замаскированы)
+	slog.Info("configuration loaded", "profile", cfg.Profile, "config", cfg)
+
+	// Трассировка OpenTelemetry (TRACING_EXPORTER: none, stdout, otlp)
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Exporter:     cfg.Tracing.Exporter,
+		ServiceName:  cfg.Tracing.ServiceName,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		SampleRatio:  cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		fatal("failed to configure tracing", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("failed to flush traces", "error", err)
+		}
+	}()
 
 	// Если порт пустой, используем дефолтный
 	port := cfg.Server.Port
 	if port == "" {
 		port = "8080"
-		fmt.Printf("⚠️  Port not configured, using default: %s\n", port)
+		slog.Warn("server port not configured, using default", "port", port)
 	}
 
-	fmt.Printf("🚀 Server will start on: http://localhost:%s\n", port)
-	fmt.Printf("📚 Swagger UI will be available at: http://localhost:%s/swagger/index.html\n", port)
-	fmt.Printf("🔍 Health check: http://localhost:%s/health\n", port)
-
 	// Настройка Gin режима
 	gin.SetMode(cfg.Server.Mode)
 
 	// Подключение к базе данных
 	database, err := db.NewDatabase(cfg)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		fatal("failed to connect to database", err)
 	}
 	defer database.Close()
+	metrics.RegisterPool(database.GetStats)
+
+	// Автомиграция (конкурентные запуски реплик ждут advisory lock)
+	if cfg.Database.AutoMigrate {
+		if err := db.Migrate(cfg.DatabaseURL()); err != nil {
+			fatal("failed to apply migrations", err)
+		}
+	}
 
 	// Инициализация зависимостей
 	jwtUtils := utils.NewJWTUtils(cfg)
+	txManager := db.NewTxManager(database.GetPool())
 
 	// Репозитории
 	userRepo := repositories.NewUserRepository(database.GetPool())
 	bookRepo := repositories.NewBookRepository(database.GetPool()) // Включаем BookRepository
 	articleRepo := repositories.NewArticleRepository(database.GetPool())
+	reviewRepo := repositories.NewReviewRepository(database.GetPool())
+	quoteRepo := repositories.NewQuoteRepository(database.GetPool())
+	readingRepo := repositories.NewReadingRepository(database.GetPool())
+	followRepo := repositories.NewFollowRepository(database.GetPool())
+	feedRepo := repositories.NewFeedRepository(database.GetPool())
+	challengeRepo := repositories.NewChallengeRepository(database.GetPool())
+	shelfRepo := repositories.NewShelfRepository(database.GetPool())
+	recommendationRepo := repositories.NewRecommendationRepository(database.GetPool())
+	characterRepo := repositories.NewCharacterRepository(database.GetPool())
+	revisionRepo := repositories.NewRevisionRepository(database.GetPool())
+	trashRepo := repositories.NewTrashRepository(database.GetPool())
+	auditRepo := repositories.NewAuditRepository(database.GetPool())
+	proposalRepo := repositories.NewProposalRepository(database.GetPool())
+	reportRepo := repositories.NewReportRepository(database.GetPool())
+
+	// Кэш книг и глав в памяти процесса: каталог читается намного чаще, чем меняется
+	if cfg.Cache.Enabled {
+		bookRepo = repositories.NewCachedBookRepository(bookRepo, repositories.BookCacheOptions{
+			TTL:           seconds(cfg.Cache.TTLSeconds),
+			MaxBooks:      cfg.Cache.MaxBooks,
+			MaxPartsBytes: int64(cfg.Cache.MaxPartsMB) << 20,
+		})
+	}
+
+	// Хранилище файлов (локальная директория или S3/MinIO)
+	blobStore, err := storage.New(context.Background(), cfg.Storage)
+	if err != nil {
+		fatal("failed to initialize blob storage", err)
+	}
+
+	// Автоматическая проверка книг (VERIFICATION_VERIFIER=none отключает ее)
+	verifier, err := verification.New(cfg.Verification)
+	if err != nil {
+		fatal("failed to initialize book verifier", err)
+	}
+
 	// Сервисы
 	authService := services.NewAuthService(userRepo, jwtUtils)
+	visibilityPolicy := services.NewVisibilityPolicy(followRepo)
+	auditService := services.NewAuditService(auditRepo)
+	userService := services.NewUserService(userRepo, reviewRepo, quoteRepo, readingRepo, visibilityPolicy, auditService, txManager)
+	socialService := services.NewSocialService(userRepo, followRepo, feedRepo, visibilityPolicy)
+	readingService := services.NewReadingService(readingRepo, bookRepo, socialService)
+	shelfService := services.NewShelfService(shelfRepo, bookRepo, readingRepo, readingService, txManager)
+	recommendationService := services.NewRecommendationService(recommendationRepo, bookRepo, cfg.Recommendations.TopN)
+	mediaService := services.NewMediaService(blobStore, bookRepo, articleRepo, userRepo, characterRepo, auditService, txManager,
+		cfg.Storage.PublicURL, time.Duration(cfg.Storage.SignedURLTTLMinutes)*time.Minute)
+	importService := services.NewImportService(bookRepo, mediaService, auditService, txManager)
+	exportService := services.NewExportService(bookRepo, userRepo, reviewRepo, quoteRepo, readingRepo, mediaService)
+	revisionService := services.NewRevisionService(revisionRepo)
+	trashService := services.NewTrashService(trashRepo, auditService, txManager, cfg.Trash.RetentionDays)
+	verificationService := services.NewVerificationService(proposalRepo, bookRepo, verifier, revisionService, auditService, txManager,
+		services.VerificationOptions{
+			VoteThreshold:        cfg.Verification.VoteThreshold,
+			TrustedMinAccountAge: time.Duration(cfg.Verification.TrustedMinAccountDays) * 24 * time.Hour,
+			TrustedMinReviews:    cfg.Verification.TrustedMinReviews,
+			AIMinConfidence:      cfg.Verification.AIMinConfidence,
+		})
+	reportService := services.NewReportService(reportRepo, userRepo, auditService, txManager,
+		services.ReportOptions{
+			AutoHideReports:    cfg.Moderation.AutoHideReports,
+			StrikesToSuspend:   cfg.Moderation.StrikesToSuspend,
+			SuspensionDuration: time.Duration(cfg.Moderation.SuspensionDays) * 24 * time.Hour,
+		})
 
 	// Handlers
 	authHandler := handlers.NewAuthHandler(authService)
-	bookHandler := handlers.NewBookHandler(bookRepo)          // Настоящий handler с репозиторием
-	articleHandler := handlers.NewArticleHandler(articleRepo) // Настоящий handler с репозиторием
+	bookHandler := handlers.NewBookHandler(bookRepo, revisionService, auditService, txManager) // Настоящий handler с репозиторием
+	characterHandler := handlers.NewCharacterHandler(characterRepo, revisionService, auditService, txManager)
+	articleHandler := handlers.NewArticleHandler(articleRepo, socialService, auditService, txManager)
+	userHandler := handlers.NewUserHandler(userService)
+	socialHandler := handlers.NewSocialHandler(socialService)
+	reviewHandler := handlers.NewReviewHandler(reviewRepo, bookRepo, txManager, socialService)
+	quoteHandler := handlers.NewQuoteHandler(quoteRepo, bookRepo, socialService)
+	readingHandler := handlers.NewReadingHandler(readingService)
+	challengeHandler := handlers.NewChallengeHandler(challengeRepo, socialService)
+	shelfHandler := handlers.NewShelfHandler(shelfService)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
+	importHandler := handlers.NewImportHandler(importService)
+	exportHandler := handlers.NewExportHandler(exportService)
+	mediaHandler := handlers.NewMediaHandler(mediaService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	verificationHandler := handlers.NewVerificationHandler(verificationService)
+	reportHandler := handlers.NewReportHandler(reportService)
+
+	// Проверки готовности (/readyz)
+	checker := health.NewChecker()
+	checker.Register("database", database.HealthCheck)
+	checker.Register("migrations", database.CheckSchema)
+	checker.Register("storage", blobStore.Ping)
+	healthHandler := handlers.NewHealthHandler(checker)
 
-	// Debug: проверим что handler не nil
-	if bookHandler == nil {
-		log.Fatal("bookHandler is nil!")
+	// Фоновый пересчет рекомендаций; при остановке jobsCtx отменяется, workers ждет завершения
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+	var workers sync.WaitGroup
+	if interval := cfg.Recommendations.IntervalMinutes; interval > 0 {
+		workers.Go(func() {
+			recommendationService.Run(jobsCtx, time.Duration(interval)*time.Minute)
+		})
+	}
+	// Окончательное удаление сущностей, пролежавших в корзине дольше срока хранения
+	if interval := cfg.Trash.PurgeIntervalMinutes; interval > 0 {
+		workers.Go(func() {
+			trashService.Run(jobsCtx, time.Duration(interval)*time.Minute)
+		})
+	}
+
+	// Ограничение частоты запросов: корзины в памяти реплики или общие в Postgres
+	var rateLimitStore ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.RateLimit.Store == "postgres" {
+		rateLimitStore = ratelimit.NewPostgresStore(database.GetPool())
+	}
+	rateLimits := middleware.NewRateLimits(cfg.RateLimit, rateLimitStore)
+	if cfg.RateLimit.Enabled {
+		workers.Go(func() {
+			ratelimit.RunJanitor(jobsCtx, rateLimitStore, time.Minute, rateLimits.IdleTimeout())
+		})
 	}
 
 	// Настройка роутов
-	r := gin.Default()
+	r := gin.New()
+	// X-Forwarded-For учитывается только от доверенных прокси (IP клиента важен для лимитов и логов)
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		fatal("invalid trusted proxies", err)
+	}
 
 	// Настройка CORS
 	config := cors.DefaultConfig()
-	config.AllowOrigins = []string{
-		"http://localhost:3000", // React dev server
-		"http://localhost:5173", // Vite dev server
-		"http://localhost:8080", // Swagger UI
-	}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Authorization"}
+	config.AllowOrigins = cfg.CORS.AllowedOrigins
+	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	config.AllowHeaders = []string{"Origin", "Content-Type", "Authorization", middleware.RequestIDHeader, "traceparent", "tracestate",
+		"If-None-Match", "If-Modified-Since", "If-Match"}
+	config.ExposeHeaders = append([]string{middleware.RequestIDHeader, "ETag"}, middleware.RateLimitHeaders...)
 	config.AllowCredentials = true
 
 	r.Use(cors.New(config))
 
+	// Идентификатор запроса, спан трассировки, журнал запросов, метрики, единый формат ошибок
+	// (apperrors -> JSON) и перехват паник; Recovery внутри ErrorHandler, чтобы паника тоже получила JSON ответ
+	r.Use(middleware.RequestID(), middleware.Tracing(), middleware.AccessLog(logger), middleware.Metrics(),
+		middleware.ErrorHandler(), middleware.Recovery())
+
+	// Метрики Prometheus: на основном порту или на отдельном METRICS_PORT
+	var metricsServer *http.Server
+	if cfg.Metrics.Enabled {
+		if cfg.Metrics.Port == "" || cfg.Metrics.Port == port {
+			api.SetupMetrics(r, cfg.Metrics.Path)
+		} else {
+			metricsServer = api.NewMetricsServer(cfg.Metrics.Port, cfg.Metrics.Path)
+			go func() {
+				if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					slog.Error("metrics server stopped", "error", err)
+				}
+			}()
+			slog.Info("metrics server starting", "port", cfg.Metrics.Port, "path", cfg.Metrics.Path)
+		}
+	}
+	if cfg.Metrics.PprofEnabled {
+		api.SetupPprof(r, authService)
+		slog.Warn("pprof enabled", "path", "/debug/pprof")
+	}
+
 	// Swagger документация
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	api.SetupRoutes(r, authHandler, bookHandler, articleHandler, authService)
+	api.SetupRoutes(r, authHandler, bookHandler, characterHandler, articleHandler, userHandler, socialHandler,
+		reviewHandler, quoteHandler, readingHandler, challengeHandler, shelfHandler, recommendationHandler, importHandler, exportHandler, mediaHandler, trashHandler, auditHandler, verificationHandler, reportHandler, healthHandler, authService, rateLimits,
+		seconds(cfg.Server.UploadTimeoutSeconds))
+
+	srv := &http.Server{
+		Addr:              ":" + port,
+		Handler:           r,
+		ReadHeaderTimeout: seconds(cfg.Server.ReadHeaderTimeoutSeconds),
+		ReadTimeout:       seconds(cfg.Server.ReadTimeoutSeconds),
+		WriteTimeout:      seconds(cfg.Server.WriteTimeoutSeconds),
+		IdleTimeout:       seconds(cfg.Server.IdleTimeoutSeconds),
+	}
+
+	// Запуск сервера; SIGINT/SIGTERM запускают graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- srv.ListenAndServe()
+	}()
+	slog.Info("server starting", "port", port, "mode", cfg.Server.Mode,
+		"swagger", "http://localhost:"+port+"/swagger/index.html")
 
-	// Запуск сервера
-	log.Printf("Server starting on port %s", port)
-	if err := r.Run(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	select {
+	case err := <-serverErr:
+		fatal("failed to start server", err)
+	case <-ctx.Done():
+		stop()
+		slog.Info("shutdown signal received, draining requests")
 	}
+
+	// Остановка: /readyz отвечает 503 в течение drain_delay_seconds, пока балансировщик
+	// исключает реплику, затем listener закрывается, текущие запросы дорабатывают и останавливаются
+	// фоновые задачи; пул БД и экспорт трассировки закрываются defer после выхода из main
+	checker.SetDraining()
+	if delay := seconds(cfg.Server.DrainDelaySeconds); delay > 0 {
+		slog.Info("waiting for load balancer to stop routing", "delay", delay)
+		time.Sleep(delay)
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), seconds(cfg.Server.ShutdownTimeoutSeconds))
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to drain http requests", "error", err)
+	}
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			slog.Error("failed to stop metrics server", "error", err)
+		}
+	}
+	cancelJobs()
+	if err := waitWorkers(shutdownCtx, &workers); err != nil {
+		slog.Error("background workers did not stop in time", "error", err)
+	}
+	slog.Info("server stopped")
+}
+
+// seconds - длительность из числа секунд в конфигурации
+func seconds(n int) time.Duration {
+	return time.Duration(n) * time.Second
+}
+
+// waitWorkers - ожидание фоновых задач, но не дольше ctx
+func waitWorkers(ctx context.Context, workers *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fatal - запись ошибки запуска и завершение процесса
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/config"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/repositories"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+	"github.com/tukembaev/bookVisionGo/internal/storage"
+)
+
+// Импорт книг из EPUB/FB2:
+//
+//	go run ./cmd/import -dry-run books/fathers_and_sons.fb2
+//	go run ./cmd/import -created-by <user-id> books/*.fb2 books/*.epub
+func main() {
+	dryRun := flag.Bool("dry-run", false, "только разобрать файлы и показать результат, без записи в базу")
+	createdBy := flag.String("created-by", "", "ID пользователя, от имени которого создаются книги")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Использование: %s [флаги] файл.epub|файл.fb2 ...\n", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Для предпросмотра подключение к базе данных не требуется
+	var (
+		bookRepo     interfaces.BookRepository
+		mediaService *services.MediaService
+		auditService *services.AuditService
+		txManager    interfaces.TxManager
+	)
+	if !*dryRun {
+		cfg, err := config.Load()
+		if err != nil {
+			log.Fatalf("Не удалось загрузить конфигурацию: %v", err)
+		}
+
+		database, err := db.NewDatabase(cfg)
+		if err != nil {
+			log.Fatalf("Не удалось подключиться к базе данных: %v", err)
+		}
+		defer database.Close()
+
+		store, err := storage.New(context.Background(), cfg.Storage)
+		if err != nil {
+			log.Fatalf("Не удалось подключить хранилище файлов: %v", err)
+		}
+
+		pool := database.GetPool()
+		bookRepo = repositories.NewBookRepository(pool)
+		txManager = db.NewTxManager(pool)
+		// Импорт из CLI попадает в журнал аудита без исполнителя
+		auditService = services.NewAuditService(repositories.NewAuditRepository(pool))
+		mediaService = services.NewMediaService(store, bookRepo, repositories.NewArticleRepository(pool),
+			repositories.NewUserRepository(pool), repositories.NewCharacterRepository(pool), auditService, txManager,
+			cfg.Storage.PublicURL, time.Duration(cfg.Storage.SignedURLTTLMinutes)*time.Minute)
+	}
+
+	importService := services.NewImportService(bookRepo, mediaService, auditService, txManager)
+
+	var creator *string
+	if *createdBy != "" {
+		creator = createdBy
+	}
+
+	failed := 0
+	for _, path := range flag.Args() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Printf("Не удалось прочитать %s: %v", path, err)
+			failed++
+			continue
+		}
+
+		var result interface{}
+		if *dryRun {
+			result, err = importService.Preview(filepath.Base(path), data)
+		} else {
+			result, err = importService.Import(context.Background(), filepath.Base(path), data, creator)
+		}
+		if err != nil {
+			log.Printf("Ошибка импорта %s: %v", path, err)
+			failed++
+			continue
+		}
+
+		output, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(output))
+	}
+
+	if failed > 0 {
+		log.Fatalf("Не удалось импортировать файлов: %d из %d", failed, flag.NArg())
+	}
+}
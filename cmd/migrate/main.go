@@ -1,35 +1,129 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
-	"os/exec"
+	"os"
+	"path/filepath"
+	"strconv"
 
 	"github.com/tukembaev/bookVisionGo/internal/config"
+	"github.com/tukembaev/bookVisionGo/internal/db"
 )
 
+const usage = `Использование: %s <команда> [аргумент]
+
+Команды:
+  up             применить все новые миграции
+  down [N|all]   откатить N последних миграций (по умолчанию 1) или все
+  goto V         перейти к версии V (вверх или вниз)
+  force V        записать версию V без выполнения миграций (снимает флаг dirty)
+  status         показать текущую версию и список миграций
+`
+
+// Миграции встроены в бинарник (internal/db/migrations), внешний migrate CLI не нужен:
+//
+//	go run ./cmd/migrate up
+//	go run ./cmd/migrate down 1
+//	go run ./cmd/migrate status
 func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), usage, filepath.Base(os.Args[0]))
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	command, arg := flag.Arg(0), flag.Arg(1)
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatal("Ошибка загрузки конфигурации:", err)
 	}
 
-	dbURL := cfg.DatabaseURL()
-	fmt.Println("Connection URL:", dbURL)
+	migrator, err := db.NewMigrator(cfg.DatabaseURL())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer migrator.Close()
 
-	cmd := exec.Command("migrate",
-		"-path", "internal/db/migrations",
-		"-database", dbURL,
-		"up")
+	switch command {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		steps := 1
+		switch arg {
+		case "":
+		case "all":
+			steps = 0
+		default:
+			steps = parseNumber(arg)
+			if steps <= 0 {
+				log.Fatalf("Количество миграций должно быть положительным: %s", arg)
+			}
+		}
+		err = migrator.Down(steps)
+	case "goto":
+		err = migrator.Goto(uint(parseNumber(requireArg(command, arg))))
+	case "force":
+		err = migrator.Force(parseNumber(requireArg(command, arg)))
+	case "status":
+		err = printStatus(migrator)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("Ошибка выполнения %s: %v", command, err)
+	}
 
-	fmt.Println("Running command:", cmd.String())
+	if command != "status" {
+		version, dirty, err := migrator.Version()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Текущая версия: %d (dirty: %t)\n", version, dirty)
+	}
+}
 
-	output, err := cmd.CombinedOutput()
+// printStatus - вывод текущей версии и списка миграций
+func printStatus(migrator *db.Migrator) error {
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	migrations, err := migrator.Status()
 	if err != nil {
-		fmt.Println("Command output:", string(output))
-		log.Fatal("Migration failed:", err)
+		return err
 	}
 
-	fmt.Println("Migration successful!")
-	fmt.Println("Output:", string(output))
+	fmt.Printf("Текущая версия: %d (dirty: %t)\n\n", version, dirty)
+	for _, m := range migrations {
+		state := "pending"
+		if m.Applied {
+			state = "applied"
+		}
+		fmt.Printf("  %06d  %-8s %s\n", m.Version, state, m.Name)
+	}
+	return nil
+}
+
+// requireArg - обязательный аргумент команды
+func requireArg(command, arg string) string {
+	if arg == "" {
+		log.Fatalf("Команда %s требует номер версии", command)
+	}
+	return arg
+}
+
+// parseNumber - разбор числового аргумента
+func parseNumber(arg string) int {
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 0 {
+		log.Fatalf("Ожидалось неотрицательное число, получено %q", arg)
+	}
+	return n
 }
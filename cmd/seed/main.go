@@ -2,14 +2,54 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 
 	"github.com/tukembaev/bookVisionGo/internal/config"
 	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/seed"
 )
 
+// Заполнение базы из фикстур internal/seed/fixtures:
+//
+//	go run ./cmd/seed                                  # catalog + demo
+//	go run ./cmd/seed -datasets catalog -truncate      # очистить и загрузить только каталог
+//	go run ./cmd/seed -datasets "" -synthetic-books 10000 -synthetic-users 1000
 func main() {
+	datasets := flag.String("datasets", "catalog,demo", "наборы фикстур через запятую (зависимости подключаются автоматически)")
+	list := flag.Bool("list", false, "показать доступные наборы и выйти")
+	truncate := flag.Bool("truncate", false, "очистить таблицы заполняемых сущностей перед загрузкой (TRUNCATE ... CASCADE)")
+	syntheticBooks := flag.Int("synthetic-books", 0, "сгенерировать N синтетических книг")
+	syntheticUsers := flag.Int("synthetic-users", 0, "сгенерировать N синтетических пользователей")
+	syntheticParts := flag.Int("synthetic-parts", 5, "частей в каждой синтетической книге")
+	syntheticReviews := flag.Int("synthetic-reviews", 3, "отзывов от каждого синтетического пользователя")
+	syntheticSeed := flag.Int64("synthetic-seed", 1, "зерно генератора синтетических данных")
+	flag.Parse()
+
+	if *list {
+		printDatasets()
+		return
+	}
+
+	opts := seed.Options{Truncate: *truncate}
+	for _, name := range strings.Split(*datasets, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			opts.Datasets = append(opts.Datasets, name)
+		}
+	}
+	if *syntheticBooks > 0 || *syntheticUsers > 0 {
+		opts.Synthetic = &seed.SyntheticOptions{
+			Books:          *syntheticBooks,
+			Users:          *syntheticUsers,
+			PartsPerBook:   *syntheticParts,
+			ReviewsPerUser: *syntheticReviews,
+			Seed:           *syntheticSeed,
+		}
+	}
+
 	// Загрузка конфигурации
 	cfg, err := config.Load()
 	if err != nil {
@@ -23,17 +63,33 @@ func main() {
 	}
 	defer database.Close()
 
-	// Заполнение базы данных книгами
-	err = db.SeedBooks(context.Background(), database.GetPool())
+	report, err := seed.Run(context.Background(), database.GetPool(), opts)
 	if err != nil {
-		log.Fatalf("Ошибка при заполнении базы данных книгами: %v", err)
+		log.Fatalf("Ошибка при заполнении базы данных: %v", err)
 	}
 
-	// Заполнение базы данных статьями
-	err = db.SeedArticles(context.Background(), database.GetPool())
+	fmt.Printf("База данных успешно заполнена: %s\n", report)
+}
+
+// printDatasets - список встроенных наборов фикстур
+func printDatasets() {
+	datasets, err := seed.LoadDatasets()
 	if err != nil {
-		log.Fatalf("Ошибка при заполнении базы данных статьями: %v", err)
+		log.Fatalf("Не удалось прочитать фикстуры: %v", err)
 	}
 
-	fmt.Println("База данных успешно заполнена!")
+	names := make([]string, 0, len(datasets))
+	for name := range datasets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		manifest := datasets[name].Manifest
+		fmt.Printf("%-10s %s", name, manifest.Description)
+		if len(manifest.Requires) > 0 {
+			fmt.Printf(" (requires: %s)", strings.Join(manifest.Requires, ", "))
+		}
+		fmt.Println()
+	}
 }
@@ -0,0 +1,172 @@
+// Package apperrors - доменные ошибки с кодом, который однозначно определяет HTTP статус ответа.
+package apperrors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Code - категория доменной ошибки
+type Code string
+
+const (
+	CodeValidation       Code = "validation_failed"
+	CodeUnauthorized     Code = "unauthorized"
+	CodeForbidden        Code = "forbidden"
+	CodeNotFound         Code = "not_found"
+	CodeConflict         Code = "conflict"
+	CodeTooLarge         Code = "payload_too_large"
+	CodeUnsupportedMedia Code = "unsupported_media_type"
+	CodeUnprocessable    Code = "unprocessable_entity"
+	CodeRateLimited      Code = "rate_limited"
+	CodePrecondition     Code = "precondition_required"
+	CodeInternal         Code = "internal_error"
+)
+
+// Error - код как ошибка, чтобы проверять категорию через errors.Is(err, apperrors.CodeNotFound)
+func (c Code) Error() string {
+	return string(c)
+}
+
+// Status - HTTP статус для кода
+func (c Code) Status() int {
+	switch c {
+	case CodeValidation:
+		return http.StatusBadRequest
+	case CodeUnauthorized:
+		return http.StatusUnauthorized
+	case CodeForbidden:
+		return http.StatusForbidden
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeConflict:
+		return http.StatusConflict
+	case CodeTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case CodeUnsupportedMedia:
+		return http.StatusUnsupportedMediaType
+	case CodeUnprocessable:
+		return http.StatusUnprocessableEntity
+	case CodeRateLimited:
+		return http.StatusTooManyRequests
+	case CodePrecondition:
+		return http.StatusPreconditionRequired
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// FieldError - ошибка конкретного поля запроса
+type FieldError struct {
+	Field   string `json:"field" example:"username"`
+	Message string `json:"message" example:"is required"`
+}
+
+// Error - доменная ошибка: код, сообщение для клиента, ошибки полей и исходная причина
+type Error struct {
+	Code    Code
+	Message string
+	Details []FieldError
+	Err     error
+	// Current - актуальное представление ресурса для ответа на конфликт версий
+	Current any
+
+	// origin - sentinel, из которого получена копия через WithField/WithDetail
+	origin *Error
+}
+
+// New - создание доменной ошибки
+func New(code Code, message string, details ...FieldError) *Error {
+	return &Error{Code: code, Message: message, Details: details}
+}
+
+// Wrap - доменная ошибка с исходной причиной (причина не попадает в ответ клиенту)
+func Wrap(code Code, message string, err error) *Error {
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+// NotFound - ресурс не найден
+func NotFound(message string) *Error {
+	return New(CodeNotFound, message)
+}
+
+// Conflict - конфликт с текущим состоянием (дубликат, занятое имя)
+func Conflict(message string) *Error {
+	return New(CodeConflict, message)
+}
+
+// Validation - некорректные входные данные
+func Validation(message string, details ...FieldError) *Error {
+	return New(CodeValidation, message, details...)
+}
+
+// Forbidden - действие запрещено для текущего пользователя
+func Forbidden(message string) *Error {
+	return New(CodeForbidden, message)
+}
+
+// Unauthorized - требуется аутентификация
+func Unauthorized(message string) *Error {
+	return New(CodeUnauthorized, message)
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is - совпадение по коду (errors.Is(err, apperrors.CodeNotFound)) или с исходным sentinel
+func (e *Error) Is(target error) bool {
+	switch t := target.(type) {
+	case Code:
+		return t == e.Code
+	case *Error:
+		return e.origin != nil && t == e.origin
+	}
+	return false
+}
+
+// derive - копия ошибки, которая остается равной исходному sentinel для errors.Is
+func (e *Error) derive() *Error {
+	copied := *e
+	if e.origin == nil {
+		copied.origin = e
+	}
+	return &copied
+}
+
+// WithField - копия ошибки с добавленной ошибкой поля (исходный sentinel не меняется)
+func (e *Error) WithField(field, message string) *Error {
+	copied := e.derive()
+	copied.Details = append(append([]FieldError(nil), e.Details...), FieldError{Field: field, Message: message})
+	return copied
+}
+
+// WithDetail - копия ошибки с уточнением в сообщении; errors.Is с исходным sentinel сохраняется
+func (e *Error) WithDetail(detail string) *Error {
+	copied := e.derive()
+	copied.Message = e.Message + ": " + detail
+	return copied
+}
+
+// WithCurrent - копия ошибки с актуальным представлением ресурса (исходный sentinel не меняется)
+func (e *Error) WithCurrent(current any) *Error {
+	copied := e.derive()
+	copied.Current = current
+	return copied
+}
+
+// As - доменная ошибка из цепочки; ошибки без кода считаются внутренними
+func As(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Wrap(CodeInternal, "internal server error", err)
+}
@@ -0,0 +1,63 @@
+package apperrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FromBinding - ошибка привязки запроса (c.ShouldBindJSON, c.ShouldBindQuery) как ошибка валидации
+// с перечнем некорректных полей
+func FromBinding(err error) *Error {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		details := make([]FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			details = append(details, FieldError{Field: fe.Field(), Message: fieldMessage(fe)})
+		}
+		return &Error{Code: CodeValidation, Message: "request validation failed", Details: details, Err: err}
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return &Error{
+			Code:    CodeValidation,
+			Message: "request validation failed",
+			Details: []FieldError{{Field: typeErr.Field, Message: "must be " + typeErr.Type.String()}},
+			Err:     err,
+		}
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return Wrap(CodeValidation, "malformed JSON body", err)
+	}
+
+	return Wrap(CodeValidation, err.Error(), err)
+}
+
+// fieldMessage - описание нарушенного правила валидации
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email"
+	case "min":
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(fe.Param()), ", ")
+	case "uuid", "uuid4":
+		return "must be a valid UUID"
+	case "url":
+		return "must be a valid URL"
+	default:
+		return fmt.Sprintf("failed on the %q rule", fe.Tag())
+	}
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU - потокобезопасный кэш с вытеснением давно не используемых записей.
+// Размер ограничен суммарным весом записей (например, байтами текста глав),
+// а не их количеством; записи старше ttl считаются отсутствующими.
+type LRU[K comparable, V any] struct {
+	mu       sync.Mutex
+	maxCost  int64
+	cost     int64
+	ttl      time.Duration
+	costFunc func(V) int64
+	items    map[K]*list.Element
+	order    *list.List // начало списка - недавно использованные записи
+}
+
+// entry - запись кэша
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	cost    int64
+	expires time.Time
+}
+
+// NewLRU - создание нового LRU с лимитом maxCost и временем жизни записи ttl (0 - без ограничения).
+// costFunc возвращает вес значения; nil - каждая запись весит 1.
+func NewLRU[K comparable, V any](maxCost int64, ttl time.Duration, costFunc func(V) int64) *LRU[K, V] {
+	if costFunc == nil {
+		costFunc = func(V) int64 { return 1 }
+	}
+	return &LRU[K, V]{
+		maxCost:  maxCost,
+		ttl:      ttl,
+		costFunc: costFunc,
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get - значение по ключу; просроченная запись удаляется
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	el, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+	e := el.Value.(*entry[K, V])
+	if c.ttl > 0 && time.Now().After(e.expires) {
+		c.removeElement(el)
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// Add - добавление или замена значения с вытеснением старых записей сверх лимита.
+// Значение тяжелее всего кэша не сохраняется.
+func (c *LRU[K, V]) Add(key K, value V) {
+	cost := c.costFunc(value)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+	if cost > c.maxCost {
+		return
+	}
+
+	e := &entry[K, V]{key: key, value: value, cost: cost}
+	if c.ttl > 0 {
+		e.expires = time.Now().Add(c.ttl)
+	}
+	c.items[key] = c.order.PushFront(e)
+	c.cost += cost
+
+	for c.cost > c.maxCost {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// Remove - удаление записи по ключу
+func (c *LRU[K, V]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+}
+
+// Purge - удаление всех записей
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[K]*list.Element)
+	c.order.Init()
+	c.cost = 0
+}
+
+// Len - количество записей
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Cost - суммарный вес записей
+func (c *LRU[K, V]) Cost() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cost
+}
+
+// removeElement - удаление элемента списка (вызывается под блокировкой)
+func (c *LRU[K, V]) removeElement(el *list.Element) {
+	e := c.order.Remove(el).(*entry[K, V])
+	delete(c.items, e.key)
+	c.cost -= e.cost
+}
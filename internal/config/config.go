@@ -2,124 +2,456 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
+// Профили конфигурации (APP_ENV)
+const (
+	ProfileDev  = "dev"
+	ProfileTest = "test"
+	ProfileProd = "prod"
+)
+
+// devJWTSecret - секрет по умолчанию для профилей dev и test; в prod запрещен
+const devJWTSecret = "dev-only-jwt-secret-change-me"
+
 type Config struct {
-	Server   ServerConfig
-	Database DBConfig
-	JWT      JWTConfig
+	// Profile - dev, test или prod (APP_ENV)
+	Profile         string                `mapstructure:"profile"`
+	Server          ServerConfig          `mapstructure:"server"`
+	CORS            CORSConfig            `mapstructure:"cors"`
+	Database        DBConfig              `mapstructure:"database"`
+	JWT             JWTConfig             `mapstructure:"jwt"`
+	Recommendations RecommendationsConfig `mapstructure:"recommendations"`
+	Storage         StorageConfig         `mapstructure:"storage"`
+	Log             LogConfig             `mapstructure:"log"`
+	Metrics         MetricsConfig         `mapstructure:"metrics"`
+	Tracing         TracingConfig         `mapstructure:"tracing"`
+	RateLimit       RateLimitConfig       `mapstructure:"rate_limit"`
+	Cache           CacheConfig           `mapstructure:"cache"`
+	Trash           TrashConfig           `mapstructure:"trash"`
+	Verification    VerificationConfig    `mapstructure:"verification"`
+	Moderation      ModerationConfig      `mapstructure:"moderation"`
 }
 
 type ServerConfig struct {
-	Port string `mapstructure:"SERVER_PORT"`
-	Mode string `mapstructure:"GIN_MODE"`
+	Port string `mapstructure:"port"`
+	Mode string `mapstructure:"mode"`
+	// Таймауты http.Server: чтение заголовков, чтение запроса, запись ответа, простой keep-alive соединения
+	ReadHeaderTimeoutSeconds int `mapstructure:"read_header_timeout_seconds"`
+	ReadTimeoutSeconds       int `mapstructure:"read_timeout_seconds"`
+	WriteTimeoutSeconds      int `mapstructure:"write_timeout_seconds"`
+	IdleTimeoutSeconds       int `mapstructure:"idle_timeout_seconds"`
+	// UploadTimeoutSeconds - таймаут чтения и записи для загрузки файлов (импорт книг, изображения)
+	// вместо read_timeout_seconds и write_timeout_seconds
+	UploadTimeoutSeconds int `mapstructure:"upload_timeout_seconds"`
+	// DrainDelaySeconds - сколько /readyz отвечает 503 до закрытия listener при остановке,
+	// чтобы балансировщик успел исключить реплику
+	DrainDelaySeconds int `mapstructure:"drain_delay_seconds"`
+	// ShutdownTimeoutSeconds - сколько ждать текущие запросы и фоновые задачи при остановке
+	ShutdownTimeoutSeconds int `mapstructure:"shutdown_timeout_seconds"`
+	// TrustedProxies - прокси, которым доверяется X-Forwarded-For при определении IP клиента
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+type CORSConfig struct {
+	// AllowedOrigins - источники, которым разрешены запросы с учетными данными
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
 }
 
 type DBConfig struct {
-	Host     string `mapstructure:"DB_HOST"`
-	Port     string `mapstructure:"DB_PORT"`
-	User     string `mapstructure:"DB_USER"`
-	Password string `mapstructure:"DB_PASSWORD"`
-	Name     string `mapstructure:"DB_NAME"`
-	SSLMode  string `mapstructure:"DB_SSLMODE"`
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password" redact:"true"`
+	Name     string `mapstructure:"name"`
+	SSLMode  string `mapstructure:"sslmode"`
+	// AutoMigrate - применять встроенные миграции при старте API
+	AutoMigrate           bool         `mapstructure:"auto_migrate"`
+	ConnectTimeoutSeconds int          `mapstructure:"connect_timeout_seconds"`
+	Pool                  DBPoolConfig `mapstructure:"pool"`
+}
+
+// DBPoolConfig - размер и время жизни соединений pgxpool; 0 - значение pgx по умолчанию
+type DBPoolConfig struct {
+	MaxConns               int32 `mapstructure:"max_conns"`
+	MinConns               int32 `mapstructure:"min_conns"`
+	MaxConnLifetimeMinutes int   `mapstructure:"max_conn_lifetime_minutes"`
+	MaxConnIdleMinutes     int   `mapstructure:"max_conn_idle_minutes"`
 }
 
 type JWTConfig struct {
-	SecretKey string `mapstructure:"JWT_SECRET"`
-	ExpiresIn int    `mapstructure:"JWT_EXPIRES_IN"`
+	SecretKey string `mapstructure:"secret" redact:"true"`
+	// ExpiresIn - время жизни токена в часах
+	ExpiresIn int `mapstructure:"expires_in"`
 }
 
-func Load() (*Config, error) {
-	viper.SetConfigType("env")
-	viper.AddConfigPath(".")
-	viper.AddConfigPath("./configs")
-
-	// Загрузка .env файла
-	viper.SetConfigFile(".env")
-	if err := viper.ReadInConfig(); err != nil {
-		log.Printf("Warning: .env file not found: %v", err)
-	} else {
-		log.Printf("Successfully loaded .env file")
-	}
-
-	viper.AutomaticEnv()
-
-	// Установка значений по умолчанию
-	viper.SetDefault("SERVER_PORT", "8080")
-	viper.SetDefault("GIN_MODE", "debug")
-	viper.SetDefault("DB_HOST", "localhost")
-	viper.SetDefault("DB_PORT", "5432")
-	viper.SetDefault("DB_SSLMODE", "disable")
-	viper.SetDefault("JWT_EXPIRES_IN", 24)
-	viper.SetDefault("JWT_SECRET", "your-secret-key-change-in-production")
-
-	// Отладка: выводим загруженные значения
-	log.Printf("DB_HOST: %s", viper.GetString("DB_HOST"))
-	log.Printf("DB_USER: %s", viper.GetString("DB_USER"))
-	log.Printf("DB_PASSWORD: %s", viper.GetString("DB_PASSWORD"))
-	log.Printf("DB_NAME: %s", viper.GetString("DB_NAME"))
-	log.Printf("SERVER_PORT: %s", viper.GetString("SERVER_PORT"))
-	log.Printf("GIN_MODE: %s", viper.GetString("GIN_MODE"))
-	log.Printf("JWT_SECRET: %s", viper.GetString("JWT_SECRET"))
-	log.Printf("JWT_EXPIRES_IN: %d", viper.GetInt("JWT_EXPIRES_IN"))
+type RecommendationsConfig struct {
+	IntervalMinutes int `mapstructure:"interval_minutes"`
+	TopN            int `mapstructure:"top_n"`
+}
 
-	var config Config
-	if err := viper.Unmarshal(&config.Server); err != nil {
+type StorageConfig struct {
+	Driver              string `mapstructure:"driver"`
+	LocalDir            string `mapstructure:"local_dir"`
+	PublicURL           string `mapstructure:"public_url"`
+	SignedURLTTLMinutes int    `mapstructure:"signed_url_ttl_minutes"`
+	S3Endpoint          string `mapstructure:"s3_endpoint"`
+	S3Region            string `mapstructure:"s3_region"`
+	S3Bucket            string `mapstructure:"s3_bucket"`
+	S3AccessKey         string `mapstructure:"s3_access_key" redact:"true"`
+	S3SecretKey         string `mapstructure:"s3_secret_key" redact:"true"`
+	S3UseSSL            bool   `mapstructure:"s3_use_ssl"`
+}
+
+type LogConfig struct {
+	// Level - debug, info, warn, error
+	Level string `mapstructure:"level"`
+	// Format - text или json
+	Format string `mapstructure:"format"`
+}
+
+type MetricsConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Port - отдельный порт для /metrics; пустое значение - основной порт API (запрещено в prod)
+	Port string `mapstructure:"port"`
+	Path string `mapstructure:"path"`
+	// PprofEnabled - подключить /debug/pprof (только для администраторов)
+	PprofEnabled bool `mapstructure:"pprof_enabled"`
+}
+
+type TracingConfig struct {
+	// Exporter - none, stdout или otlp
+	Exporter    string `mapstructure:"exporter"`
+	ServiceName string `mapstructure:"service_name"`
+	// OTLPEndpoint - URL коллектора OTLP/HTTP; пустое значение - OTEL_EXPORTER_OTLP_ENDPOINT
+	OTLPEndpoint string `mapstructure:"otlp_endpoint"`
+	// SampleRatio - доля записываемых трассировок (0..1)
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Store - memory (на реплику) или postgres (общий для всех реплик)
+	Store string `mapstructure:"store"`
+	// Политики групп маршрутов
+	Default RateLimitPolicy `mapstructure:"default"` // весь /api
+	Auth    RateLimitPolicy `mapstructure:"auth"`    // регистрация, вход, обновление токена
+	Write   RateLimitPolicy `mapstructure:"write"`   // рецензии, цитаты, подписки
+	Search  RateLimitPolicy `mapstructure:"search"`  // каталог и поиск
+}
+
+// RateLimitPolicy - корзина токенов: емкость Burst, пополнение RequestsPerMinute в минуту
+type RateLimitPolicy struct {
+	RequestsPerMinute int `mapstructure:"requests_per_minute"`
+	Burst             int `mapstructure:"burst"`
+	// Key - ip, user (ID пользователя, для анонимных - IP) или token (API токен, для анонимных - IP)
+	Key string `mapstructure:"key"`
+}
+
+// CacheConfig - кэш книг и глав в памяти процесса
+type CacheConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// TTLSeconds - время жизни записи: за это время изменения на других репликах
+	// и в обход репозитория книг (пересчет рейтинга) становятся видны
+	TTLSeconds int `mapstructure:"ttl_seconds"`
+	MaxBooks   int `mapstructure:"max_books"`
+	// MaxPartsMB - лимит суммарного текста закэшированных глав
+	MaxPartsMB int `mapstructure:"max_parts_mb"`
+}
+
+// TrashConfig - хранение мягко удаленных сущностей
+type TrashConfig struct {
+	// RetentionDays - через сколько дней после удаления сущность удаляется окончательно
+	RetentionDays int `mapstructure:"retention_days"`
+	// PurgeIntervalMinutes - период задачи очистки (0 - задача не запускается)
+	PurgeIntervalMinutes int `mapstructure:"purge_interval_minutes"`
+}
+
+// VerificationConfig - верификация книг: голосование сообщества и автоматическая проверка
+type VerificationConfig struct {
+	// VoteThreshold - перевес голосов доверенных пользователей, при котором предложение принимается (или отклоняется)
+	VoteThreshold int `mapstructure:"vote_threshold"`
+	// TrustedMinAccountDays и TrustedMinReviews - когда голос обычного пользователя считается доверенным
+	// (голоса модераторов и администраторов доверенные всегда)
+	TrustedMinAccountDays int `mapstructure:"trusted_min_account_days"`
+	TrustedMinReviews     int `mapstructure:"trusted_min_reviews"`
+	// Verifier - none, fake (локальные правила для разработки) или http (внешний сервис проверки)
+	Verifier               string `mapstructure:"verifier"`
+	VerifierURL            string `mapstructure:"verifier_url"`
+	VerifierToken          string `mapstructure:"verifier_token" redact:"true"`
+	VerifierTimeoutSeconds int    `mapstructure:"verifier_timeout_seconds"`
+	// AIMinConfidence - минимальная уверенность проверки для верификации типа AI
+	AIMinConfidence float64 `mapstructure:"ai_min_confidence"`
+}
+
+// ModerationConfig - жалобы на контент: автоматическое скрытие и блокировка за нарушения
+type ModerationConfig struct {
+	// AutoHideReports - число жалоб разных пользователей, после которого контент скрывается (0 - не скрывать)
+	AutoHideReports int `mapstructure:"auto_hide_reports"`
+	// StrikesToSuspend - каждое такое число подтвержденных нарушений блокирует аккаунт (0 - не блокировать)
+	StrikesToSuspend int `mapstructure:"strikes_to_suspend"`
+	// SuspensionDays - срок автоматической блокировки
+	SuspensionDays int `mapstructure:"suspension_days"`
+}
+
+// setting - параметр конфигурации: ключ в файле, переменная окружения и значение по умолчанию
+type setting struct {
+	key string
+	env string
+	def any
+}
+
+// settings - все параметры конфигурации. Переменные окружения сохраняют прежние имена,
+// поэтому существующие .env файлы продолжают работать.
+var settings = []setting{
+	{"server.port", "SERVER_PORT", "8080"},
+	{"server.mode", "GIN_MODE", "debug"},
+	{"server.read_header_timeout_seconds", "SERVER_READ_HEADER_TIMEOUT_SECONDS", 10},
+	{"server.read_timeout_seconds", "SERVER_READ_TIMEOUT_SECONDS", 15},
+	{"server.write_timeout_seconds", "SERVER_WRITE_TIMEOUT_SECONDS", 60},
+	{"server.idle_timeout_seconds", "SERVER_IDLE_TIMEOUT_SECONDS", 120},
+	{"server.upload_timeout_seconds", "SERVER_UPLOAD_TIMEOUT_SECONDS", 300},
+	{"server.drain_delay_seconds", "SERVER_DRAIN_DELAY_SECONDS", 5},
+	{"server.shutdown_timeout_seconds", "SERVER_SHUTDOWN_TIMEOUT_SECONDS", 30},
+	{"server.trusted_proxies", "SERVER_TRUSTED_PROXIES", []string{}},
+
+	{"cors.allowed_origins", "CORS_ALLOWED_ORIGINS", []string{
+		"http://localhost:3000", // React dev server
+		"http://localhost:5173", // Vite dev server
+		"http://localhost:8080", // Swagger UI
+	}},
+
+	{"database.host", "DB_HOST", "localhost"},
+	{"database.port", "DB_PORT", "5432"},
+	{"database.user", "DB_USER", ""},
+	{"database.password", "DB_PASSWORD", ""},
+	{"database.name", "DB_NAME", ""},
+	{"database.sslmode", "DB_SSLMODE", "disable"},
+	{"database.auto_migrate", "DB_AUTO_MIGRATE", false},
+	{"database.connect_timeout_seconds", "DB_CONNECT_TIMEOUT_SECONDS", 10},
+	{"database.pool.max_conns", "DB_POOL_MAX_CONNS", 0},
+	{"database.pool.min_conns", "DB_POOL_MIN_CONNS", 0},
+	{"database.pool.max_conn_lifetime_minutes", "DB_POOL_MAX_CONN_LIFETIME_MINUTES", 60},
+	{"database.pool.max_conn_idle_minutes", "DB_POOL_MAX_CONN_IDLE_MINUTES", 30},
+
+	{"jwt.secret", "JWT_SECRET", ""},
+	{"jwt.expires_in", "JWT_EXPIRES_IN", 24},
+
+	{"recommendations.interval_minutes", "RECOMMENDATIONS_INTERVAL_MINUTES", 60},
+	{"recommendations.top_n", "RECOMMENDATIONS_TOP_N", 20},
+
+	{"storage.driver", "STORAGE_DRIVER", "local"},
+	{"storage.local_dir", "STORAGE_LOCAL_DIR", "./uploads"},
+	{"storage.public_url", "STORAGE_PUBLIC_URL", ""},
+	{"storage.signed_url_ttl_minutes", "STORAGE_SIGNED_URL_TTL_MINUTES", 15},
+	{"storage.s3_endpoint", "S3_ENDPOINT", ""},
+	{"storage.s3_region", "S3_REGION", "us-east-1"},
+	{"storage.s3_bucket", "S3_BUCKET", ""},
+	{"storage.s3_access_key", "S3_ACCESS_KEY", ""},
+	{"storage.s3_secret_key", "S3_SECRET_KEY", ""},
+	{"storage.s3_use_ssl", "S3_USE_SSL", true},
+
+	{"log.level", "LOG_LEVEL", "info"},
+	{"log.format", "LOG_FORMAT", "text"},
+
+	{"metrics.enabled", "METRICS_ENABLED", true},
+	{"metrics.port", "METRICS_PORT", ""},
+	{"metrics.path", "METRICS_PATH", "/metrics"},
+	{"metrics.pprof_enabled", "PPROF_ENABLED", false},
+
+	{"tracing.exporter", "TRACING_EXPORTER", "none"},
+	{"tracing.service_name", "TRACING_SERVICE_NAME", "bookvision-api"},
+	{"tracing.otlp_endpoint", "TRACING_OTLP_ENDPOINT", ""},
+	{"tracing.sample_ratio", "TRACING_SAMPLE_RATIO", 1.0},
+
+	{"rate_limit.enabled", "RATE_LIMIT_ENABLED", true},
+	{"rate_limit.store", "RATE_LIMIT_STORE", "memory"},
+	{"rate_limit.default.requests_per_minute", "RATE_LIMIT_DEFAULT_REQUESTS_PER_MINUTE", 300},
+	{"rate_limit.default.burst", "RATE_LIMIT_DEFAULT_BURST", 100},
+	{"rate_limit.default.key", "RATE_LIMIT_DEFAULT_KEY", "ip"},
+	{"rate_limit.auth.requests_per_minute", "RATE_LIMIT_AUTH_REQUESTS_PER_MINUTE", 10},
+	{"rate_limit.auth.burst", "RATE_LIMIT_AUTH_BURST", 5},
+	{"rate_limit.auth.key", "RATE_LIMIT_AUTH_KEY", "ip"},
+	{"rate_limit.write.requests_per_minute", "RATE_LIMIT_WRITE_REQUESTS_PER_MINUTE", 30},
+	{"rate_limit.write.burst", "RATE_LIMIT_WRITE_BURST", 10},
+	{"rate_limit.write.key", "RATE_LIMIT_WRITE_KEY", "user"},
+	{"rate_limit.search.requests_per_minute", "RATE_LIMIT_SEARCH_REQUESTS_PER_MINUTE", 60},
+	{"rate_limit.search.burst", "RATE_LIMIT_SEARCH_BURST", 20},
+	{"rate_limit.search.key", "RATE_LIMIT_SEARCH_KEY", "ip"},
+	{"cache.enabled", "CACHE_ENABLED", true},
+	{"cache.ttl_seconds", "CACHE_TTL_SECONDS", 60},
+	{"cache.max_books", "CACHE_MAX_BOOKS", 10000},
+	{"cache.max_parts_mb", "CACHE_MAX_PARTS_MB", 64},
+
+	{"trash.retention_days", "TRASH_RETENTION_DAYS", 30},
+	{"trash.purge_interval_minutes", "TRASH_PURGE_INTERVAL_MINUTES", 60},
+
+	{"verification.vote_threshold", "VERIFICATION_VOTE_THRESHOLD", 3},
+	{"verification.trusted_min_account_days", "VERIFICATION_TRUSTED_MIN_ACCOUNT_DAYS", 30},
+	{"verification.trusted_min_reviews", "VERIFICATION_TRUSTED_MIN_REVIEWS", 3},
+	{"verification.verifier", "VERIFICATION_VERIFIER", "none"},
+	{"verification.verifier_url", "VERIFICATION_VERIFIER_URL", ""},
+	{"verification.verifier_token", "VERIFICATION_VERIFIER_TOKEN", ""},
+	{"verification.verifier_timeout_seconds", "VERIFICATION_VERIFIER_TIMEOUT_SECONDS", 10},
+	{"verification.ai_min_confidence", "VERIFICATION_AI_MIN_CONFIDENCE", 0.8},
+
+	{"moderation.auto_hide_reports", "MODERATION_AUTO_HIDE_REPORTS", 3},
+	{"moderation.strikes_to_suspend", "MODERATION_STRIKES_TO_SUSPEND", 3},
+	{"moderation.suspension_days", "MODERATION_SUSPENSION_DAYS", 7},
+}
+
+// profileDefaults - значения по умолчанию, зависящие от профиля (поверх settings)
+var profileDefaults = map[string]map[string]any{
+	ProfileDev: {
+		"jwt.secret":                 devJWTSecret,
+		"verification.verifier":      "fake",
+		"server.drain_delay_seconds": 0,
+	},
+	ProfileTest: {
+		"server.mode":                      "test",
+		"jwt.secret":                       devJWTSecret,
+		"log.level":                        "warn",
+		"recommendations.interval_minutes": 0,
+		"rate_limit.enabled":               false,
+		"trash.purge_interval_minutes":     0,
+		"verification.verifier":            "fake",
+		"server.drain_delay_seconds":       0,
+	},
+	ProfileProd: {
+		"server.mode":  "release",
+		"log.format":   "json",
+		"metrics.port": "9090",
+	},
+}
+
+// configPaths - директории поиска config.{yaml,yml,toml,json}
+var configPaths = []string{".", "./configs"}
+
+// Load - загрузка конфигурации слоями (каждый следующий переопределяет предыдущий):
+// значения по умолчанию, значения профиля, config.yaml, config.<profile>.yaml, .env, переменные окружения.
+// Профиль задается APP_ENV (dev по умолчанию), явный путь к файлу - CONFIG_FILE.
+// Конфигурация проверяется (Validate) до возврата.
+func Load() (*Config, error) {
+	// .env только дополняет окружение: уже заданные переменные не перезаписываются
+	if err := loadDotEnv(".env"); err != nil {
 		return nil, err
 	}
-	if err := viper.Unmarshal(&config.Database); err != nil {
-		return nil, err
+
+	profile := strings.ToLower(os.Getenv("APP_ENV"))
+	if profile == "" {
+		profile = ProfileDev
+	}
+
+	v := viper.New()
+	for _, s := range settings {
+		v.SetDefault(s.key, s.def)
+		if err := v.BindEnv(s.key, s.env); err != nil {
+			return nil, err
+		}
 	}
-	if err := viper.Unmarshal(&config.JWT); err != nil {
+	for key, value := range profileDefaults[profile] {
+		v.SetDefault(key, value)
+	}
+
+	if err := readConfigFiles(v, profile); err != nil {
 		return nil, err
 	}
 
-	// Отладка: выводим значения из структуры
-	log.Printf("Config DB_HOST: %s", config.Database.Host)
-	log.Printf("Config DB_USER: %s", config.Database.User)
-	log.Printf("Config DB_PASSWORD: %s", config.Database.Password)
-	log.Printf("Config DB_NAME: %s", config.Database.Name)
-	log.Printf("Config DB_PORT: %s", config.Database.Port)
-	log.Printf("Config DB_SSLMODE: %s", config.Database.SSLMode)
-	log.Printf("Config SERVER_PORT: %s", config.Server.Port)
-	log.Printf("Config GIN_MODE: %s", config.Server.Mode)
+	var config Config
+	if err := v.Unmarshal(&config); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+	config.Profile = profile
 
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if profile != ProfileProd && config.JWT.SecretKey == devJWTSecret {
+		slog.Warn("using development JWT secret; set JWT_SECRET outside local development", "profile", profile)
+	}
 	return &config, nil
 }
 
+// readConfigFiles - общий файл конфигурации и файл профиля (оба необязательны).
+// Если CONFIG_FILE задан, файл обязан существовать, а файл профиля ищется рядом с ним.
+func readConfigFiles(v *viper.Viper, profile string) error {
+	paths := configPaths
+	if file := os.Getenv("CONFIG_FILE"); file != "" {
+		base := viper.New()
+		base.SetConfigFile(file)
+		if err := base.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to read config file %s: %w", file, err)
+		}
+		if err := v.MergeConfigMap(base.AllSettings()); err != nil {
+			return err
+		}
+		paths = append([]string{filepath.Dir(file)}, paths...)
+	} else if err := mergeConfigFile(v, "config", paths); err != nil {
+		return err
+	}
+	return mergeConfigFile(v, "config."+profile, paths)
+}
+
+// mergeConfigFile - чтение файла name.{yaml,yml,toml,json} из paths поверх v
+func mergeConfigFile(v *viper.Viper, name string, paths []string) error {
+	file := viper.New()
+	file.SetConfigName(name)
+	for _, path := range paths {
+		file.AddConfigPath(path)
+	}
+
+	var notFound viper.ConfigFileNotFoundError
+	if err := file.ReadInConfig(); errors.As(err, &notFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", name, err)
+	}
+	slog.Info("config file loaded", "file", file.ConfigFileUsed())
+	return v.MergeConfigMap(file.AllSettings())
+}
+
+// loadDotEnv - переменные из .env попадают в окружение процесса, если они еще не заданы
+func loadDotEnv(path string) error {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	dotenv := viper.New()
+	dotenv.SetConfigFile(path)
+	dotenv.SetConfigType("env")
+	if err := dotenv.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	for _, key := range dotenv.AllKeys() {
+		name := strings.ToUpper(key)
+		if _, ok := os.LookupEnv(name); !ok {
+			os.Setenv(name, dotenv.GetString(key))
+		}
+	}
+	return nil
+}
+
+// DatabaseURL - строка подключения postgres:// из параметров Database (с экранированием пароля)
 func (c *Config) DatabaseURL() string {
-	// Используем прямой доступ к viper вместо полей структуры
-	user := viper.GetString("DB_USER")
-	password := viper.GetString("DB_PASSWORD")
-	host := viper.GetString("DB_HOST")
-	port := viper.GetString("DB_PORT")
-	name := viper.GetString("DB_NAME")
-	sslMode := viper.GetString("DB_SSLMODE")
-
-	// Отладка: выводим значения из структуры
-	log.Printf("Config DB_HOST: %s", c.Database.Host)
-	log.Printf("Config DB_USER: %s", c.Database.User)
-	log.Printf("Config DB_PASSWORD: %s", c.Database.Password)
-	log.Printf("Config DB_NAME: %s", c.Database.Name)
-	log.Printf("Config DB_PORT: %s", c.Database.Port)
-	log.Printf("Config DB_SSLMODE: %s", c.Database.SSLMode)
-
-	// Отладка: выводим значения из viper
-	log.Printf("Viper DB_HOST: %s", host)
-	log.Printf("Viper DB_USER: %s", user)
-	log.Printf("Viper DB_PASSWORD: %s", password)
-	log.Printf("Viper DB_NAME: %s", name)
-
-	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		user,
-		password,
-		host,
-		port,
-		name,
-		sslMode,
-	)
+	u := url.URL{
+		Scheme:   "postgres",
+		Host:     net.JoinHostPort(c.Database.Host, c.Database.Port),
+		Path:     "/" + c.Database.Name,
+		RawQuery: url.Values{"sslmode": {c.Database.SSLMode}}.Encode(),
+	}
+	if c.Database.User != "" {
+		u.User = url.UserPassword(c.Database.User, c.Database.Password)
+	}
+	return u.String()
 }
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"log/slog"
+	"reflect"
+)
+
+// redacted - замена секретных значений при выводе конфигурации
+const redacted = "[REDACTED]"
+
+// LogValue - действующая конфигурация для лога: группы по секциям с ключами как в файле
+// конфигурации; поля с тегом redact:"true" заменяются на [REDACTED].
+func (c *Config) LogValue() slog.Value {
+	return structValue(reflect.ValueOf(*c))
+}
+
+// structValue - группа атрибутов из полей структуры по тегам mapstructure
+func structValue(v reflect.Value) slog.Value {
+	t := v.Type()
+	attrs := make([]slog.Attr, 0, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		key := field.Tag.Get("mapstructure")
+		if key == "" || !field.IsExported() {
+			continue
+		}
+
+		value := v.Field(i)
+		switch {
+		case field.Tag.Get("redact") == "true" && !value.IsZero():
+			attrs = append(attrs, slog.String(key, redacted))
+		case value.Kind() == reflect.Struct:
+			attrs = append(attrs, slog.Attr{Key: key, Value: structValue(value)})
+		default:
+			attrs = append(attrs, slog.Any(key, value.Interface()))
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidConfig - конфигурация не прошла проверку при запуске
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// insecureSecrets - известные значения из примеров и старых значений по умолчанию
+var insecureSecrets = []string{
+	devJWTSecret,
+	"your-secret-key-change-in-production",
+	"minioadmin",
+	"password",
+	"postgres",
+	"secret",
+}
+
+// minProdSecretLength - минимальная длина JWT секрета в prod
+const minProdSecretLength = 32
+
+// Validate - проверка конфигурации. В prod дополнительно запрещены секреты по умолчанию,
+// пустые учетные данные базы данных, CORS "*" и /metrics без авторизации на публичном порту API.
+// Все найденные проблемы возвращаются одной ошибкой ErrInvalidConfig.
+func (c *Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(slices.Contains([]string{ProfileDev, ProfileTest, ProfileProd}, c.Profile),
+		"APP_ENV must be one of: dev, test, prod (got %q)", c.Profile)
+
+	check(validPort(c.Server.Port), "server.port must be a TCP port (got %q)", c.Server.Port)
+	check(slices.Contains([]string{"debug", "release", "test"}, c.Server.Mode),
+		"server.mode must be one of: debug, release, test (got %q)", c.Server.Mode)
+	check(c.Server.ReadHeaderTimeoutSeconds >= 0 && c.Server.ReadTimeoutSeconds >= 0 && c.Server.WriteTimeoutSeconds >= 0 &&
+		c.Server.IdleTimeoutSeconds >= 0 && c.Server.UploadTimeoutSeconds >= 0 && c.Server.DrainDelaySeconds >= 0,
+		"server timeouts must not be negative")
+	check(c.Server.ShutdownTimeoutSeconds > 0, "server.shutdown_timeout_seconds must be positive")
+
+	check(validPort(c.Database.Port), "database.port must be a TCP port (got %q)", c.Database.Port)
+	check(c.Database.Host != "", "database.host is required")
+	check(c.Database.ConnectTimeoutSeconds > 0, "database.connect_timeout_seconds must be positive")
+	pool := c.Database.Pool
+	check(pool.MaxConns >= 0 && pool.MinConns >= 0, "database.pool sizes must not be negative")
+	check(pool.MaxConns == 0 || pool.MinConns <= pool.MaxConns,
+		"database.pool.min_conns (%d) must not exceed max_conns (%d)", pool.MinConns, pool.MaxConns)
+
+	check(c.JWT.SecretKey != "", "jwt.secret (JWT_SECRET) is required")
+	check(c.JWT.ExpiresIn > 0, "jwt.expires_in must be positive")
+
+	check(c.Storage.Driver == "local" || c.Storage.Driver == "s3",
+		"storage.driver must be local or s3 (got %q)", c.Storage.Driver)
+	if c.Storage.Driver == "s3" {
+		check(c.Storage.S3Endpoint != "" && c.Storage.S3Bucket != "", "storage.s3_endpoint and storage.s3_bucket are required for the s3 driver")
+	}
+
+	check(c.Metrics.Port == "" || validPort(c.Metrics.Port), "metrics.port must be a TCP port (got %q)", c.Metrics.Port)
+	check(strings.HasPrefix(c.Metrics.Path, "/"), "metrics.path must start with / (got %q)", c.Metrics.Path)
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio must be between 0 and 1")
+
+	check(c.RateLimit.Store == "memory" || c.RateLimit.Store == "postgres",
+		"rate_limit.store must be memory or postgres (got %q)", c.RateLimit.Store)
+	for _, p := range []struct {
+		name   string
+		policy RateLimitPolicy
+	}{
+		{"default", c.RateLimit.Default},
+		{"auth", c.RateLimit.Auth},
+		{"write", c.RateLimit.Write},
+		{"search", c.RateLimit.Search},
+	} {
+		check(p.policy.RequestsPerMinute > 0 && p.policy.Burst > 0,
+			"rate_limit.%s.requests_per_minute and burst must be positive", p.name)
+		check(slices.Contains([]string{"ip", "user", "token"}, p.policy.Key),
+			"rate_limit.%s.key must be one of: ip, user, token (got %q)", p.name, p.policy.Key)
+	}
+
+	if c.Cache.Enabled {
+		check(c.Cache.TTLSeconds > 0, "cache.ttl_seconds must be positive")
+		check(c.Cache.MaxBooks > 0 && c.Cache.MaxPartsMB > 0, "cache.max_books and cache.max_parts_mb must be positive")
+	}
+
+	check(c.Trash.RetentionDays > 0, "trash.retention_days must be positive")
+	check(c.Trash.PurgeIntervalMinutes >= 0, "trash.purge_interval_minutes must not be negative")
+
+	check(c.Verification.VoteThreshold > 0, "verification.vote_threshold must be positive")
+	check(c.Verification.TrustedMinAccountDays >= 0 && c.Verification.TrustedMinReviews >= 0,
+		"verification.trusted_min_account_days and trusted_min_reviews must not be negative")
+	check(slices.Contains([]string{"none", "fake", "http"}, c.Verification.Verifier),
+		"verification.verifier must be one of: none, fake, http (got %q)", c.Verification.Verifier)
+	if c.Verification.Verifier == "http" {
+		check(c.Verification.VerifierURL != "", "verification.verifier_url is required for the http verifier")
+		check(c.Verification.VerifierTimeoutSeconds > 0, "verification.verifier_timeout_seconds must be positive")
+	}
+	check(c.Verification.AIMinConfidence >= 0 && c.Verification.AIMinConfidence <= 1,
+		"verification.ai_min_confidence must be between 0 and 1")
+
+	check(c.Moderation.AutoHideReports >= 0, "moderation.auto_hide_reports must not be negative")
+	check(c.Moderation.StrikesToSuspend >= 0, "moderation.strikes_to_suspend must not be negative")
+	check(c.Moderation.SuspensionDays > 0, "moderation.suspension_days must be positive")
+
+	if c.Profile == ProfileProd {
+		check(c.JWT.SecretKey == "" || !insecure(c.JWT.SecretKey) && len(c.JWT.SecretKey) >= minProdSecretLength,
+			"jwt.secret must be a random value of at least %d characters in prod", minProdSecretLength)
+		check(c.Database.User != "" && c.Database.Password != "" && c.Database.Name != "",
+			"database.user, database.password and database.name are required in prod")
+		check(!insecure(c.Database.Password), "database.password uses a well-known default value")
+		if c.Storage.Driver == "s3" {
+			check(c.Storage.S3AccessKey != "" && c.Storage.S3SecretKey != "" && !insecure(c.Storage.S3SecretKey),
+				"storage S3 credentials must be set and must not use default values in prod")
+		}
+		check(!slices.Contains(c.CORS.AllowedOrigins, "*"), "cors.allowed_origins must not contain * in prod")
+		check(c.Server.Mode == "release", "server.mode must be release in prod")
+		check(c.Verification.Verifier != "fake", "verification.verifier must not be fake in prod")
+		check(!c.Metrics.Enabled || c.Metrics.Port != "" && c.Metrics.Port != c.Server.Port,
+			"metrics.port must be a separate internal port in prod; /metrics has no authentication")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w:\n  - %s", ErrInvalidConfig, strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// validPort - номер TCP порта 1..65535
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+// insecure - пустое или известное значение из примеров
+func insecure(secret string) bool {
+	return secret == "" || slices.Contains(insecureSecrets, secret)
+}
@@ -0,0 +1,341 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: articles.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+const createArticle = `-- name: CreateArticle :one
+INSERT INTO articles (
+    title, type, author_id, book_id, excerpt, reading_minutes, cover_url,
+    verified, verification_type, no_spoilers, readiness, content
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+)
+RETURNING id, created_at, version, updated_at
+`
+
+type CreateArticleParams struct {
+	Title            string                   `json:"title"`
+	Type             string                   `json:"type"`
+	AuthorID         *string                  `json:"author_id"`
+	BookID           *string                  `json:"book_id"`
+	Excerpt          string                   `json:"excerpt"`
+	ReadingMinutes   *int32                   `json:"reading_minutes"`
+	CoverURL         *string                  `json:"cover_url"`
+	Verified         *bool                    `json:"verified"`
+	VerificationType *models.VerificationType `json:"verification_type"`
+	NoSpoilers       bool                     `json:"no_spoilers"`
+	Readiness        *string                  `json:"readiness"`
+	Content          []byte                   `json:"content"`
+}
+
+type CreateArticleRow struct {
+	ID        string     `json:"id"`
+	CreatedAt *time.Time `json:"created_at"`
+	Version   int32      `json:"version"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (q *Queries) CreateArticle(ctx context.Context, arg CreateArticleParams) (CreateArticleRow, error) {
+	row := q.db.QueryRow(ctx, createArticle,
+		arg.Title,
+		arg.Type,
+		arg.AuthorID,
+		arg.BookID,
+		arg.Excerpt,
+		arg.ReadingMinutes,
+		arg.CoverURL,
+		arg.Verified,
+		arg.VerificationType,
+		arg.NoSpoilers,
+		arg.Readiness,
+		arg.Content,
+	)
+	var i CreateArticleRow
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.Version,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteArticle = `-- name: DeleteArticle :execrows
+UPDATE articles SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+// Мягкое удаление: статья переносится в корзину
+func (q *Queries) DeleteArticle(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteArticle, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getArticle = `-- name: GetArticle :one
+SELECT id, title, type, author_id, book_id, excerpt, created_at, likes, views, reading_minutes, cover_url, verified, verification_type, no_spoilers, readiness, content, updated_at, version, deleted_at, hidden_at FROM articles
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetArticle(ctx context.Context, id string) (Article, error) {
+	row := q.db.QueryRow(ctx, getArticle, id)
+	var i Article
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Type,
+		&i.AuthorID,
+		&i.BookID,
+		&i.Excerpt,
+		&i.CreatedAt,
+		&i.Likes,
+		&i.Views,
+		&i.ReadingMinutes,
+		&i.CoverURL,
+		&i.Verified,
+		&i.VerificationType,
+		&i.NoSpoilers,
+		&i.Readiness,
+		&i.Content,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.HiddenAt,
+	)
+	return i, err
+}
+
+const getVisibleArticle = `-- name: GetVisibleArticle :one
+SELECT id, title, type, author_id, book_id, excerpt, created_at, likes, views, reading_minutes, cover_url, verified, verification_type, no_spoilers, readiness, content, updated_at, version, deleted_at, hidden_at FROM articles
+WHERE id = $1 AND deleted_at IS NULL AND hidden_at IS NULL
+`
+
+// Скрытые по жалобам статьи недоступны читателям, пока модератор не отклонит жалобы
+func (q *Queries) GetVisibleArticle(ctx context.Context, id string) (Article, error) {
+	row := q.db.QueryRow(ctx, getVisibleArticle, id)
+	var i Article
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Type,
+		&i.AuthorID,
+		&i.BookID,
+		&i.Excerpt,
+		&i.CreatedAt,
+		&i.Likes,
+		&i.Views,
+		&i.ReadingMinutes,
+		&i.CoverURL,
+		&i.Verified,
+		&i.VerificationType,
+		&i.NoSpoilers,
+		&i.Readiness,
+		&i.Content,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+		&i.HiddenAt,
+	)
+	return i, err
+}
+
+const listArticles = `-- name: ListArticles :many
+SELECT id, title, type, author_id, book_id, excerpt, likes, views, cover_url, version, updated_at
+FROM articles
+WHERE deleted_at IS NULL AND hidden_at IS NULL
+ORDER BY
+    CASE WHEN $1::text = 'likes' AND $2::bool THEN likes END ASC,
+    CASE WHEN $1::text = 'likes' AND NOT $2::bool THEN likes END DESC,
+    CASE WHEN $1::text = 'views' AND $2::bool THEN views END ASC,
+    CASE WHEN $1::text = 'views' AND NOT $2::bool THEN views END DESC,
+    CASE WHEN $2::bool THEN created_at END ASC,
+    created_at DESC
+LIMIT $3
+`
+
+type ListArticlesParams struct {
+	SortBy    string `json:"sort_by"`
+	Ascending bool   `json:"ascending"`
+	RowLimit  int32  `json:"row_limit"`
+}
+
+type ListArticlesRow struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Type      string    `json:"type"`
+	AuthorID  *string   `json:"author_id"`
+	BookID    *string   `json:"book_id"`
+	Excerpt   string    `json:"excerpt"`
+	Likes     *int32    `json:"likes"`
+	Views     *int32    `json:"views"`
+	CoverURL  *string   `json:"cover_url"`
+	Version   int32     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Сортировка задается параметром, а не подстановкой в текст запроса:
+// неизвестное поле сортирует по created_at.
+func (q *Queries) ListArticles(ctx context.Context, arg ListArticlesParams) ([]ListArticlesRow, error) {
+	rows, err := q.db.Query(ctx, listArticles, arg.SortBy, arg.Ascending, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArticlesRow
+	for rows.Next() {
+		var i ListArticlesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Type,
+			&i.AuthorID,
+			&i.BookID,
+			&i.Excerpt,
+			&i.Likes,
+			&i.Views,
+			&i.CoverURL,
+			&i.Version,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateArticle = `-- name: UpdateArticle :one
+UPDATE articles SET
+    title = $2, type = $3, excerpt = $4, reading_minutes = $5, cover_url = $6,
+    verified = $7, verification_type = $8, no_spoilers = $9, readiness = $10,
+    version = version + 1
+WHERE id = $1 AND version = $11 AND deleted_at IS NULL
+RETURNING version, updated_at
+`
+
+type UpdateArticleParams struct {
+	ID               string                   `json:"id"`
+	Title            string                   `json:"title"`
+	Type             string                   `json:"type"`
+	Excerpt          string                   `json:"excerpt"`
+	ReadingMinutes   *int32                   `json:"reading_minutes"`
+	CoverURL         *string                  `json:"cover_url"`
+	Verified         *bool                    `json:"verified"`
+	VerificationType *models.VerificationType `json:"verification_type"`
+	NoSpoilers       bool                     `json:"no_spoilers"`
+	Readiness        *string                  `json:"readiness"`
+	ExpectedVersion  int32                    `json:"expected_version"`
+}
+
+type UpdateArticleRow struct {
+	Version   int32     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) UpdateArticle(ctx context.Context, arg UpdateArticleParams) (UpdateArticleRow, error) {
+	row := q.db.QueryRow(ctx, updateArticle,
+		arg.ID,
+		arg.Title,
+		arg.Type,
+		arg.Excerpt,
+		arg.ReadingMinutes,
+		arg.CoverURL,
+		arg.Verified,
+		arg.VerificationType,
+		arg.NoSpoilers,
+		arg.Readiness,
+		arg.ExpectedVersion,
+	)
+	var i UpdateArticleRow
+	err := row.Scan(&i.Version, &i.UpdatedAt)
+	return i, err
+}
+
+const updateArticleCoverURL = `-- name: UpdateArticleCoverURL :execrows
+UPDATE articles SET cover_url = $2, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type UpdateArticleCoverURLParams struct {
+	ID       string  `json:"id"`
+	CoverURL *string `json:"cover_url"`
+}
+
+func (q *Queries) UpdateArticleCoverURL(ctx context.Context, arg UpdateArticleCoverURLParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateArticleCoverURL, arg.ID, arg.CoverURL)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertArticle = `-- name: UpsertArticle :exec
+INSERT INTO articles (
+    id, title, type, author_id, book_id, excerpt, created_at, likes, views, reading_minutes,
+    cover_url, verified, verification_type, no_spoilers, readiness, content
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+)
+ON CONFLICT (id) DO UPDATE SET
+    title = EXCLUDED.title, type = EXCLUDED.type, author_id = EXCLUDED.author_id,
+    book_id = EXCLUDED.book_id, excerpt = EXCLUDED.excerpt, created_at = EXCLUDED.created_at,
+    likes = EXCLUDED.likes, views = EXCLUDED.views, reading_minutes = EXCLUDED.reading_minutes,
+    cover_url = EXCLUDED.cover_url, verified = EXCLUDED.verified,
+    verification_type = EXCLUDED.verification_type, no_spoilers = EXCLUDED.no_spoilers,
+    readiness = EXCLUDED.readiness, content = EXCLUDED.content
+`
+
+type UpsertArticleParams struct {
+	ID               string                   `json:"id"`
+	Title            string                   `json:"title"`
+	Type             string                   `json:"type"`
+	AuthorID         *string                  `json:"author_id"`
+	BookID           *string                  `json:"book_id"`
+	Excerpt          string                   `json:"excerpt"`
+	CreatedAt        *time.Time               `json:"created_at"`
+	Likes            *int32                   `json:"likes"`
+	Views            *int32                   `json:"views"`
+	ReadingMinutes   *int32                   `json:"reading_minutes"`
+	CoverURL         *string                  `json:"cover_url"`
+	Verified         *bool                    `json:"verified"`
+	VerificationType *models.VerificationType `json:"verification_type"`
+	NoSpoilers       bool                     `json:"no_spoilers"`
+	Readiness        *string                  `json:"readiness"`
+	Content          []byte                   `json:"content"`
+}
+
+func (q *Queries) UpsertArticle(ctx context.Context, arg UpsertArticleParams) error {
+	_, err := q.db.Exec(ctx, upsertArticle,
+		arg.ID,
+		arg.Title,
+		arg.Type,
+		arg.AuthorID,
+		arg.BookID,
+		arg.Excerpt,
+		arg.CreatedAt,
+		arg.Likes,
+		arg.Views,
+		arg.ReadingMinutes,
+		arg.CoverURL,
+		arg.Verified,
+		arg.VerificationType,
+		arg.NoSpoilers,
+		arg.Readiness,
+		arg.Content,
+	)
+	return err
+}
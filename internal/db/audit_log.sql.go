@@ -0,0 +1,118 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: audit_log.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const createAuditEntry = `-- name: CreateAuditEntry :exec
+INSERT INTO audit_log (
+    actor_id, actor_username, actor_role, action, target_type, target_id,
+    before, after, ip, request_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+)
+`
+
+type CreateAuditEntryParams struct {
+	ActorID       *string `json:"actor_id"`
+	ActorUsername *string `json:"actor_username"`
+	ActorRole     *string `json:"actor_role"`
+	Action        string  `json:"action"`
+	TargetType    string  `json:"target_type"`
+	TargetID      string  `json:"target_id"`
+	Before        []byte  `json:"before"`
+	After         []byte  `json:"after"`
+	IP            *string `json:"ip"`
+	RequestID     *string `json:"request_id"`
+}
+
+func (q *Queries) CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error {
+	_, err := q.db.Exec(ctx, createAuditEntry,
+		arg.ActorID,
+		arg.ActorUsername,
+		arg.ActorRole,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Before,
+		arg.After,
+		arg.IP,
+		arg.RequestID,
+	)
+	return err
+}
+
+const listAuditEntries = `-- name: ListAuditEntries :many
+SELECT id, actor_id, actor_username, actor_role, action, target_type, target_id, before, after, ip, request_id, created_at FROM audit_log
+WHERE ($1::uuid IS NULL OR actor_id = $1::uuid)
+  AND ($2::text IS NULL OR action = $2::text)
+  AND ($3::text IS NULL OR target_type = $3::text)
+  AND ($4::text IS NULL OR target_id = $4::text)
+  AND ($5::timestamptz IS NULL OR created_at >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR created_at < $6::timestamptz)
+  AND ($7::bigint IS NULL OR id < $7::bigint)
+ORDER BY id DESC
+LIMIT $9 OFFSET $8
+`
+
+type ListAuditEntriesParams struct {
+	ActorID     *string    `json:"actor_id"`
+	Action      *string    `json:"action"`
+	TargetType  *string    `json:"target_type"`
+	TargetID    *string    `json:"target_id"`
+	CreatedFrom *time.Time `json:"created_from"`
+	CreatedTo   *time.Time `json:"created_to"`
+	BeforeID    *int64     `json:"before_id"`
+	RowOffset   int32      `json:"row_offset"`
+	RowLimit    int32      `json:"row_limit"`
+}
+
+// Пустой фильтр не ограничивает выборку; before_id - курсор для постраничной выгрузки
+func (q *Queries) ListAuditEntries(ctx context.Context, arg ListAuditEntriesParams) ([]AuditLog, error) {
+	rows, err := q.db.Query(ctx, listAuditEntries,
+		arg.ActorID,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.BeforeID,
+		arg.RowOffset,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.ActorID,
+			&i.ActorUsername,
+			&i.ActorRole,
+			&i.Action,
+			&i.TargetType,
+			&i.TargetID,
+			&i.Before,
+			&i.After,
+			&i.IP,
+			&i.RequestID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
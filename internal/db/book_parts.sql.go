@@ -0,0 +1,215 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: book_parts.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const createBookPart = `-- name: CreateBookPart :one
+INSERT INTO book_parts (
+    id, book_id, title, content, order_num, page_start, page_end, mood_tags, average_rating
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING id, version, updated_at
+`
+
+type CreateBookPartParams struct {
+	ID            string   `json:"id"`
+	BookID        *string  `json:"book_id"`
+	Title         string   `json:"title"`
+	Content       string   `json:"content"`
+	OrderNum      int32    `json:"order_num"`
+	PageStart     *int32   `json:"page_start"`
+	PageEnd       *int32   `json:"page_end"`
+	MoodTags      []string `json:"mood_tags"`
+	AverageRating *float64 `json:"average_rating"`
+}
+
+type CreateBookPartRow struct {
+	ID        string    `json:"id"`
+	Version   int32     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) CreateBookPart(ctx context.Context, arg CreateBookPartParams) (CreateBookPartRow, error) {
+	row := q.db.QueryRow(ctx, createBookPart,
+		arg.ID,
+		arg.BookID,
+		arg.Title,
+		arg.Content,
+		arg.OrderNum,
+		arg.PageStart,
+		arg.PageEnd,
+		arg.MoodTags,
+		arg.AverageRating,
+	)
+	var i CreateBookPartRow
+	err := row.Scan(&i.ID, &i.Version, &i.UpdatedAt)
+	return i, err
+}
+
+const deleteBookPart = `-- name: DeleteBookPart :execrows
+DELETE FROM book_parts
+WHERE id = $1
+`
+
+func (q *Queries) DeleteBookPart(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBookPart, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getBookPart = `-- name: GetBookPart :one
+SELECT id, book_id, title, order_num, page_start, page_end, mood_tags, average_rating, content, updated_at, version FROM book_parts
+WHERE book_parts.id = $1
+  AND EXISTS (SELECT 1 FROM books WHERE books.id = book_parts.book_id AND books.deleted_at IS NULL)
+`
+
+// Части книги в корзине скрыты вместе с ней
+func (q *Queries) GetBookPart(ctx context.Context, id string) (BookPart, error) {
+	row := q.db.QueryRow(ctx, getBookPart, id)
+	var i BookPart
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.Title,
+		&i.OrderNum,
+		&i.PageStart,
+		&i.PageEnd,
+		&i.MoodTags,
+		&i.AverageRating,
+		&i.Content,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const listBookParts = `-- name: ListBookParts :many
+SELECT id, book_id, title, order_num, page_start, page_end, mood_tags, average_rating, content, updated_at, version FROM book_parts
+WHERE book_parts.book_id = $1
+  AND EXISTS (SELECT 1 FROM books WHERE books.id = book_parts.book_id AND books.deleted_at IS NULL)
+ORDER BY order_num
+`
+
+func (q *Queries) ListBookParts(ctx context.Context, bookID *string) ([]BookPart, error) {
+	rows, err := q.db.Query(ctx, listBookParts, bookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BookPart
+	for rows.Next() {
+		var i BookPart
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.Title,
+			&i.OrderNum,
+			&i.PageStart,
+			&i.PageEnd,
+			&i.MoodTags,
+			&i.AverageRating,
+			&i.Content,
+			&i.UpdatedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateBookPart = `-- name: UpdateBookPart :one
+UPDATE book_parts SET
+    title = $2, content = $3, order_num = $4, page_start = $5, page_end = $6,
+    mood_tags = $7, average_rating = $8, version = version + 1
+WHERE book_parts.id = $1 AND book_parts.version = $9
+  AND EXISTS (SELECT 1 FROM books WHERE books.id = book_parts.book_id AND books.deleted_at IS NULL)
+RETURNING version, updated_at
+`
+
+type UpdateBookPartParams struct {
+	ID              string   `json:"id"`
+	Title           string   `json:"title"`
+	Content         string   `json:"content"`
+	OrderNum        int32    `json:"order_num"`
+	PageStart       *int32   `json:"page_start"`
+	PageEnd         *int32   `json:"page_end"`
+	MoodTags        []string `json:"mood_tags"`
+	AverageRating   *float64 `json:"average_rating"`
+	ExpectedVersion int32    `json:"expected_version"`
+}
+
+type UpdateBookPartRow struct {
+	Version   int32     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) UpdateBookPart(ctx context.Context, arg UpdateBookPartParams) (UpdateBookPartRow, error) {
+	row := q.db.QueryRow(ctx, updateBookPart,
+		arg.ID,
+		arg.Title,
+		arg.Content,
+		arg.OrderNum,
+		arg.PageStart,
+		arg.PageEnd,
+		arg.MoodTags,
+		arg.AverageRating,
+		arg.ExpectedVersion,
+	)
+	var i UpdateBookPartRow
+	err := row.Scan(&i.Version, &i.UpdatedAt)
+	return i, err
+}
+
+const upsertBookPart = `-- name: UpsertBookPart :exec
+INSERT INTO book_parts (
+    id, book_id, title, content, order_num, page_start, page_end, mood_tags, average_rating
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (id) DO UPDATE SET
+    book_id = EXCLUDED.book_id, title = EXCLUDED.title, content = EXCLUDED.content,
+    order_num = EXCLUDED.order_num, page_start = EXCLUDED.page_start, page_end = EXCLUDED.page_end,
+    mood_tags = EXCLUDED.mood_tags, average_rating = EXCLUDED.average_rating
+`
+
+type UpsertBookPartParams struct {
+	ID            string   `json:"id"`
+	BookID        *string  `json:"book_id"`
+	Title         string   `json:"title"`
+	Content       string   `json:"content"`
+	OrderNum      int32    `json:"order_num"`
+	PageStart     *int32   `json:"page_start"`
+	PageEnd       *int32   `json:"page_end"`
+	MoodTags      []string `json:"mood_tags"`
+	AverageRating *float64 `json:"average_rating"`
+}
+
+func (q *Queries) UpsertBookPart(ctx context.Context, arg UpsertBookPartParams) error {
+	_, err := q.db.Exec(ctx, upsertBookPart,
+		arg.ID,
+		arg.BookID,
+		arg.Title,
+		arg.Content,
+		arg.OrderNum,
+		arg.PageStart,
+		arg.PageEnd,
+		arg.MoodTags,
+		arg.AverageRating,
+	)
+	return err
+}
@@ -0,0 +1,384 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: book_proposals.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+const createBookProposal = `-- name: CreateBookProposal :one
+INSERT INTO book_proposals (
+    book_id, proposer_id, changes, comment, book_version, ai_approved, ai_confidence, ai_notes
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING id, status, created_at
+`
+
+type CreateBookProposalParams struct {
+	BookID       string   `json:"book_id"`
+	ProposerID   *string  `json:"proposer_id"`
+	Changes      []byte   `json:"changes"`
+	Comment      *string  `json:"comment"`
+	BookVersion  int32    `json:"book_version"`
+	AIApproved   *bool    `json:"ai_approved"`
+	AIConfidence *float64 `json:"ai_confidence"`
+	AINotes      *string  `json:"ai_notes"`
+}
+
+type CreateBookProposalRow struct {
+	ID        string    `json:"id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) CreateBookProposal(ctx context.Context, arg CreateBookProposalParams) (CreateBookProposalRow, error) {
+	row := q.db.QueryRow(ctx, createBookProposal,
+		arg.BookID,
+		arg.ProposerID,
+		arg.Changes,
+		arg.Comment,
+		arg.BookVersion,
+		arg.AIApproved,
+		arg.AIConfidence,
+		arg.AINotes,
+	)
+	var i CreateBookProposalRow
+	err := row.Scan(&i.ID, &i.Status, &i.CreatedAt)
+	return i, err
+}
+
+const getBookProposal = `-- name: GetBookProposal :one
+SELECT p.id, p.book_id, p.proposer_id, p.changes, p.comment, p.book_version, p.status, p.ai_approved, p.ai_confidence, p.ai_notes, p.resolved_by, p.resolution_note, p.resolved_at, p.created_at,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND v.approve)::int AS approvals,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND NOT v.approve)::int AS rejections,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND v.approve AND v.trusted)::int AS trusted_approvals,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND NOT v.approve AND v.trusted)::int AS trusted_rejections
+FROM book_proposals p
+JOIN books b ON b.id = p.book_id AND b.deleted_at IS NULL
+WHERE p.id = $1
+`
+
+type GetBookProposalRow struct {
+	ID                string     `json:"id"`
+	BookID            string     `json:"book_id"`
+	ProposerID        *string    `json:"proposer_id"`
+	Changes           []byte     `json:"changes"`
+	Comment           *string    `json:"comment"`
+	BookVersion       int32      `json:"book_version"`
+	Status            string     `json:"status"`
+	AIApproved        *bool      `json:"ai_approved"`
+	AIConfidence      *float64   `json:"ai_confidence"`
+	AINotes           *string    `json:"ai_notes"`
+	ResolvedBy        *string    `json:"resolved_by"`
+	ResolutionNote    *string    `json:"resolution_note"`
+	ResolvedAt        *time.Time `json:"resolved_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	Approvals         int32      `json:"approvals"`
+	Rejections        int32      `json:"rejections"`
+	TrustedApprovals  int32      `json:"trusted_approvals"`
+	TrustedRejections int32      `json:"trusted_rejections"`
+}
+
+// Предложение с итогами голосования (trusted_* учитываются при подсчете порога)
+func (q *Queries) GetBookProposal(ctx context.Context, id string) (GetBookProposalRow, error) {
+	row := q.db.QueryRow(ctx, getBookProposal, id)
+	var i GetBookProposalRow
+	err := row.Scan(
+		&i.ID,
+		&i.BookID,
+		&i.ProposerID,
+		&i.Changes,
+		&i.Comment,
+		&i.BookVersion,
+		&i.Status,
+		&i.AIApproved,
+		&i.AIConfidence,
+		&i.AINotes,
+		&i.ResolvedBy,
+		&i.ResolutionNote,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+		&i.Approvals,
+		&i.Rejections,
+		&i.TrustedApprovals,
+		&i.TrustedRejections,
+	)
+	return i, err
+}
+
+const getProposalVoter = `-- name: GetProposalVoter :one
+SELECT u.id, u.role, u.created_at,
+       (SELECT COUNT(*) FROM reviews r WHERE r.user_id = u.id)::int AS reviews
+FROM users u
+WHERE u.id = $1 AND u.deleted_at IS NULL
+`
+
+type GetProposalVoterRow struct {
+	ID        string          `json:"id"`
+	Role      models.UserRole `json:"role"`
+	CreatedAt *time.Time      `json:"created_at"`
+	Reviews   int32           `json:"reviews"`
+}
+
+// Данные голосующего для проверки доверия: роль, возраст аккаунта и число рецензий
+func (q *Queries) GetProposalVoter(ctx context.Context, id string) (GetProposalVoterRow, error) {
+	row := q.db.QueryRow(ctx, getProposalVoter, id)
+	var i GetProposalVoterRow
+	err := row.Scan(
+		&i.ID,
+		&i.Role,
+		&i.CreatedAt,
+		&i.Reviews,
+	)
+	return i, err
+}
+
+const listBookProposals = `-- name: ListBookProposals :many
+SELECT p.id, p.book_id, p.proposer_id, p.changes, p.comment, p.book_version, p.status, p.ai_approved, p.ai_confidence, p.ai_notes, p.resolved_by, p.resolution_note, p.resolved_at, p.created_at,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND v.approve)::int AS approvals,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND NOT v.approve)::int AS rejections,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND v.approve AND v.trusted)::int AS trusted_approvals,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND NOT v.approve AND v.trusted)::int AS trusted_rejections
+FROM book_proposals p
+WHERE p.book_id = $1
+  AND ($2::text IS NULL OR p.status = $2::text)
+ORDER BY p.created_at DESC
+LIMIT $4 OFFSET $3
+`
+
+type ListBookProposalsParams struct {
+	BookID    string  `json:"book_id"`
+	Status    *string `json:"status"`
+	RowOffset int32   `json:"row_offset"`
+	RowLimit  int32   `json:"row_limit"`
+}
+
+type ListBookProposalsRow struct {
+	ID                string     `json:"id"`
+	BookID            string     `json:"book_id"`
+	ProposerID        *string    `json:"proposer_id"`
+	Changes           []byte     `json:"changes"`
+	Comment           *string    `json:"comment"`
+	BookVersion       int32      `json:"book_version"`
+	Status            string     `json:"status"`
+	AIApproved        *bool      `json:"ai_approved"`
+	AIConfidence      *float64   `json:"ai_confidence"`
+	AINotes           *string    `json:"ai_notes"`
+	ResolvedBy        *string    `json:"resolved_by"`
+	ResolutionNote    *string    `json:"resolution_note"`
+	ResolvedAt        *time.Time `json:"resolved_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	Approvals         int32      `json:"approvals"`
+	Rejections        int32      `json:"rejections"`
+	TrustedApprovals  int32      `json:"trusted_approvals"`
+	TrustedRejections int32      `json:"trusted_rejections"`
+}
+
+func (q *Queries) ListBookProposals(ctx context.Context, arg ListBookProposalsParams) ([]ListBookProposalsRow, error) {
+	rows, err := q.db.Query(ctx, listBookProposals,
+		arg.BookID,
+		arg.Status,
+		arg.RowOffset,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBookProposalsRow
+	for rows.Next() {
+		var i ListBookProposalsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.ProposerID,
+			&i.Changes,
+			&i.Comment,
+			&i.BookVersion,
+			&i.Status,
+			&i.AIApproved,
+			&i.AIConfidence,
+			&i.AINotes,
+			&i.ResolvedBy,
+			&i.ResolutionNote,
+			&i.ResolvedAt,
+			&i.CreatedAt,
+			&i.Approvals,
+			&i.Rejections,
+			&i.TrustedApprovals,
+			&i.TrustedRejections,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBookProposalsByStatus = `-- name: ListBookProposalsByStatus :many
+SELECT p.id, p.book_id, p.proposer_id, p.changes, p.comment, p.book_version, p.status, p.ai_approved, p.ai_confidence, p.ai_notes, p.resolved_by, p.resolution_note, p.resolved_at, p.created_at,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND v.approve)::int AS approvals,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND NOT v.approve)::int AS rejections,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND v.approve AND v.trusted)::int AS trusted_approvals,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND NOT v.approve AND v.trusted)::int AS trusted_rejections
+FROM book_proposals p
+JOIN books b ON b.id = p.book_id AND b.deleted_at IS NULL
+WHERE p.status = $1
+ORDER BY p.created_at
+LIMIT $3 OFFSET $2
+`
+
+type ListBookProposalsByStatusParams struct {
+	Status    string `json:"status"`
+	RowOffset int32  `json:"row_offset"`
+	RowLimit  int32  `json:"row_limit"`
+}
+
+type ListBookProposalsByStatusRow struct {
+	ID                string     `json:"id"`
+	BookID            string     `json:"book_id"`
+	ProposerID        *string    `json:"proposer_id"`
+	Changes           []byte     `json:"changes"`
+	Comment           *string    `json:"comment"`
+	BookVersion       int32      `json:"book_version"`
+	Status            string     `json:"status"`
+	AIApproved        *bool      `json:"ai_approved"`
+	AIConfidence      *float64   `json:"ai_confidence"`
+	AINotes           *string    `json:"ai_notes"`
+	ResolvedBy        *string    `json:"resolved_by"`
+	ResolutionNote    *string    `json:"resolution_note"`
+	ResolvedAt        *time.Time `json:"resolved_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	Approvals         int32      `json:"approvals"`
+	Rejections        int32      `json:"rejections"`
+	TrustedApprovals  int32      `json:"trusted_approvals"`
+	TrustedRejections int32      `json:"trusted_rejections"`
+}
+
+// Очередь модераторов: сначала давние предложения
+func (q *Queries) ListBookProposalsByStatus(ctx context.Context, arg ListBookProposalsByStatusParams) ([]ListBookProposalsByStatusRow, error) {
+	rows, err := q.db.Query(ctx, listBookProposalsByStatus, arg.Status, arg.RowOffset, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBookProposalsByStatusRow
+	for rows.Next() {
+		var i ListBookProposalsByStatusRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookID,
+			&i.ProposerID,
+			&i.Changes,
+			&i.Comment,
+			&i.BookVersion,
+			&i.Status,
+			&i.AIApproved,
+			&i.AIConfidence,
+			&i.AINotes,
+			&i.ResolvedBy,
+			&i.ResolutionNote,
+			&i.ResolvedAt,
+			&i.CreatedAt,
+			&i.Approvals,
+			&i.Rejections,
+			&i.TrustedApprovals,
+			&i.TrustedRejections,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockBookProposal = `-- name: LockBookProposal :one
+SELECT p.status FROM book_proposals p
+JOIN books b ON b.id = p.book_id AND b.deleted_at IS NULL
+WHERE p.id = $1
+FOR UPDATE OF p
+`
+
+// Блокировка предложения до конца транзакции: голоса и решения модераторов идут по очереди
+func (q *Queries) LockBookProposal(ctx context.Context, id string) (string, error) {
+	row := q.db.QueryRow(ctx, lockBookProposal, id)
+	var status string
+	err := row.Scan(&status)
+	return status, err
+}
+
+const resolveBookProposal = `-- name: ResolveBookProposal :exec
+UPDATE book_proposals
+SET status = $2, resolved_by = $3, resolution_note = $4, resolved_at = NOW()
+WHERE id = $1
+`
+
+type ResolveBookProposalParams struct {
+	ID             string  `json:"id"`
+	Status         string  `json:"status"`
+	ResolvedBy     *string `json:"resolved_by"`
+	ResolutionNote *string `json:"resolution_note"`
+}
+
+func (q *Queries) ResolveBookProposal(ctx context.Context, arg ResolveBookProposalParams) error {
+	_, err := q.db.Exec(ctx, resolveBookProposal,
+		arg.ID,
+		arg.Status,
+		arg.ResolvedBy,
+		arg.ResolutionNote,
+	)
+	return err
+}
+
+const setBookProposalStatus = `-- name: SetBookProposalStatus :exec
+UPDATE book_proposals
+SET status = $2
+WHERE id = $1
+`
+
+type SetBookProposalStatusParams struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (q *Queries) SetBookProposalStatus(ctx context.Context, arg SetBookProposalStatusParams) error {
+	_, err := q.db.Exec(ctx, setBookProposalStatus, arg.ID, arg.Status)
+	return err
+}
+
+const upsertBookProposalVote = `-- name: UpsertBookProposalVote :exec
+INSERT INTO book_proposal_votes (proposal_id, user_id, approve, trusted)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (proposal_id, user_id) DO UPDATE
+SET approve = EXCLUDED.approve, trusted = EXCLUDED.trusted, created_at = NOW()
+`
+
+type UpsertBookProposalVoteParams struct {
+	ProposalID string `json:"proposal_id"`
+	UserID     string `json:"user_id"`
+	Approve    bool   `json:"approve"`
+	Trusted    bool   `json:"trusted"`
+}
+
+func (q *Queries) UpsertBookProposalVote(ctx context.Context, arg UpsertBookProposalVoteParams) error {
+	_, err := q.db.Exec(ctx, upsertBookProposalVote,
+		arg.ProposalID,
+		arg.UserID,
+		arg.Approve,
+		arg.Trusted,
+	)
+	return err
+}
@@ -0,0 +1,336 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: books.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+const countBooks = `-- name: CountBooks :one
+SELECT COUNT(*) FROM books
+WHERE deleted_at IS NULL
+`
+
+func (q *Queries) CountBooks(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countBooks)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createBook = `-- name: CreateBook :one
+INSERT INTO books (
+    title, original_title, author, year, genres, age_rating,
+    author_country, description, cover_url, pages_count, tags,
+    verified, verification_type, created_by, created_at,
+    average_rating, rating_count
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+)
+RETURNING id, version, updated_at
+`
+
+type CreateBookParams struct {
+	Title            string                   `json:"title"`
+	OriginalTitle    *string                  `json:"original_title"`
+	Author           string                   `json:"author"`
+	Year             *int32                   `json:"year"`
+	Genres           []string                 `json:"genres"`
+	AgeRating        *models.AgeRating        `json:"age_rating"`
+	AuthorCountry    *string                  `json:"author_country"`
+	Description      string                   `json:"description"`
+	CoverURL         *string                  `json:"cover_url"`
+	PagesCount       int32                    `json:"pages_count"`
+	Tags             []string                 `json:"tags"`
+	Verified         *bool                    `json:"verified"`
+	VerificationType *models.VerificationType `json:"verification_type"`
+	CreatedBy        *string                  `json:"created_by"`
+	CreatedAt        *time.Time               `json:"created_at"`
+	AverageRating    *float64                 `json:"average_rating"`
+	RatingCount      *int32                   `json:"rating_count"`
+}
+
+type CreateBookRow struct {
+	ID        string    `json:"id"`
+	Version   int32     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) CreateBook(ctx context.Context, arg CreateBookParams) (CreateBookRow, error) {
+	row := q.db.QueryRow(ctx, createBook,
+		arg.Title,
+		arg.OriginalTitle,
+		arg.Author,
+		arg.Year,
+		arg.Genres,
+		arg.AgeRating,
+		arg.AuthorCountry,
+		arg.Description,
+		arg.CoverURL,
+		arg.PagesCount,
+		arg.Tags,
+		arg.Verified,
+		arg.VerificationType,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.AverageRating,
+		arg.RatingCount,
+	)
+	var i CreateBookRow
+	err := row.Scan(&i.ID, &i.Version, &i.UpdatedAt)
+	return i, err
+}
+
+const deleteBook = `-- name: DeleteBook :execrows
+UPDATE books SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+// Мягкое удаление: книга переносится в корзину, части и отзывы остаются до окончательной очистки
+func (q *Queries) DeleteBook(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBook, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getBook = `-- name: GetBook :one
+SELECT id, title, original_title, author, year, genres, age_rating, author_country, description, cover_url, pages_count, verified, verification_type, created_by, created_at, tags, average_rating, rating_count, updated_at, version, deleted_at FROM books
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetBook(ctx context.Context, id string) (Book, error) {
+	row := q.db.QueryRow(ctx, getBook, id)
+	var i Book
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.OriginalTitle,
+		&i.Author,
+		&i.Year,
+		&i.Genres,
+		&i.AgeRating,
+		&i.AuthorCountry,
+		&i.Description,
+		&i.CoverURL,
+		&i.PagesCount,
+		&i.Verified,
+		&i.VerificationType,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.Tags,
+		&i.AverageRating,
+		&i.RatingCount,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const listBooks = `-- name: ListBooks :many
+SELECT id, title, original_title, author, year, genres, age_rating, author_country, description, cover_url, pages_count, verified, verification_type, created_by, created_at, tags, average_rating, rating_count, updated_at, version, deleted_at FROM books
+WHERE deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListBooksParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListBooks(ctx context.Context, arg ListBooksParams) ([]Book, error) {
+	rows, err := q.db.Query(ctx, listBooks, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Book
+	for rows.Next() {
+		var i Book
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.OriginalTitle,
+			&i.Author,
+			&i.Year,
+			&i.Genres,
+			&i.AgeRating,
+			&i.AuthorCountry,
+			&i.Description,
+			&i.CoverURL,
+			&i.PagesCount,
+			&i.Verified,
+			&i.VerificationType,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Tags,
+			&i.AverageRating,
+			&i.RatingCount,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateBook = `-- name: UpdateBook :one
+UPDATE books SET
+    title = $2, original_title = $3, author = $4, year = $5, genres = $6,
+    age_rating = $7, author_country = $8, description = $9, cover_url = $10,
+    pages_count = $11, tags = $12, verified = $13, verification_type = $14,
+    version = version + 1
+WHERE id = $1 AND version = $15 AND deleted_at IS NULL
+RETURNING version, updated_at, average_rating, rating_count
+`
+
+type UpdateBookParams struct {
+	ID               string                   `json:"id"`
+	Title            string                   `json:"title"`
+	OriginalTitle    *string                  `json:"original_title"`
+	Author           string                   `json:"author"`
+	Year             *int32                   `json:"year"`
+	Genres           []string                 `json:"genres"`
+	AgeRating        *models.AgeRating        `json:"age_rating"`
+	AuthorCountry    *string                  `json:"author_country"`
+	Description      string                   `json:"description"`
+	CoverURL         *string                  `json:"cover_url"`
+	PagesCount       int32                    `json:"pages_count"`
+	Tags             []string                 `json:"tags"`
+	Verified         *bool                    `json:"verified"`
+	VerificationType *models.VerificationType `json:"verification_type"`
+	ExpectedVersion  int32                    `json:"expected_version"`
+}
+
+type UpdateBookRow struct {
+	Version       int32     `json:"version"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	AverageRating *float64  `json:"average_rating"`
+	RatingCount   *int32    `json:"rating_count"`
+}
+
+// Обновление применяется, только если с момента чтения книгу никто не изменил;
+// пустой результат - книга удалена или версия устарела.
+// Рейтинг не перезаписывается: его меняет только RefreshBookRating, который не повышает версию,
+// поэтому запись прочитанного рейтинга потеряла бы конкурентный пересчет.
+func (q *Queries) UpdateBook(ctx context.Context, arg UpdateBookParams) (UpdateBookRow, error) {
+	row := q.db.QueryRow(ctx, updateBook,
+		arg.ID,
+		arg.Title,
+		arg.OriginalTitle,
+		arg.Author,
+		arg.Year,
+		arg.Genres,
+		arg.AgeRating,
+		arg.AuthorCountry,
+		arg.Description,
+		arg.CoverURL,
+		arg.PagesCount,
+		arg.Tags,
+		arg.Verified,
+		arg.VerificationType,
+		arg.ExpectedVersion,
+	)
+	var i UpdateBookRow
+	err := row.Scan(
+		&i.Version,
+		&i.UpdatedAt,
+		&i.AverageRating,
+		&i.RatingCount,
+	)
+	return i, err
+}
+
+const updateBookCoverURL = `-- name: UpdateBookCoverURL :execrows
+UPDATE books SET cover_url = $2, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type UpdateBookCoverURLParams struct {
+	ID       string  `json:"id"`
+	CoverURL *string `json:"cover_url"`
+}
+
+func (q *Queries) UpdateBookCoverURL(ctx context.Context, arg UpdateBookCoverURLParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateBookCoverURL, arg.ID, arg.CoverURL)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertBook = `-- name: UpsertBook :exec
+INSERT INTO books (
+    id, title, original_title, author, year, genres, age_rating,
+    author_country, description, cover_url, pages_count, tags,
+    verified, verification_type, created_at, average_rating, rating_count
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+)
+ON CONFLICT (id) DO UPDATE SET
+    title = EXCLUDED.title, original_title = EXCLUDED.original_title, author = EXCLUDED.author,
+    year = EXCLUDED.year, genres = EXCLUDED.genres, age_rating = EXCLUDED.age_rating,
+    author_country = EXCLUDED.author_country, description = EXCLUDED.description,
+    cover_url = EXCLUDED.cover_url, pages_count = EXCLUDED.pages_count, tags = EXCLUDED.tags,
+    verified = EXCLUDED.verified, verification_type = EXCLUDED.verification_type,
+    created_at = EXCLUDED.created_at, average_rating = EXCLUDED.average_rating,
+    rating_count = EXCLUDED.rating_count
+`
+
+type UpsertBookParams struct {
+	ID               string                   `json:"id"`
+	Title            string                   `json:"title"`
+	OriginalTitle    *string                  `json:"original_title"`
+	Author           string                   `json:"author"`
+	Year             *int32                   `json:"year"`
+	Genres           []string                 `json:"genres"`
+	AgeRating        *models.AgeRating        `json:"age_rating"`
+	AuthorCountry    *string                  `json:"author_country"`
+	Description      string                   `json:"description"`
+	CoverURL         *string                  `json:"cover_url"`
+	PagesCount       int32                    `json:"pages_count"`
+	Tags             []string                 `json:"tags"`
+	Verified         *bool                    `json:"verified"`
+	VerificationType *models.VerificationType `json:"verification_type"`
+	CreatedAt        *time.Time               `json:"created_at"`
+	AverageRating    *float64                 `json:"average_rating"`
+	RatingCount      *int32                   `json:"rating_count"`
+}
+
+func (q *Queries) UpsertBook(ctx context.Context, arg UpsertBookParams) error {
+	_, err := q.db.Exec(ctx, upsertBook,
+		arg.ID,
+		arg.Title,
+		arg.OriginalTitle,
+		arg.Author,
+		arg.Year,
+		arg.Genres,
+		arg.AgeRating,
+		arg.AuthorCountry,
+		arg.Description,
+		arg.CoverURL,
+		arg.PagesCount,
+		arg.Tags,
+		arg.Verified,
+		arg.VerificationType,
+		arg.CreatedAt,
+		arg.AverageRating,
+		arg.RatingCount,
+	)
+	return err
+}
@@ -0,0 +1,350 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: content_reports.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const claimContentReport = `-- name: ClaimContentReport :exec
+UPDATE content_reports
+SET claimed_by = $2, claimed_at = NOW()
+WHERE id = $1
+`
+
+type ClaimContentReportParams struct {
+	ID        string  `json:"id"`
+	ClaimedBy *string `json:"claimed_by"`
+}
+
+func (q *Queries) ClaimContentReport(ctx context.Context, arg ClaimContentReportParams) error {
+	_, err := q.db.Exec(ctx, claimContentReport, arg.ID, arg.ClaimedBy)
+	return err
+}
+
+const countOpenTargetReporters = `-- name: CountOpenTargetReporters :one
+SELECT COUNT(DISTINCT reporter_id)::int FROM content_reports
+WHERE target_type = $1 AND target_id = $2 AND status = 'open'
+`
+
+type CountOpenTargetReportersParams struct {
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+}
+
+func (q *Queries) CountOpenTargetReporters(ctx context.Context, arg CountOpenTargetReportersParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countOpenTargetReporters, arg.TargetType, arg.TargetID)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const createContentReport = `-- name: CreateContentReport :one
+INSERT INTO content_reports (target_type, target_id, reporter_id, reason, details)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, status, created_at
+`
+
+type CreateContentReportParams struct {
+	TargetType string  `json:"target_type"`
+	TargetID   string  `json:"target_id"`
+	ReporterID string  `json:"reporter_id"`
+	Reason     string  `json:"reason"`
+	Details    *string `json:"details"`
+}
+
+type CreateContentReportRow struct {
+	ID        string    `json:"id"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) CreateContentReport(ctx context.Context, arg CreateContentReportParams) (CreateContentReportRow, error) {
+	row := q.db.QueryRow(ctx, createContentReport,
+		arg.TargetType,
+		arg.TargetID,
+		arg.ReporterID,
+		arg.Reason,
+		arg.Details,
+	)
+	var i CreateContentReportRow
+	err := row.Scan(&i.ID, &i.Status, &i.CreatedAt)
+	return i, err
+}
+
+const getContentReport = `-- name: GetContentReport :one
+SELECT r.id, r.target_type, r.target_id, r.reporter_id, r.reason, r.details, r.status, r.claimed_by, r.claimed_at, r.resolved_by, r.resolution_note, r.resolved_at, r.created_at,
+       (SELECT COUNT(*) FROM content_reports o
+        WHERE o.target_type = r.target_type AND o.target_id = r.target_id AND o.status = 'open')::int AS open_reports,
+       COALESCE(CASE r.target_type
+           WHEN 'review' THEN (SELECT t.hidden_at IS NOT NULL FROM reviews t WHERE t.id = r.target_id)
+           WHEN 'comment' THEN (SELECT t.hidden_at IS NOT NULL FROM comments t WHERE t.id = r.target_id)
+           WHEN 'quote' THEN (SELECT t.hidden_at IS NOT NULL FROM quotes t WHERE t.id = r.target_id)
+           WHEN 'article' THEN (SELECT t.hidden_at IS NOT NULL FROM articles t WHERE t.id = r.target_id)
+           WHEN 'character_profile' THEN (SELECT t.hidden_at IS NOT NULL FROM character_profiles t WHERE t.id = r.target_id)
+       END, false)::bool AS target_hidden
+FROM content_reports r
+WHERE r.id = $1
+`
+
+type GetContentReportRow struct {
+	ID             string     `json:"id"`
+	TargetType     string     `json:"target_type"`
+	TargetID       string     `json:"target_id"`
+	ReporterID     string     `json:"reporter_id"`
+	Reason         string     `json:"reason"`
+	Details        *string    `json:"details"`
+	Status         string     `json:"status"`
+	ClaimedBy      *string    `json:"claimed_by"`
+	ClaimedAt      *time.Time `json:"claimed_at"`
+	ResolvedBy     *string    `json:"resolved_by"`
+	ResolutionNote *string    `json:"resolution_note"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	OpenReports    int32      `json:"open_reports"`
+	TargetHidden   bool       `json:"target_hidden"`
+}
+
+// Жалоба с числом открытых жалоб на тот же объект и его текущим состоянием
+func (q *Queries) GetContentReport(ctx context.Context, id string) (GetContentReportRow, error) {
+	row := q.db.QueryRow(ctx, getContentReport, id)
+	var i GetContentReportRow
+	err := row.Scan(
+		&i.ID,
+		&i.TargetType,
+		&i.TargetID,
+		&i.ReporterID,
+		&i.Reason,
+		&i.Details,
+		&i.Status,
+		&i.ClaimedBy,
+		&i.ClaimedAt,
+		&i.ResolvedBy,
+		&i.ResolutionNote,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+		&i.OpenReports,
+		&i.TargetHidden,
+	)
+	return i, err
+}
+
+const hasResolvedTargetReports = `-- name: HasResolvedTargetReports :one
+SELECT EXISTS (
+    SELECT 1 FROM content_reports
+    WHERE target_type = $1 AND target_id = $2 AND status = 'resolved'
+)
+`
+
+type HasResolvedTargetReportsParams struct {
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+}
+
+func (q *Queries) HasResolvedTargetReports(ctx context.Context, arg HasResolvedTargetReportsParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasResolvedTargetReports, arg.TargetType, arg.TargetID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listContentReports = `-- name: ListContentReports :many
+SELECT r.id, r.target_type, r.target_id, r.reporter_id, r.reason, r.details, r.status, r.claimed_by, r.claimed_at, r.resolved_by, r.resolution_note, r.resolved_at, r.created_at,
+       (SELECT COUNT(*) FROM content_reports o
+        WHERE o.target_type = r.target_type AND o.target_id = r.target_id AND o.status = 'open')::int AS open_reports,
+       COALESCE(CASE r.target_type
+           WHEN 'review' THEN (SELECT t.hidden_at IS NOT NULL FROM reviews t WHERE t.id = r.target_id)
+           WHEN 'comment' THEN (SELECT t.hidden_at IS NOT NULL FROM comments t WHERE t.id = r.target_id)
+           WHEN 'quote' THEN (SELECT t.hidden_at IS NOT NULL FROM quotes t WHERE t.id = r.target_id)
+           WHEN 'article' THEN (SELECT t.hidden_at IS NOT NULL FROM articles t WHERE t.id = r.target_id)
+           WHEN 'character_profile' THEN (SELECT t.hidden_at IS NOT NULL FROM character_profiles t WHERE t.id = r.target_id)
+       END, false)::bool AS target_hidden
+FROM content_reports r
+WHERE ($1::text IS NULL OR r.status = $1::text)
+  AND ($2::text IS NULL OR r.target_type = $2::text)
+  AND ($3::uuid IS NULL OR r.target_id = $3::uuid)
+  AND ($4::uuid IS NULL OR r.claimed_by = $4::uuid)
+ORDER BY r.created_at
+LIMIT $6 OFFSET $5
+`
+
+type ListContentReportsParams struct {
+	Status     *string `json:"status"`
+	TargetType *string `json:"target_type"`
+	TargetID   *string `json:"target_id"`
+	ClaimedBy  *string `json:"claimed_by"`
+	RowOffset  int32   `json:"row_offset"`
+	RowLimit   int32   `json:"row_limit"`
+}
+
+type ListContentReportsRow struct {
+	ID             string     `json:"id"`
+	TargetType     string     `json:"target_type"`
+	TargetID       string     `json:"target_id"`
+	ReporterID     string     `json:"reporter_id"`
+	Reason         string     `json:"reason"`
+	Details        *string    `json:"details"`
+	Status         string     `json:"status"`
+	ClaimedBy      *string    `json:"claimed_by"`
+	ClaimedAt      *time.Time `json:"claimed_at"`
+	ResolvedBy     *string    `json:"resolved_by"`
+	ResolutionNote *string    `json:"resolution_note"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	OpenReports    int32      `json:"open_reports"`
+	TargetHidden   bool       `json:"target_hidden"`
+}
+
+// Очередь модераторов: сначала давние жалобы; пустые фильтры не ограничивают выборку
+func (q *Queries) ListContentReports(ctx context.Context, arg ListContentReportsParams) ([]ListContentReportsRow, error) {
+	rows, err := q.db.Query(ctx, listContentReports,
+		arg.Status,
+		arg.TargetType,
+		arg.TargetID,
+		arg.ClaimedBy,
+		arg.RowOffset,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListContentReportsRow
+	for rows.Next() {
+		var i ListContentReportsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TargetType,
+			&i.TargetID,
+			&i.ReporterID,
+			&i.Reason,
+			&i.Details,
+			&i.Status,
+			&i.ClaimedBy,
+			&i.ClaimedAt,
+			&i.ResolvedBy,
+			&i.ResolutionNote,
+			&i.ResolvedAt,
+			&i.CreatedAt,
+			&i.OpenReports,
+			&i.TargetHidden,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockContentReport = `-- name: LockContentReport :one
+SELECT id, target_type, target_id, reporter_id, reason, details, status, claimed_by, claimed_at, resolved_by, resolution_note, resolved_at, created_at FROM content_reports
+WHERE id = $1
+FOR UPDATE
+`
+
+// Блокировка жалобы до конца транзакции (взятие в работу)
+func (q *Queries) LockContentReport(ctx context.Context, id string) (ContentReport, error) {
+	row := q.db.QueryRow(ctx, lockContentReport, id)
+	var i ContentReport
+	err := row.Scan(
+		&i.ID,
+		&i.TargetType,
+		&i.TargetID,
+		&i.ReporterID,
+		&i.Reason,
+		&i.Details,
+		&i.Status,
+		&i.ClaimedBy,
+		&i.ClaimedAt,
+		&i.ResolvedBy,
+		&i.ResolutionNote,
+		&i.ResolvedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const lockOpenTargetReports = `-- name: LockOpenTargetReports :many
+SELECT id, claimed_by FROM content_reports
+WHERE target_type = $1 AND target_id = $2 AND status = 'open'
+ORDER BY id
+FOR UPDATE
+`
+
+type LockOpenTargetReportsParams struct {
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+}
+
+type LockOpenTargetReportsRow struct {
+	ID        string  `json:"id"`
+	ClaimedBy *string `json:"claimed_by"`
+}
+
+// Блокировка всех открытых жалоб на объект: решение по объекту закрывает их вместе.
+// Порядок по id исключает взаимную блокировку параллельных решений.
+func (q *Queries) LockOpenTargetReports(ctx context.Context, arg LockOpenTargetReportsParams) ([]LockOpenTargetReportsRow, error) {
+	rows, err := q.db.Query(ctx, lockOpenTargetReports, arg.TargetType, arg.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LockOpenTargetReportsRow
+	for rows.Next() {
+		var i LockOpenTargetReportsRow
+		if err := rows.Scan(&i.ID, &i.ClaimedBy); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const releaseContentReport = `-- name: ReleaseContentReport :exec
+UPDATE content_reports
+SET claimed_by = NULL, claimed_at = NULL
+WHERE id = $1
+`
+
+func (q *Queries) ReleaseContentReport(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, releaseContentReport, id)
+	return err
+}
+
+const resolveOpenTargetReports = `-- name: ResolveOpenTargetReports :execrows
+UPDATE content_reports
+SET status = $3, resolved_by = $4, resolution_note = $5, resolved_at = NOW()
+WHERE target_type = $1 AND target_id = $2 AND status = 'open'
+`
+
+type ResolveOpenTargetReportsParams struct {
+	TargetType     string  `json:"target_type"`
+	TargetID       string  `json:"target_id"`
+	Status         string  `json:"status"`
+	ResolvedBy     *string `json:"resolved_by"`
+	ResolutionNote *string `json:"resolution_note"`
+}
+
+func (q *Queries) ResolveOpenTargetReports(ctx context.Context, arg ResolveOpenTargetReportsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, resolveOpenTargetReports,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Status,
+		arg.ResolvedBy,
+		arg.ResolutionNote,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
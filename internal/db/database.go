@@ -3,11 +3,12 @@ package db
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/tukembaev/bookVisionGo/internal/config"
+	"github.com/tukembaev/bookVisionGo/internal/tracing"
 )
 
 // Database - структура для работы с базой данных
@@ -17,15 +18,24 @@ type Database struct {
 
 // NewDatabase - создание нового подключения к базе данных
 func NewDatabase(cfg *config.Config) (*Database, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Database.ConnectTimeoutSeconds)*time.Second)
 	defer cancel()
 
 	// Используем DatabaseURL() из конфига
 	dbURL := cfg.DatabaseURL()
 
-	log.Printf("Connecting to database: %s", dbURL)
+	slog.Info("connecting to database",
+		"host", cfg.Database.Host, "port", cfg.Database.Port, "database", cfg.Database.Name)
 
-	pool, err := pgxpool.New(ctx, dbURL)
+	poolConfig, err := pgxpool.ParseConfig(dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse database config: %w", err)
+	}
+	applyPoolConfig(poolConfig, cfg.Database.Pool)
+	// Каждый SQL запрос - дочерний спан трассировки запроса (без экспортера спаны не записываются)
+	poolConfig.ConnConfig.Tracer = tracing.NewPgxTracer()
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create connection pool: %w", err)
 	}
@@ -35,18 +45,34 @@ func NewDatabase(cfg *config.Config) (*Database, error) {
 		return nil, fmt.Errorf("unable to ping database: %w", err)
 	}
 
-	log.Printf("Successfully connected to database")
+	slog.Info("connected to database")
 
 	return &Database{
 		Pool: pool,
 	}, nil
 }
 
+// applyPoolConfig - размер пула и время жизни соединений; нулевые значения оставляют настройки pgx
+func applyPoolConfig(poolConfig *pgxpool.Config, cfg config.DBPoolConfig) {
+	if cfg.MaxConns > 0 {
+		poolConfig.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns > 0 {
+		poolConfig.MinConns = cfg.MinConns
+	}
+	if cfg.MaxConnLifetimeMinutes > 0 {
+		poolConfig.MaxConnLifetime = time.Duration(cfg.MaxConnLifetimeMinutes) * time.Minute
+	}
+	if cfg.MaxConnIdleMinutes > 0 {
+		poolConfig.MaxConnIdleTime = time.Duration(cfg.MaxConnIdleMinutes) * time.Minute
+	}
+}
+
 // Close - закрытие подключения к базе данных
 func (d *Database) Close() {
 	if d.Pool != nil {
 		d.Pool.Close()
-		log.Println("Database connection closed")
+		slog.Info("database connection closed")
 	}
 }
 
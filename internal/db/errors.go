@@ -0,0 +1,87 @@
+package db
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+)
+
+// Коды ошибок PostgreSQL, которые означают ошибку клиента, а не сбой сервера
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgNotNullViolation    = "23502"
+	pgCheckViolation      = "23514"
+	pgInvalidText         = "22P02"
+	pgInvalidDatetime     = "22007"
+	pgStringTooLong       = "22001"
+)
+
+// TranslateError - перевод ошибок pgx в доменные ошибки apperrors.
+// Уже доменные ошибки и неизвестные ошибки возвращаются без изменений.
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *apperrors.Error
+	if errors.As(err, &appErr) {
+		return err
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.Wrap(apperrors.CodeNotFound, "resource not found", err)
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return withField(apperrors.CodeConflict, "resource already exists", constraintField(pgErr), "already taken", err)
+	case pgForeignKeyViolation:
+		return withField(apperrors.CodeValidation, "referenced resource does not exist", constraintField(pgErr), "does not exist", err)
+	case pgNotNullViolation:
+		return withField(apperrors.CodeValidation, "required field is missing", pgErr.ColumnName, "is required", err)
+	case pgCheckViolation:
+		return withField(apperrors.CodeValidation, "value violates a constraint", constraintField(pgErr), "is invalid", err)
+	case pgInvalidText, pgInvalidDatetime, pgStringTooLong:
+		return apperrors.Wrap(apperrors.CodeValidation, "invalid value", err)
+	}
+	return err
+}
+
+// withField - доменная ошибка с ошибкой поля, если поле удалось определить
+func withField(code apperrors.Code, message, field, fieldMessage string, err error) error {
+	appErr := apperrors.Wrap(code, message, err)
+	if field != "" {
+		appErr.Details = []apperrors.FieldError{{Field: field, Message: fieldMessage}}
+	}
+	return appErr
+}
+
+// constraintField - имя поля из имени ограничения по соглашению PostgreSQL
+// "<таблица>_<колонка>_key|fkey|check" (users_username_key -> username)
+func constraintField(pgErr *pgconn.PgError) string {
+	if pgErr.ColumnName != "" {
+		return pgErr.ColumnName
+	}
+	name := pgErr.ConstraintName
+	for _, suffix := range []string{"_key", "_fkey", "_check"} {
+		if trimmed, ok := strings.CutSuffix(name, suffix); ok {
+			name = trimmed
+			break
+		}
+	}
+	if table := pgErr.TableName; table != "" {
+		if trimmed, ok := strings.CutPrefix(name, table+"_"); ok {
+			return trimmed
+		}
+	}
+	return ""
+}
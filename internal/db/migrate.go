@@ -0,0 +1,234 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5" // драйвер pgx5://
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// migrationsFS - SQL миграции, встроенные в бинарник
+//
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+var (
+	// ErrPendingMigrations - в базе применены не все встроенные миграции
+	ErrPendingMigrations = errors.New("database schema has pending migrations")
+	// ErrDirtySchema - последняя миграция завершилась ошибкой, нужен migrate force
+	ErrDirtySchema = errors.New("database schema is dirty")
+)
+
+// migrationsTable - таблица версий golang-migrate
+const migrationsTable = "schema_migrations"
+
+// migrationLockTimeout - ожидание advisory lock, удерживаемого другим запуском миграций
+const migrationLockTimeout = time.Minute
+
+// MigrationInfo - состояние одной миграции
+type MigrationInfo struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// Migrator - запуск встроенных миграций.
+// Одновременные запуски (несколько реплик API, CLI) сериализуются через pg_advisory_lock.
+type Migrator struct {
+	m *migrate.Migrate
+}
+
+// NewMigrator - создание Migrator для базы databaseURL (postgres://...)
+func NewMigrator(databaseURL string) (*Migrator, error) {
+	source, err := iofs.New(migrationsFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithSourceInstance("iofs", source, pgx5URL(databaseURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize migrations: %w", err)
+	}
+	m.LockTimeout = migrationLockTimeout
+	m.Log = migrationLogger{}
+
+	return &Migrator{m: m}, nil
+}
+
+// Up - применение всех новых миграций
+func (mg *Migrator) Up() error {
+	return ignoreNoChange(mg.m.Up())
+}
+
+// Down - откат steps последних миграций (steps <= 0 - откат всех)
+func (mg *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return ignoreNoChange(mg.m.Down())
+	}
+	return ignoreNoChange(mg.m.Steps(-steps))
+}
+
+// Goto - переход к версии version (вверх или вниз)
+func (mg *Migrator) Goto(version uint) error {
+	return ignoreNoChange(mg.m.Migrate(version))
+}
+
+// Force - установка версии без выполнения миграций (снятие флага dirty после ручного исправления)
+func (mg *Migrator) Force(version int) error {
+	return mg.m.Force(version)
+}
+
+// Version - текущая версия схемы; 0, если миграции еще не применялись
+func (mg *Migrator) Version() (version uint, dirty bool, err error) {
+	version, dirty, err = mg.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Status - список встроенных миграций с отметкой о применении
+func (mg *Migrator) Status() ([]MigrationInfo, error) {
+	current, _, err := mg.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := embeddedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	for i := range migrations {
+		migrations[i].Applied = migrations[i].Version <= current
+	}
+	return migrations, nil
+}
+
+// LatestVersion - версия последней встроенной миграции
+func LatestVersion() (uint, error) {
+	migrations, err := embeddedMigrations()
+	if err != nil {
+		return 0, err
+	}
+	var latest uint
+	for _, migration := range migrations {
+		latest = max(latest, migration.Version)
+	}
+	return latest, nil
+}
+
+// CheckSchema - проверка готовности схемы: все встроенные миграции применены и схема не dirty.
+// Читает таблицу версий через пул, без отдельного подключения мигратора.
+func (d *Database) CheckSchema(ctx context.Context) error {
+	latest, err := LatestVersion()
+	if err != nil {
+		return err
+	}
+
+	var (
+		version int64
+		dirty   bool
+	)
+	err = d.Pool.QueryRow(ctx, "SELECT version, dirty FROM "+migrationsTable+" LIMIT 1").Scan(&version, &dirty)
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows), errors.As(err, &pgErr) && pgErr.Code == "42P01": // undefined_table - миграции еще не запускались
+		version = 0
+	case err != nil:
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if dirty {
+		return fmt.Errorf("%w at version %d", ErrDirtySchema, version)
+	}
+	if uint(version) < latest {
+		return fmt.Errorf("%w: at version %d, latest is %d", ErrPendingMigrations, version, latest)
+	}
+	return nil
+}
+
+// embeddedMigrations - список встроенных миграций по файлам *.up.sql
+func embeddedMigrations() ([]MigrationInfo, error) {
+	entries, err := fs.Glob(migrationsFS, "migrations/*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]MigrationInfo, 0, len(entries))
+	for _, entry := range entries {
+		name := strings.TrimSuffix(strings.TrimPrefix(entry, "migrations/"), ".up.sql")
+		number, title, _ := strings.Cut(name, "_")
+
+		var version uint
+		if _, err := fmt.Sscanf(number, "%d", &version); err != nil {
+			return nil, fmt.Errorf("invalid migration file name %s", entry)
+		}
+		migrations = append(migrations, MigrationInfo{Version: version, Name: title})
+	}
+	return migrations, nil
+}
+
+// Close - закрытие подключений мигратора
+func (mg *Migrator) Close() error {
+	sourceErr, dbErr := mg.m.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+// Migrate - применение всех новых миграций (автомиграция при старте API)
+func Migrate(databaseURL string) error {
+	migrator, err := NewMigrator(databaseURL)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if err := migrator.Up(); err != nil {
+		return err
+	}
+
+	version, _, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	slog.Info("database schema version", "version", version)
+	return nil
+}
+
+// pgx5URL - замена схемы postgres:// на pgx5:// для драйвера golang-migrate
+func pgx5URL(databaseURL string) string {
+	for _, scheme := range []string{"postgres://", "postgresql://"} {
+		if rest, ok := strings.CutPrefix(databaseURL, scheme); ok {
+			return "pgx5://" + rest
+		}
+	}
+	return databaseURL
+}
+
+// ignoreNoChange - отсутствие изменений не считается ошибкой
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+// migrationLogger - вывод хода миграций в структурированный лог
+type migrationLogger struct{}
+
+func (migrationLogger) Printf(format string, v ...interface{}) {
+	slog.Info("migrate", "msg", strings.TrimSuffix(fmt.Sprintf(format, v...), "\n"))
+}
+
+func (migrationLogger) Verbose() bool {
+	return false
+}
@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS user_character_favorites;
+DROP TABLE IF EXISTS user_favorites;
+DROP TABLE IF EXISTS user_reading_sessions;
+DROP TABLE IF EXISTS articles;
+DROP TABLE IF EXISTS character_profile_illustrations;
+DROP TABLE IF EXISTS character_profiles;
+DROP TABLE IF EXISTS character_illustrations;
+DROP TABLE IF EXISTS quotes;
+DROP TABLE IF EXISTS challenges;
+DROP TABLE IF EXISTS playlists;
+DROP TABLE IF EXISTS user_book_progress;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS reviews;
+DROP TABLE IF EXISTS characters;
+DROP TABLE IF EXISTS book_parts;
+DROP TABLE IF EXISTS books;
+DROP TABLE IF EXISTS users;
+
+DROP TYPE IF EXISTS verification_type;
+DROP TYPE IF EXISTS age_rating;
+DROP TYPE IF EXISTS user_role;
@@ -0,0 +1 @@
+ALTER TABLE book_parts DROP COLUMN IF EXISTS content;
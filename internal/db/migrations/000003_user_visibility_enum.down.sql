@@ -0,0 +1,16 @@
+-- Возврат видимости к строковым колонкам
+ALTER TABLE users
+    ALTER COLUMN profile_visibility DROP DEFAULT,
+    ALTER COLUMN profile_visibility DROP NOT NULL,
+    ALTER COLUMN activity_visibility DROP DEFAULT,
+    ALTER COLUMN activity_visibility DROP NOT NULL;
+
+ALTER TABLE users
+    ALTER COLUMN profile_visibility TYPE VARCHAR(10) USING profile_visibility::text,
+    ALTER COLUMN activity_visibility TYPE VARCHAR(10) USING activity_visibility::text;
+
+ALTER TABLE users
+    ALTER COLUMN profile_visibility SET DEFAULT 'public',
+    ALTER COLUMN activity_visibility SET DEFAULT 'public';
+
+DROP TYPE IF EXISTS visibility;
@@ -0,0 +1,21 @@
+-- Видимость профиля и активности пользователя
+CREATE TYPE visibility AS ENUM ('public', 'followers', 'private');
+
+UPDATE users SET profile_visibility = 'public'
+WHERE profile_visibility IS NULL OR profile_visibility NOT IN ('public', 'followers', 'private');
+UPDATE users SET activity_visibility = 'public'
+WHERE activity_visibility IS NULL OR activity_visibility NOT IN ('public', 'followers', 'private');
+
+ALTER TABLE users
+    ALTER COLUMN profile_visibility DROP DEFAULT,
+    ALTER COLUMN activity_visibility DROP DEFAULT;
+
+ALTER TABLE users
+    ALTER COLUMN profile_visibility TYPE visibility USING profile_visibility::visibility,
+    ALTER COLUMN activity_visibility TYPE visibility USING activity_visibility::visibility;
+
+ALTER TABLE users
+    ALTER COLUMN profile_visibility SET DEFAULT 'public',
+    ALTER COLUMN profile_visibility SET NOT NULL,
+    ALTER COLUMN activity_visibility SET DEFAULT 'public',
+    ALTER COLUMN activity_visibility SET NOT NULL;
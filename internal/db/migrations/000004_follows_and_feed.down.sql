@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS feed_items;
+DROP TABLE IF EXISTS activity_events;
+DROP TABLE IF EXISTS user_challenge_progress;
+DROP TABLE IF EXISTS user_follows;
@@ -0,0 +1,44 @@
+-- Подписки пользователей
+CREATE TABLE user_follows (
+    follower_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    followee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+-- Прогресс пользователей в челленджах
+CREATE TABLE user_challenge_progress (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    challenge_id UUID NOT NULL REFERENCES challenges(id) ON DELETE CASCADE,
+    status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed')),
+    progress_count INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP,
+    UNIQUE(user_id, challenge_id)
+);
+
+-- События активности пользователей
+CREATE TABLE activity_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL CHECK (type IN ('book_finished', 'review_created', 'quote_saved', 'article_published', 'challenge_joined')),
+    target_id TEXT NOT NULL,
+    book_id UUID REFERENCES books(id) ON DELETE CASCADE,
+    payload JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Лента подписчиков (fan-out on write)
+CREATE TABLE feed_items (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES activity_events(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, event_id)
+);
+
+CREATE INDEX idx_user_follows_followee_id ON user_follows(followee_id);
+CREATE INDEX idx_user_challenge_progress_user_id ON user_challenge_progress(user_id);
+CREATE INDEX idx_activity_events_actor_id ON activity_events(actor_id, created_at DESC);
+CREATE INDEX idx_feed_items_user_cursor ON feed_items(user_id, created_at DESC, event_id DESC);
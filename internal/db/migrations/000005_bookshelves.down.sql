@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS user_shelf_items;
+DROP TABLE IF EXISTS user_shelves;
+DROP TABLE IF EXISTS user_shelf_books;
+DROP TYPE IF EXISTS shelf_status;
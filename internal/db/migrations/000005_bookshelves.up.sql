@@ -0,0 +1,38 @@
+-- Встроенные статусы полок
+CREATE TYPE shelf_status AS ENUM ('want_to_read', 'reading', 'read', 'abandoned');
+
+-- Статус книги у пользователя (одна встроенная полка на книгу)
+CREATE TABLE user_shelf_books (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    book_id UUID NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    status shelf_status NOT NULL,
+    note TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, book_id)
+);
+
+-- Пользовательские именованные полки
+CREATE TABLE user_shelves (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(user_id, name)
+);
+
+-- Книги на пользовательских полках
+CREATE TABLE user_shelf_items (
+    shelf_id UUID NOT NULL REFERENCES user_shelves(id) ON DELETE CASCADE,
+    book_id UUID NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL DEFAULT 0,
+    note TEXT,
+    added_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (shelf_id, book_id)
+);
+
+CREATE INDEX idx_user_shelf_books_status ON user_shelf_books(user_id, status, updated_at DESC);
+CREATE INDEX idx_user_shelves_user_position ON user_shelves(user_id, position);
+CREATE INDEX idx_user_shelf_items_shelf_position ON user_shelf_items(shelf_id, position);
+CREATE INDEX idx_user_shelf_items_book_id ON user_shelf_items(book_id);
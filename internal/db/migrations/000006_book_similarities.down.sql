@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reviews_positive;
+DROP TABLE IF EXISTS book_similarities;
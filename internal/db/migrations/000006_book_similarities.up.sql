@@ -0,0 +1,13 @@
+-- Предрассчитанная похожесть книг (заполняется фоновой задачей рекомендаций)
+CREATE TABLE book_similarities (
+    book_id UUID NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    similar_book_id UUID NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    reasons TEXT[] NOT NULL DEFAULT '{}',
+    computed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (book_id, similar_book_id),
+    CHECK (book_id <> similar_book_id)
+);
+
+CREATE INDEX idx_book_similarities_score ON book_similarities(book_id, score DESC);
+CREATE INDEX idx_reviews_positive ON reviews(user_id, book_id) WHERE rating >= 8;
@@ -0,0 +1,10 @@
+-- Корзины token bucket общего ограничителя частоты запросов (RATE_LIMIT_STORE=postgres).
+-- UNLOGGED: данные не нужны после сбоя, зато запись не идет в WAL
+CREATE UNLOGGED TABLE rate_limit_buckets (
+    key TEXT PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL,
+    allowed BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_rate_limit_buckets_updated_at ON rate_limit_buckets(updated_at);
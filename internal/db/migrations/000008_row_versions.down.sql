@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS articles_set_updated_at ON articles;
+DROP TRIGGER IF EXISTS book_parts_set_updated_at ON book_parts;
+DROP TRIGGER IF EXISTS books_set_updated_at ON books;
+
+ALTER TABLE articles DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE book_parts DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE books DROP COLUMN IF EXISTS updated_at;
+
+DROP FUNCTION IF EXISTS set_updated_at();
@@ -0,0 +1,22 @@
+-- Версии строк каталога: updated_at обновляется триггером при любом UPDATE
+-- и служит основой для ETag/Last-Modified
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE books ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE book_parts ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE articles ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+UPDATE books SET updated_at = COALESCE(created_at, NOW());
+UPDATE articles SET updated_at = COALESCE(created_at, NOW());
+
+CREATE TRIGGER books_set_updated_at BEFORE UPDATE ON books
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER book_parts_set_updated_at BEFORE UPDATE ON book_parts
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER articles_set_updated_at BEFORE UPDATE ON articles
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS character_profiles_set_updated_at ON character_profiles;
+
+ALTER TABLE character_profiles DROP COLUMN IF EXISTS version;
+ALTER TABLE articles DROP COLUMN IF EXISTS version;
+ALTER TABLE book_parts DROP COLUMN IF EXISTS version;
+ALTER TABLE books DROP COLUMN IF EXISTS version;
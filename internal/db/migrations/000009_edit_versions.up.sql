@@ -0,0 +1,10 @@
+-- Счетчик правок для оптимистичной блокировки: увеличивается только запросами редактирования
+-- (UPDATE ... WHERE version = $n), а не служебными обновлениями вроде пересчета рейтинга
+ALTER TABLE books ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE book_parts ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE articles ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE character_profiles ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- updated_at профилей персонажей был только значением по умолчанию при вставке
+CREATE TRIGGER character_profiles_set_updated_at BEFORE UPDATE ON character_profiles
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,14 @@
+-- История правок каталога: одна строка на каждую правку книги, части или профиля персонажа.
+-- Ревизия нумеруется версией строки после правки; snapshot - редактируемые поля целиком
+-- (для сравнения любых двух ревизий и отката), changes - отличия от предыдущего состояния
+CREATE TABLE revisions (
+    entity_type VARCHAR(32) NOT NULL CHECK (entity_type IN ('book', 'book_part', 'character_profile')),
+    entity_id TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    editor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    comment TEXT,
+    changes JSONB,
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (entity_type, entity_id, version)
+);
@@ -0,0 +1,32 @@
+ALTER TABLE articles DROP CONSTRAINT articles_author_id_fkey,
+    ADD CONSTRAINT articles_author_id_fkey FOREIGN KEY (author_id) REFERENCES users(id);
+ALTER TABLE books DROP CONSTRAINT books_created_by_fkey,
+    ADD CONSTRAINT books_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id);
+ALTER TABLE user_character_favorites DROP CONSTRAINT user_character_favorites_user_id_fkey,
+    ADD CONSTRAINT user_character_favorites_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE user_favorites DROP CONSTRAINT user_favorites_user_id_fkey,
+    ADD CONSTRAINT user_favorites_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE user_reading_sessions DROP CONSTRAINT user_reading_sessions_user_id_fkey,
+    ADD CONSTRAINT user_reading_sessions_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE quotes DROP CONSTRAINT quotes_user_id_fkey,
+    ADD CONSTRAINT quotes_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE user_book_progress DROP CONSTRAINT user_book_progress_user_id_fkey,
+    ADD CONSTRAINT user_book_progress_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE comments DROP CONSTRAINT comments_parent_comment_id_fkey,
+    ADD CONSTRAINT comments_parent_comment_id_fkey FOREIGN KEY (parent_comment_id) REFERENCES comments(id);
+ALTER TABLE comments DROP CONSTRAINT comments_reply_to_user_id_fkey,
+    ADD CONSTRAINT comments_reply_to_user_id_fkey FOREIGN KEY (reply_to_user_id) REFERENCES users(id);
+ALTER TABLE comments DROP CONSTRAINT comments_user_id_fkey,
+    ADD CONSTRAINT comments_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+ALTER TABLE reviews DROP CONSTRAINT reviews_user_id_fkey,
+    ADD CONSTRAINT reviews_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DROP INDEX IF EXISTS idx_comments_deleted_at;
+DROP INDEX IF EXISTS idx_articles_deleted_at;
+DROP INDEX IF EXISTS idx_books_deleted_at;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE comments DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE books DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,38 @@
+-- Мягкое удаление: строка с deleted_at скрыта от обычных запросов и лежит в корзине,
+-- пока администратор ее не восстановит или задача очистки не удалит ее окончательно
+ALTER TABLE books ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE articles ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE comments ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Корзина и очистка выбирают только удаленные строки
+CREATE INDEX idx_books_deleted_at ON books (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_articles_deleted_at ON articles (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_comments_deleted_at ON comments (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_users_deleted_at ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Окончательное удаление пользователя или комментария не должно упираться во внешние ключи:
+-- личные данные пользователя удаляются вместе с ним, авторство общего контента обнуляется,
+-- ответы на удаленный комментарий остаются без родителя
+ALTER TABLE reviews DROP CONSTRAINT reviews_user_id_fkey,
+    ADD CONSTRAINT reviews_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE comments DROP CONSTRAINT comments_user_id_fkey,
+    ADD CONSTRAINT comments_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE comments DROP CONSTRAINT comments_reply_to_user_id_fkey,
+    ADD CONSTRAINT comments_reply_to_user_id_fkey FOREIGN KEY (reply_to_user_id) REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE comments DROP CONSTRAINT comments_parent_comment_id_fkey,
+    ADD CONSTRAINT comments_parent_comment_id_fkey FOREIGN KEY (parent_comment_id) REFERENCES comments(id) ON DELETE SET NULL;
+ALTER TABLE user_book_progress DROP CONSTRAINT user_book_progress_user_id_fkey,
+    ADD CONSTRAINT user_book_progress_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE quotes DROP CONSTRAINT quotes_user_id_fkey,
+    ADD CONSTRAINT quotes_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE user_reading_sessions DROP CONSTRAINT user_reading_sessions_user_id_fkey,
+    ADD CONSTRAINT user_reading_sessions_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE user_favorites DROP CONSTRAINT user_favorites_user_id_fkey,
+    ADD CONSTRAINT user_favorites_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE user_character_favorites DROP CONSTRAINT user_character_favorites_user_id_fkey,
+    ADD CONSTRAINT user_character_favorites_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE books DROP CONSTRAINT books_created_by_fkey,
+    ADD CONSTRAINT books_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE articles DROP CONSTRAINT articles_author_id_fkey,
+    ADD CONSTRAINT articles_author_id_fkey FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE SET NULL;
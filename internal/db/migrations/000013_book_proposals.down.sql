@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS book_proposal_votes;
+DROP TABLE IF EXISTS book_proposals;
@@ -0,0 +1,35 @@
+-- Предложения правок метаданных книги и голоса за них. Принятое голосованием доверенных
+-- пользователей или модератором предложение применяется к книге и делает ее верифицированной.
+CREATE TABLE book_proposals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    book_id UUID NOT NULL REFERENCES books(id) ON DELETE CASCADE,
+    proposer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    -- Измененные поля книги; пустой объект - подтверждение текущих метаданных
+    changes JSONB NOT NULL DEFAULT '{}',
+    comment TEXT,
+    -- Версия книги на момент предложения: если книгу изменили, решение принимает модератор
+    book_version INTEGER NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'needs_review', 'accepted', 'rejected')),
+    -- Вердикт автоматической проверки (NULL - проверка не выполнялась)
+    ai_approved BOOLEAN,
+    ai_confidence DOUBLE PRECISION,
+    ai_notes TEXT,
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolution_note TEXT,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_book_proposals_book_id ON book_proposals (book_id, created_at DESC);
+CREATE INDEX idx_book_proposals_status ON book_proposals (status, created_at);
+
+-- trusted фиксируется в момент голоса: повышение или понижение голосующего не пересчитывает прошлые голоса
+CREATE TABLE book_proposal_votes (
+    proposal_id UUID NOT NULL REFERENCES book_proposals(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    approve BOOLEAN NOT NULL,
+    trusted BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (proposal_id, user_id)
+);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS content_reports;
+
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_until;
+ALTER TABLE users DROP COLUMN IF EXISTS strikes;
+
+ALTER TABLE character_profiles DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE articles DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE quotes DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE comments DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE reviews DROP COLUMN IF EXISTS hidden_at;
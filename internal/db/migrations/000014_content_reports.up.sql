@@ -0,0 +1,39 @@
+-- Жалобы на пользовательский контент. Скрытый контент (hidden_at) не показывается в публичных
+-- ответах: его скрывают автоматически после нескольких жалоб разных пользователей или модератор,
+-- признавший жалобу обоснованной.
+ALTER TABLE reviews ADD COLUMN hidden_at TIMESTAMPTZ;
+ALTER TABLE comments ADD COLUMN hidden_at TIMESTAMPTZ;
+ALTER TABLE quotes ADD COLUMN hidden_at TIMESTAMPTZ;
+ALTER TABLE articles ADD COLUMN hidden_at TIMESTAMPTZ;
+ALTER TABLE character_profiles ADD COLUMN hidden_at TIMESTAMPTZ;
+
+-- Предупреждения за нарушения и блокировка входа до suspended_until
+ALTER TABLE users ADD COLUMN strikes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN suspended_until TIMESTAMPTZ;
+
+-- target_id без внешнего ключа: жалобы ссылаются на строки разных таблиц
+CREATE TABLE content_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    target_type VARCHAR(32) NOT NULL
+        CHECK (target_type IN ('review', 'comment', 'quote', 'article', 'character_profile')),
+    target_id UUID NOT NULL,
+    reporter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(32) NOT NULL
+        CHECK (reason IN ('spam', 'harassment', 'hate_speech', 'spoilers', 'inappropriate', 'copyright', 'other')),
+    details TEXT,
+    status VARCHAR(16) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'resolved', 'dismissed')),
+    -- Модератор, взявший жалобу в работу; другие модераторы не решают ее, пока он ее не отпустит
+    claimed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    claimed_at TIMESTAMPTZ,
+    resolved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolution_note TEXT,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Одна открытая жалоба пользователя на один объект: автоматическое скрытие считает разных авторов жалоб
+CREATE UNIQUE INDEX idx_content_reports_open_reporter ON content_reports (target_type, target_id, reporter_id)
+    WHERE status = 'open';
+CREATE INDEX idx_content_reports_target ON content_reports (target_type, target_id);
+CREATE INDEX idx_content_reports_status ON content_reports (status, created_at);
@@ -0,0 +1,582 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package db
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+type AgeRating string
+
+const (
+	AgeRating6  AgeRating = "6+"
+	AgeRating12 AgeRating = "12+"
+	AgeRating16 AgeRating = "16+"
+	AgeRating18 AgeRating = "18+"
+)
+
+func (e *AgeRating) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AgeRating(s)
+	case string:
+		*e = AgeRating(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AgeRating: %T", src)
+	}
+	return nil
+}
+
+type NullAgeRating struct {
+	AgeRating AgeRating `json:"age_rating"`
+	Valid     bool      `json:"valid"` // Valid is true if AgeRating is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAgeRating) Scan(value interface{}) error {
+	if value == nil {
+		ns.AgeRating, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AgeRating.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAgeRating) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AgeRating), nil
+}
+
+type ShelfStatus string
+
+const (
+	ShelfStatusWantToRead ShelfStatus = "want_to_read"
+	ShelfStatusReading    ShelfStatus = "reading"
+	ShelfStatusRead       ShelfStatus = "read"
+	ShelfStatusAbandoned  ShelfStatus = "abandoned"
+)
+
+func (e *ShelfStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ShelfStatus(s)
+	case string:
+		*e = ShelfStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ShelfStatus: %T", src)
+	}
+	return nil
+}
+
+type NullShelfStatus struct {
+	ShelfStatus ShelfStatus `json:"shelf_status"`
+	Valid       bool        `json:"valid"` // Valid is true if ShelfStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullShelfStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ShelfStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ShelfStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullShelfStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ShelfStatus), nil
+}
+
+type UserRole string
+
+const (
+	UserRoleUser      UserRole = "user"
+	UserRoleModerator UserRole = "moderator"
+	UserRoleAdmin     UserRole = "admin"
+)
+
+func (e *UserRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserRole(s)
+	case string:
+		*e = UserRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserRole: %T", src)
+	}
+	return nil
+}
+
+type NullUserRole struct {
+	UserRole UserRole `json:"user_role"`
+	Valid    bool     `json:"valid"` // Valid is true if UserRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUserRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.UserRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UserRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUserRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UserRole), nil
+}
+
+type VerificationType string
+
+const (
+	VerificationTypeAI        VerificationType = "AI"
+	VerificationTypeCommunity VerificationType = "Community"
+)
+
+func (e *VerificationType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = VerificationType(s)
+	case string:
+		*e = VerificationType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for VerificationType: %T", src)
+	}
+	return nil
+}
+
+type NullVerificationType struct {
+	VerificationType VerificationType `json:"verification_type"`
+	Valid            bool             `json:"valid"` // Valid is true if VerificationType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullVerificationType) Scan(value interface{}) error {
+	if value == nil {
+		ns.VerificationType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.VerificationType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullVerificationType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.VerificationType), nil
+}
+
+type Visibility string
+
+const (
+	VisibilityPublic    Visibility = "public"
+	VisibilityFollowers Visibility = "followers"
+	VisibilityPrivate   Visibility = "private"
+)
+
+func (e *Visibility) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = Visibility(s)
+	case string:
+		*e = Visibility(s)
+	default:
+		return fmt.Errorf("unsupported scan type for Visibility: %T", src)
+	}
+	return nil
+}
+
+type NullVisibility struct {
+	Visibility Visibility `json:"visibility"`
+	Valid      bool       `json:"valid"` // Valid is true if Visibility is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullVisibility) Scan(value interface{}) error {
+	if value == nil {
+		ns.Visibility, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.Visibility.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullVisibility) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.Visibility), nil
+}
+
+type ActivityEvent struct {
+	ID        string    `json:"id"`
+	ActorID   string    `json:"actor_id"`
+	Type      string    `json:"type"`
+	TargetID  string    `json:"target_id"`
+	BookID    *string   `json:"book_id"`
+	Payload   []byte    `json:"payload"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Article struct {
+	ID               string                   `json:"id"`
+	Title            string                   `json:"title"`
+	Type             string                   `json:"type"`
+	AuthorID         *string                  `json:"author_id"`
+	BookID           *string                  `json:"book_id"`
+	Excerpt          string                   `json:"excerpt"`
+	CreatedAt        *time.Time               `json:"created_at"`
+	Likes            *int32                   `json:"likes"`
+	Views            *int32                   `json:"views"`
+	ReadingMinutes   *int32                   `json:"reading_minutes"`
+	CoverURL         *string                  `json:"cover_url"`
+	Verified         *bool                    `json:"verified"`
+	VerificationType *models.VerificationType `json:"verification_type"`
+	NoSpoilers       bool                     `json:"no_spoilers"`
+	Readiness        *string                  `json:"readiness"`
+	Content          []byte                   `json:"content"`
+	UpdatedAt        time.Time                `json:"updated_at"`
+	Version          int32                    `json:"version"`
+	DeletedAt        *time.Time               `json:"deleted_at"`
+	HiddenAt         *time.Time               `json:"hidden_at"`
+}
+
+type AuditLog struct {
+	ID            int64     `json:"id"`
+	ActorID       *string   `json:"actor_id"`
+	ActorUsername *string   `json:"actor_username"`
+	ActorRole     *string   `json:"actor_role"`
+	Action        string    `json:"action"`
+	TargetType    string    `json:"target_type"`
+	TargetID      string    `json:"target_id"`
+	Before        []byte    `json:"before"`
+	After         []byte    `json:"after"`
+	IP            *string   `json:"ip"`
+	RequestID     *string   `json:"request_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type Book struct {
+	ID               string                   `json:"id"`
+	Title            string                   `json:"title"`
+	OriginalTitle    *string                  `json:"original_title"`
+	Author           string                   `json:"author"`
+	Year             *int32                   `json:"year"`
+	Genres           []string                 `json:"genres"`
+	AgeRating        *models.AgeRating        `json:"age_rating"`
+	AuthorCountry    *string                  `json:"author_country"`
+	Description      string                   `json:"description"`
+	CoverURL         *string                  `json:"cover_url"`
+	PagesCount       int32                    `json:"pages_count"`
+	Verified         *bool                    `json:"verified"`
+	VerificationType *models.VerificationType `json:"verification_type"`
+	CreatedBy        *string                  `json:"created_by"`
+	CreatedAt        *time.Time               `json:"created_at"`
+	Tags             []string                 `json:"tags"`
+	AverageRating    *float64                 `json:"average_rating"`
+	RatingCount      *int32                   `json:"rating_count"`
+	UpdatedAt        time.Time                `json:"updated_at"`
+	Version          int32                    `json:"version"`
+	DeletedAt        *time.Time               `json:"deleted_at"`
+}
+
+type BookPart struct {
+	ID            string    `json:"id"`
+	BookID        *string   `json:"book_id"`
+	Title         string    `json:"title"`
+	OrderNum      int32     `json:"order_num"`
+	PageStart     *int32    `json:"page_start"`
+	PageEnd       *int32    `json:"page_end"`
+	MoodTags      []string  `json:"mood_tags"`
+	AverageRating *float64  `json:"average_rating"`
+	Content       string    `json:"content"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Version       int32     `json:"version"`
+}
+
+type BookProposal struct {
+	ID             string     `json:"id"`
+	BookID         string     `json:"book_id"`
+	ProposerID     *string    `json:"proposer_id"`
+	Changes        []byte     `json:"changes"`
+	Comment        *string    `json:"comment"`
+	BookVersion    int32      `json:"book_version"`
+	Status         string     `json:"status"`
+	AIApproved     *bool      `json:"ai_approved"`
+	AIConfidence   *float64   `json:"ai_confidence"`
+	AINotes        *string    `json:"ai_notes"`
+	ResolvedBy     *string    `json:"resolved_by"`
+	ResolutionNote *string    `json:"resolution_note"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type BookProposalVote struct {
+	ProposalID string    `json:"proposal_id"`
+	UserID     string    `json:"user_id"`
+	Approve    bool      `json:"approve"`
+	Trusted    bool      `json:"trusted"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type BookSimilarity struct {
+	BookID        string    `json:"book_id"`
+	SimilarBookID string    `json:"similar_book_id"`
+	Score         float64   `json:"score"`
+	Reasons       []string  `json:"reasons"`
+	ComputedAt    time.Time `json:"computed_at"`
+}
+
+type Challenge struct {
+	ID           string     `json:"id"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	Type         string     `json:"type"`
+	TargetCount  int32      `json:"target_count"`
+	RewardPoints int32      `json:"reward_points"`
+	CreatedAt    *time.Time `json:"created_at"`
+	RewardType   string     `json:"reward_type"`
+}
+
+type Character struct {
+	ID              string  `json:"id"`
+	BookID          *string `json:"book_id"`
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	Source          string  `json:"source"`
+	Verified        *bool   `json:"verified"`
+	PopularityScore *int32  `json:"popularity_score"`
+}
+
+type CharacterIllustration struct {
+	ID         string     `json:"id"`
+	ImageUrl   *string    `json:"image_url"`
+	AuthorName string     `json:"author_name"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
+type CharacterProfile struct {
+	ID                    string     `json:"id"`
+	BookID                *string    `json:"book_id"`
+	Name                  string     `json:"name"`
+	Aliases               []string   `json:"aliases"`
+	ImageUrl              *string    `json:"image_url"`
+	Age                   *string    `json:"age"`
+	Height                *string    `json:"height"`
+	Weight                *string    `json:"weight"`
+	SocialStatus          *string    `json:"social_status"`
+	DescriptionNoSpoilers string     `json:"description_no_spoilers"`
+	DescriptionSpoilers   string     `json:"description_spoilers"`
+	QuotesNoSpoilers      []string   `json:"quotes_no_spoilers"`
+	QuotesSpoilers        []string   `json:"quotes_spoilers"`
+	FavoritedByUserIds    []string   `json:"favorited_by_user_ids"`
+	CreatedAt             *time.Time `json:"created_at"`
+	UpdatedAt             *time.Time `json:"updated_at"`
+	Version               int32      `json:"version"`
+	HiddenAt              *time.Time `json:"hidden_at"`
+}
+
+type CharacterProfileIllustration struct {
+	CharacterProfileID string `json:"character_profile_id"`
+	IllustrationID     string `json:"illustration_id"`
+}
+
+type Comment struct {
+	ID              string     `json:"id"`
+	UserID          *string    `json:"user_id"`
+	BookID          *string    `json:"book_id"`
+	PartID          *string    `json:"part_id"`
+	Text            string     `json:"text"`
+	Likes           *int32     `json:"likes"`
+	CreatedAt       *time.Time `json:"created_at"`
+	ParentCommentID *string    `json:"parent_comment_id"`
+	ReplyToUserID   *string    `json:"reply_to_user_id"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+	HiddenAt        *time.Time `json:"hidden_at"`
+}
+
+type ContentReport struct {
+	ID             string     `json:"id"`
+	TargetType     string     `json:"target_type"`
+	TargetID       string     `json:"target_id"`
+	ReporterID     string     `json:"reporter_id"`
+	Reason         string     `json:"reason"`
+	Details        *string    `json:"details"`
+	Status         string     `json:"status"`
+	ClaimedBy      *string    `json:"claimed_by"`
+	ClaimedAt      *time.Time `json:"claimed_at"`
+	ResolvedBy     *string    `json:"resolved_by"`
+	ResolutionNote *string    `json:"resolution_note"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type FeedItem struct {
+	UserID    string    `json:"user_id"`
+	EventID   string    `json:"event_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Playlist struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	MoodTag   string     `json:"mood_tag"`
+	Tracks    []string   `json:"tracks"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+type Quote struct {
+	ID        string     `json:"id"`
+	UserID    *string    `json:"user_id"`
+	BookID    *string    `json:"book_id"`
+	PartID    *string    `json:"part_id"`
+	Text      string     `json:"text"`
+	CreatedAt *time.Time `json:"created_at"`
+	HiddenAt  *time.Time `json:"hidden_at"`
+}
+
+type RateLimitBucket struct {
+	Key       string    `json:"key"`
+	Tokens    float64   `json:"tokens"`
+	Allowed   bool      `json:"allowed"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Review struct {
+	ID                 string     `json:"id"`
+	UserID             *string    `json:"user_id"`
+	BookID             *string    `json:"book_id"`
+	Rating             *int32     `json:"rating"`
+	Text               string     `json:"text"`
+	LikedCharacters    []string   `json:"liked_characters"`
+	DislikedCharacters []string   `json:"disliked_characters"`
+	BestParts          []string   `json:"best_parts"`
+	CreatedAt          *time.Time `json:"created_at"`
+	HiddenAt           *time.Time `json:"hidden_at"`
+}
+
+type Revision struct {
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	Version    int32     `json:"version"`
+	EditorID   *string   `json:"editor_id"`
+	Comment    *string   `json:"comment"`
+	Changes    []byte    `json:"changes"`
+	Snapshot   []byte    `json:"snapshot"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type User struct {
+	ID                 string            `json:"id"`
+	Username           string            `json:"username"`
+	Email              string            `json:"email"`
+	PasswordHash       string            `json:"password_hash"`
+	AvatarURL          *string           `json:"avatar_url"`
+	Role               models.UserRole   `json:"role"`
+	CreatedAt          *time.Time        `json:"created_at"`
+	BooksRead          *int32            `json:"books_read"`
+	ReviewsCount       *int32            `json:"reviews_count"`
+	LikesReceived      *int32            `json:"likes_received"`
+	ProfileVisibility  models.Visibility `json:"profile_visibility"`
+	ActivityVisibility models.Visibility `json:"activity_visibility"`
+	DeletedAt          *time.Time        `json:"deleted_at"`
+	Strikes            int32             `json:"strikes"`
+	SuspendedUntil     *time.Time        `json:"suspended_until"`
+}
+
+type UserBookProgress struct {
+	ID               string     `json:"id"`
+	UserID           *string    `json:"user_id"`
+	BookID           *string    `json:"book_id"`
+	CompletedPartIds []string   `json:"completed_part_ids"`
+	CurrentPartID    *string    `json:"current_part_id"`
+	IsCompleted      *bool      `json:"is_completed"`
+	CompletedAt      *time.Time `json:"completed_at"`
+}
+
+type UserChallengeProgress struct {
+	ID            string     `json:"id"`
+	UserID        string     `json:"user_id"`
+	ChallengeID   string     `json:"challenge_id"`
+	Status        string     `json:"status"`
+	ProgressCount int32      `json:"progress_count"`
+	StartedAt     time.Time  `json:"started_at"`
+	CompletedAt   *time.Time `json:"completed_at"`
+}
+
+type UserCharacterFavorite struct {
+	ID          string     `json:"id"`
+	UserID      *string    `json:"user_id"`
+	CharacterID *string    `json:"character_id"`
+	CreatedAt   *time.Time `json:"created_at"`
+}
+
+type UserFavorite struct {
+	ID        string     `json:"id"`
+	UserID    *string    `json:"user_id"`
+	BookID    *string    `json:"book_id"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+type UserFollow struct {
+	FollowerID string    `json:"follower_id"`
+	FolloweeID string    `json:"followee_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type UserReadingSession struct {
+	ID              string     `json:"id"`
+	UserID          *string    `json:"user_id"`
+	BookID          *string    `json:"book_id"`
+	PartID          *string    `json:"part_id"`
+	StartedAt       *time.Time `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"`
+	PagesRead       *int32     `json:"pages_read"`
+	DurationMinutes *int32     `json:"duration_minutes"`
+}
+
+type UserShelf struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	Position  int32     `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserShelfBook struct {
+	UserID    string      `json:"user_id"`
+	BookID    string      `json:"book_id"`
+	Status    ShelfStatus `json:"status"`
+	Note      *string     `json:"note"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+type UserShelfItem struct {
+	ShelfID  string    `json:"shelf_id"`
+	BookID   string    `json:"book_id"`
+	Position int32     `json:"position"`
+	Note     *string   `json:"note"`
+	AddedAt  time.Time `json:"added_at"`
+}
@@ -0,0 +1,95 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+
+package db
+
+import (
+	"context"
+)
+
+type Querier interface {
+	AddUserStrike(ctx context.Context, id string) (int32, error)
+	ClaimContentReport(ctx context.Context, arg ClaimContentReportParams) error
+	CountBooks(ctx context.Context) (int64, error)
+	CountOpenTargetReporters(ctx context.Context, arg CountOpenTargetReportersParams) (int32, error)
+	CountUsers(ctx context.Context) (int64, error)
+	CreateArticle(ctx context.Context, arg CreateArticleParams) (CreateArticleRow, error)
+	CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error
+	// Исходное состояние записывается перед первой отслеживаемой правкой, если его еще нет
+	CreateBaselineRevision(ctx context.Context, arg CreateBaselineRevisionParams) error
+	CreateBook(ctx context.Context, arg CreateBookParams) (CreateBookRow, error)
+	CreateBookPart(ctx context.Context, arg CreateBookPartParams) (CreateBookPartRow, error)
+	CreateBookProposal(ctx context.Context, arg CreateBookProposalParams) (CreateBookProposalRow, error)
+	CreateContentReport(ctx context.Context, arg CreateContentReportParams) (CreateContentReportRow, error)
+	CreateRevision(ctx context.Context, arg CreateRevisionParams) error
+	CreateUser(ctx context.Context, arg CreateUserParams) error
+	// Мягкое удаление: статья переносится в корзину
+	DeleteArticle(ctx context.Context, id string) (int64, error)
+	// Мягкое удаление: книга переносится в корзину, части и отзывы остаются до окончательной очистки
+	DeleteBook(ctx context.Context, id string) (int64, error)
+	DeleteBookPart(ctx context.Context, id string) (int64, error)
+	// Мягкое удаление: пользователь не может войти, имя остается занятым до окончательной очистки
+	DeleteUser(ctx context.Context, id string) (int64, error)
+	GetArticle(ctx context.Context, id string) (Article, error)
+	GetBook(ctx context.Context, id string) (Book, error)
+	// Части книги в корзине скрыты вместе с ней
+	GetBookPart(ctx context.Context, id string) (BookPart, error)
+	// Предложение с итогами голосования (trusted_* учитываются при подсчете порога)
+	GetBookProposal(ctx context.Context, id string) (GetBookProposalRow, error)
+	// Жалоба с числом открытых жалоб на тот же объект и его текущим состоянием
+	GetContentReport(ctx context.Context, id string) (GetContentReportRow, error)
+	// Данные голосующего для проверки доверия: роль, возраст аккаунта и число рецензий
+	GetProposalVoter(ctx context.Context, id string) (GetProposalVoterRow, error)
+	GetRevision(ctx context.Context, arg GetRevisionParams) (Revision, error)
+	GetUser(ctx context.Context, id string) (User, error)
+	GetUserByUsername(ctx context.Context, username string) (User, error)
+	// Скрытые по жалобам статьи недоступны читателям, пока модератор не отклонит жалобы
+	GetVisibleArticle(ctx context.Context, id string) (Article, error)
+	HasResolvedTargetReports(ctx context.Context, arg HasResolvedTargetReportsParams) (bool, error)
+	HasRevisions(ctx context.Context, arg HasRevisionsParams) (bool, error)
+	// Сортировка задается параметром, а не подстановкой в текст запроса:
+	// неизвестное поле сортирует по created_at.
+	ListArticles(ctx context.Context, arg ListArticlesParams) ([]ListArticlesRow, error)
+	// Пустой фильтр не ограничивает выборку; before_id - курсор для постраничной выгрузки
+	ListAuditEntries(ctx context.Context, arg ListAuditEntriesParams) ([]AuditLog, error)
+	ListBookParts(ctx context.Context, bookID *string) ([]BookPart, error)
+	ListBookProposals(ctx context.Context, arg ListBookProposalsParams) ([]ListBookProposalsRow, error)
+	// Очередь модераторов: сначала давние предложения
+	ListBookProposalsByStatus(ctx context.Context, arg ListBookProposalsByStatusParams) ([]ListBookProposalsByStatusRow, error)
+	ListBooks(ctx context.Context, arg ListBooksParams) ([]Book, error)
+	// Очередь модераторов: сначала давние жалобы; пустые фильтры не ограничивают выборку
+	ListContentReports(ctx context.Context, arg ListContentReportsParams) ([]ListContentReportsRow, error)
+	ListRevisions(ctx context.Context, arg ListRevisionsParams) ([]ListRevisionsRow, error)
+	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	// Блокировка предложения до конца транзакции: голоса и решения модераторов идут по очереди
+	LockBookProposal(ctx context.Context, id string) (string, error)
+	// Блокировка жалобы до конца транзакции (взятие в работу)
+	LockContentReport(ctx context.Context, id string) (ContentReport, error)
+	// Блокировка всех открытых жалоб на объект: решение по объекту закрывает их вместе.
+	// Порядок по id исключает взаимную блокировку параллельных решений.
+	LockOpenTargetReports(ctx context.Context, arg LockOpenTargetReportsParams) ([]LockOpenTargetReportsRow, error)
+	ReleaseContentReport(ctx context.Context, id string) error
+	ResolveBookProposal(ctx context.Context, arg ResolveBookProposalParams) error
+	ResolveOpenTargetReports(ctx context.Context, arg ResolveOpenTargetReportsParams) (int64, error)
+	SetBookProposalStatus(ctx context.Context, arg SetBookProposalStatusParams) error
+	SetUserSuspension(ctx context.Context, arg SetUserSuspensionParams) (int64, error)
+	UpdateArticle(ctx context.Context, arg UpdateArticleParams) (UpdateArticleRow, error)
+	UpdateArticleCoverURL(ctx context.Context, arg UpdateArticleCoverURLParams) (int64, error)
+	// Обновление применяется, только если с момента чтения книгу никто не изменил;
+	// пустой результат - книга удалена или версия устарела.
+	// Рейтинг не перезаписывается: его меняет только RefreshBookRating, который не повышает версию,
+	// поэтому запись прочитанного рейтинга потеряла бы конкурентный пересчет.
+	UpdateBook(ctx context.Context, arg UpdateBookParams) (UpdateBookRow, error)
+	UpdateBookCoverURL(ctx context.Context, arg UpdateBookCoverURLParams) (int64, error)
+	UpdateBookPart(ctx context.Context, arg UpdateBookPartParams) (UpdateBookPartRow, error)
+	UpdateUser(ctx context.Context, arg UpdateUserParams) error
+	UpdateUserAvatarURL(ctx context.Context, arg UpdateUserAvatarURLParams) (int64, error)
+	UpsertArticle(ctx context.Context, arg UpsertArticleParams) error
+	UpsertBook(ctx context.Context, arg UpsertBookParams) error
+	UpsertBookPart(ctx context.Context, arg UpsertBookPartParams) error
+	UpsertBookProposalVote(ctx context.Context, arg UpsertBookProposalVoteParams) error
+	UpsertUser(ctx context.Context, arg UpsertUserParams) error
+}
+
+var _ Querier = (*Queries)(nil)
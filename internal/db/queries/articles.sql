@@ -0,0 +1,64 @@
+-- name: CreateArticle :one
+INSERT INTO articles (
+    title, type, author_id, book_id, excerpt, reading_minutes, cover_url,
+    verified, verification_type, no_spoilers, readiness, content
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+)
+RETURNING id, created_at, version, updated_at;
+
+-- name: GetArticle :one
+SELECT * FROM articles
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- Скрытые по жалобам статьи недоступны читателям, пока модератор не отклонит жалобы
+-- name: GetVisibleArticle :one
+SELECT * FROM articles
+WHERE id = $1 AND deleted_at IS NULL AND hidden_at IS NULL;
+
+-- Сортировка задается параметром, а не подстановкой в текст запроса:
+-- неизвестное поле сортирует по created_at.
+-- name: ListArticles :many
+SELECT id, title, type, author_id, book_id, excerpt, likes, views, cover_url, version, updated_at
+FROM articles
+WHERE deleted_at IS NULL AND hidden_at IS NULL
+ORDER BY
+    CASE WHEN sqlc.arg(sort_by)::text = 'likes' AND sqlc.arg(ascending)::bool THEN likes END ASC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'likes' AND NOT sqlc.arg(ascending)::bool THEN likes END DESC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'views' AND sqlc.arg(ascending)::bool THEN views END ASC,
+    CASE WHEN sqlc.arg(sort_by)::text = 'views' AND NOT sqlc.arg(ascending)::bool THEN views END DESC,
+    CASE WHEN sqlc.arg(ascending)::bool THEN created_at END ASC,
+    created_at DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: UpdateArticle :one
+UPDATE articles SET
+    title = $2, type = $3, excerpt = $4, reading_minutes = $5, cover_url = $6,
+    verified = $7, verification_type = $8, no_spoilers = $9, readiness = $10,
+    version = version + 1
+WHERE id = $1 AND version = sqlc.arg(expected_version) AND deleted_at IS NULL
+RETURNING version, updated_at;
+
+-- name: UpdateArticleCoverURL :execrows
+UPDATE articles SET cover_url = $2, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- Мягкое удаление: статья переносится в корзину
+-- name: DeleteArticle :execrows
+UPDATE articles SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: UpsertArticle :exec
+INSERT INTO articles (
+    id, title, type, author_id, book_id, excerpt, created_at, likes, views, reading_minutes,
+    cover_url, verified, verification_type, no_spoilers, readiness, content
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+)
+ON CONFLICT (id) DO UPDATE SET
+    title = EXCLUDED.title, type = EXCLUDED.type, author_id = EXCLUDED.author_id,
+    book_id = EXCLUDED.book_id, excerpt = EXCLUDED.excerpt, created_at = EXCLUDED.created_at,
+    likes = EXCLUDED.likes, views = EXCLUDED.views, reading_minutes = EXCLUDED.reading_minutes,
+    cover_url = EXCLUDED.cover_url, verified = EXCLUDED.verified,
+    verification_type = EXCLUDED.verification_type, no_spoilers = EXCLUDED.no_spoilers,
+    readiness = EXCLUDED.readiness, content = EXCLUDED.content;
@@ -0,0 +1,42 @@
+-- name: CreateBookPart :one
+INSERT INTO book_parts (
+    id, book_id, title, content, order_num, page_start, page_end, mood_tags, average_rating
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING id, version, updated_at;
+
+-- Части книги в корзине скрыты вместе с ней
+-- name: GetBookPart :one
+SELECT * FROM book_parts
+WHERE book_parts.id = $1
+  AND EXISTS (SELECT 1 FROM books WHERE books.id = book_parts.book_id AND books.deleted_at IS NULL);
+
+-- name: ListBookParts :many
+SELECT * FROM book_parts
+WHERE book_parts.book_id = $1
+  AND EXISTS (SELECT 1 FROM books WHERE books.id = book_parts.book_id AND books.deleted_at IS NULL)
+ORDER BY order_num;
+
+-- name: UpdateBookPart :one
+UPDATE book_parts SET
+    title = $2, content = $3, order_num = $4, page_start = $5, page_end = $6,
+    mood_tags = $7, average_rating = $8, version = version + 1
+WHERE book_parts.id = $1 AND book_parts.version = sqlc.arg(expected_version)
+  AND EXISTS (SELECT 1 FROM books WHERE books.id = book_parts.book_id AND books.deleted_at IS NULL)
+RETURNING version, updated_at;
+
+-- name: DeleteBookPart :execrows
+DELETE FROM book_parts
+WHERE id = $1;
+
+-- name: UpsertBookPart :exec
+INSERT INTO book_parts (
+    id, book_id, title, content, order_num, page_start, page_end, mood_tags, average_rating
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (id) DO UPDATE SET
+    book_id = EXCLUDED.book_id, title = EXCLUDED.title, content = EXCLUDED.content,
+    order_num = EXCLUDED.order_num, page_start = EXCLUDED.page_start, page_end = EXCLUDED.page_end,
+    mood_tags = EXCLUDED.mood_tags, average_rating = EXCLUDED.average_rating;
@@ -0,0 +1,73 @@
+-- name: CreateBookProposal :one
+INSERT INTO book_proposals (
+    book_id, proposer_id, changes, comment, book_version, ai_approved, ai_confidence, ai_notes
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING id, status, created_at;
+
+-- Предложение с итогами голосования (trusted_* учитываются при подсчете порога)
+-- name: GetBookProposal :one
+SELECT p.*,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND v.approve)::int AS approvals,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND NOT v.approve)::int AS rejections,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND v.approve AND v.trusted)::int AS trusted_approvals,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND NOT v.approve AND v.trusted)::int AS trusted_rejections
+FROM book_proposals p
+JOIN books b ON b.id = p.book_id AND b.deleted_at IS NULL
+WHERE p.id = $1;
+
+-- Блокировка предложения до конца транзакции: голоса и решения модераторов идут по очереди
+-- name: LockBookProposal :one
+SELECT p.status FROM book_proposals p
+JOIN books b ON b.id = p.book_id AND b.deleted_at IS NULL
+WHERE p.id = $1
+FOR UPDATE OF p;
+
+-- name: ListBookProposals :many
+SELECT p.*,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND v.approve)::int AS approvals,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND NOT v.approve)::int AS rejections,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND v.approve AND v.trusted)::int AS trusted_approvals,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND NOT v.approve AND v.trusted)::int AS trusted_rejections
+FROM book_proposals p
+WHERE p.book_id = $1
+  AND (sqlc.narg(status)::text IS NULL OR p.status = sqlc.narg(status)::text)
+ORDER BY p.created_at DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- Очередь модераторов: сначала давние предложения
+-- name: ListBookProposalsByStatus :many
+SELECT p.*,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND v.approve)::int AS approvals,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND NOT v.approve)::int AS rejections,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND v.approve AND v.trusted)::int AS trusted_approvals,
+       (SELECT COUNT(*) FROM book_proposal_votes v WHERE v.proposal_id = p.id AND NOT v.approve AND v.trusted)::int AS trusted_rejections
+FROM book_proposals p
+JOIN books b ON b.id = p.book_id AND b.deleted_at IS NULL
+WHERE p.status = $1
+ORDER BY p.created_at
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: UpsertBookProposalVote :exec
+INSERT INTO book_proposal_votes (proposal_id, user_id, approve, trusted)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (proposal_id, user_id) DO UPDATE
+SET approve = EXCLUDED.approve, trusted = EXCLUDED.trusted, created_at = NOW();
+
+-- name: SetBookProposalStatus :exec
+UPDATE book_proposals
+SET status = $2
+WHERE id = $1;
+
+-- name: ResolveBookProposal :exec
+UPDATE book_proposals
+SET status = $2, resolved_by = $3, resolution_note = $4, resolved_at = NOW()
+WHERE id = $1;
+
+-- Данные голосующего для проверки доверия: роль, возраст аккаунта и число рецензий
+-- name: GetProposalVoter :one
+SELECT u.id, u.role, u.created_at,
+       (SELECT COUNT(*) FROM reviews r WHERE r.user_id = u.id)::int AS reviews
+FROM users u
+WHERE u.id = $1 AND u.deleted_at IS NULL;
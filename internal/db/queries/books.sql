@@ -0,0 +1,63 @@
+-- name: CreateBook :one
+INSERT INTO books (
+    title, original_title, author, year, genres, age_rating,
+    author_country, description, cover_url, pages_count, tags,
+    verified, verification_type, created_by, created_at,
+    average_rating, rating_count
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+)
+RETURNING id, version, updated_at;
+
+-- name: GetBook :one
+SELECT * FROM books
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListBooks :many
+SELECT * FROM books
+WHERE deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: CountBooks :one
+SELECT COUNT(*) FROM books
+WHERE deleted_at IS NULL;
+
+-- Обновление применяется, только если с момента чтения книгу никто не изменил;
+-- пустой результат - книга удалена или версия устарела.
+-- Рейтинг не перезаписывается: его меняет только RefreshBookRating, который не повышает версию,
+-- поэтому запись прочитанного рейтинга потеряла бы конкурентный пересчет.
+-- name: UpdateBook :one
+UPDATE books SET
+    title = $2, original_title = $3, author = $4, year = $5, genres = $6,
+    age_rating = $7, author_country = $8, description = $9, cover_url = $10,
+    pages_count = $11, tags = $12, verified = $13, verification_type = $14,
+    version = version + 1
+WHERE id = $1 AND version = sqlc.arg(expected_version) AND deleted_at IS NULL
+RETURNING version, updated_at, average_rating, rating_count;
+
+-- name: UpdateBookCoverURL :execrows
+UPDATE books SET cover_url = $2, version = version + 1
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- Мягкое удаление: книга переносится в корзину, части и отзывы остаются до окончательной очистки
+-- name: DeleteBook :execrows
+UPDATE books SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: UpsertBook :exec
+INSERT INTO books (
+    id, title, original_title, author, year, genres, age_rating,
+    author_country, description, cover_url, pages_count, tags,
+    verified, verification_type, created_at, average_rating, rating_count
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
+)
+ON CONFLICT (id) DO UPDATE SET
+    title = EXCLUDED.title, original_title = EXCLUDED.original_title, author = EXCLUDED.author,
+    year = EXCLUDED.year, genres = EXCLUDED.genres, age_rating = EXCLUDED.age_rating,
+    author_country = EXCLUDED.author_country, description = EXCLUDED.description,
+    cover_url = EXCLUDED.cover_url, pages_count = EXCLUDED.pages_count, tags = EXCLUDED.tags,
+    verified = EXCLUDED.verified, verification_type = EXCLUDED.verification_type,
+    created_at = EXCLUDED.created_at, average_rating = EXCLUDED.average_rating,
+    rating_count = EXCLUDED.rating_count;
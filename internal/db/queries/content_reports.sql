@@ -0,0 +1,78 @@
+-- name: CreateContentReport :one
+INSERT INTO content_reports (target_type, target_id, reporter_id, reason, details)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, status, created_at;
+
+-- Жалоба с числом открытых жалоб на тот же объект и его текущим состоянием
+-- name: GetContentReport :one
+SELECT r.*,
+       (SELECT COUNT(*) FROM content_reports o
+        WHERE o.target_type = r.target_type AND o.target_id = r.target_id AND o.status = 'open')::int AS open_reports,
+       COALESCE(CASE r.target_type
+           WHEN 'review' THEN (SELECT t.hidden_at IS NOT NULL FROM reviews t WHERE t.id = r.target_id)
+           WHEN 'comment' THEN (SELECT t.hidden_at IS NOT NULL FROM comments t WHERE t.id = r.target_id)
+           WHEN 'quote' THEN (SELECT t.hidden_at IS NOT NULL FROM quotes t WHERE t.id = r.target_id)
+           WHEN 'article' THEN (SELECT t.hidden_at IS NOT NULL FROM articles t WHERE t.id = r.target_id)
+           WHEN 'character_profile' THEN (SELECT t.hidden_at IS NOT NULL FROM character_profiles t WHERE t.id = r.target_id)
+       END, false)::bool AS target_hidden
+FROM content_reports r
+WHERE r.id = $1;
+
+-- Очередь модераторов: сначала давние жалобы; пустые фильтры не ограничивают выборку
+-- name: ListContentReports :many
+SELECT r.*,
+       (SELECT COUNT(*) FROM content_reports o
+        WHERE o.target_type = r.target_type AND o.target_id = r.target_id AND o.status = 'open')::int AS open_reports,
+       COALESCE(CASE r.target_type
+           WHEN 'review' THEN (SELECT t.hidden_at IS NOT NULL FROM reviews t WHERE t.id = r.target_id)
+           WHEN 'comment' THEN (SELECT t.hidden_at IS NOT NULL FROM comments t WHERE t.id = r.target_id)
+           WHEN 'quote' THEN (SELECT t.hidden_at IS NOT NULL FROM quotes t WHERE t.id = r.target_id)
+           WHEN 'article' THEN (SELECT t.hidden_at IS NOT NULL FROM articles t WHERE t.id = r.target_id)
+           WHEN 'character_profile' THEN (SELECT t.hidden_at IS NOT NULL FROM character_profiles t WHERE t.id = r.target_id)
+       END, false)::bool AS target_hidden
+FROM content_reports r
+WHERE (sqlc.narg(status)::text IS NULL OR r.status = sqlc.narg(status)::text)
+  AND (sqlc.narg(target_type)::text IS NULL OR r.target_type = sqlc.narg(target_type)::text)
+  AND (sqlc.narg(target_id)::uuid IS NULL OR r.target_id = sqlc.narg(target_id)::uuid)
+  AND (sqlc.narg(claimed_by)::uuid IS NULL OR r.claimed_by = sqlc.narg(claimed_by)::uuid)
+ORDER BY r.created_at
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- Блокировка жалобы до конца транзакции (взятие в работу)
+-- name: LockContentReport :one
+SELECT * FROM content_reports
+WHERE id = $1
+FOR UPDATE;
+
+-- Блокировка всех открытых жалоб на объект: решение по объекту закрывает их вместе.
+-- Порядок по id исключает взаимную блокировку параллельных решений.
+-- name: LockOpenTargetReports :many
+SELECT id, claimed_by FROM content_reports
+WHERE target_type = $1 AND target_id = $2 AND status = 'open'
+ORDER BY id
+FOR UPDATE;
+
+-- name: CountOpenTargetReporters :one
+SELECT COUNT(DISTINCT reporter_id)::int FROM content_reports
+WHERE target_type = $1 AND target_id = $2 AND status = 'open';
+
+-- name: HasResolvedTargetReports :one
+SELECT EXISTS (
+    SELECT 1 FROM content_reports
+    WHERE target_type = $1 AND target_id = $2 AND status = 'resolved'
+);
+
+-- name: ClaimContentReport :exec
+UPDATE content_reports
+SET claimed_by = $2, claimed_at = NOW()
+WHERE id = $1;
+
+-- name: ReleaseContentReport :exec
+UPDATE content_reports
+SET claimed_by = NULL, claimed_at = NULL
+WHERE id = $1;
+
+-- name: ResolveOpenTargetReports :execrows
+UPDATE content_reports
+SET status = $3, resolved_by = $4, resolution_note = $5, resolved_at = NOW()
+WHERE target_type = $1 AND target_id = $2 AND status = 'open';
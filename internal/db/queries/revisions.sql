@@ -0,0 +1,29 @@
+-- name: CreateRevision :exec
+INSERT INTO revisions (
+    entity_type, entity_id, version, editor_id, comment, changes, snapshot
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- Исходное состояние записывается перед первой отслеживаемой правкой, если его еще нет
+-- name: CreateBaselineRevision :exec
+INSERT INTO revisions (entity_type, entity_id, version, snapshot)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (entity_type, entity_id, version) DO NOTHING;
+
+-- name: HasRevisions :one
+SELECT EXISTS (
+    SELECT 1 FROM revisions
+    WHERE entity_type = $1 AND entity_id = $2
+);
+
+-- name: ListRevisions :many
+SELECT entity_type, entity_id, version, editor_id, comment, changes, created_at
+FROM revisions
+WHERE entity_type = $1 AND entity_id = $2
+ORDER BY version DESC
+LIMIT $3 OFFSET $4;
+
+-- name: GetRevision :one
+SELECT * FROM revisions
+WHERE entity_type = $1 AND entity_id = $2 AND version = $3;
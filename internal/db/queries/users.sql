@@ -0,0 +1,61 @@
+-- name: CreateUser :exec
+INSERT INTO users (
+    id, username, email, password_hash, avatar_url, role, created_at,
+    books_read, reviews_count, likes_received, profile_visibility, activity_visibility
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+);
+
+-- name: GetUser :one
+SELECT * FROM users
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetUserByUsername :one
+SELECT * FROM users
+WHERE username = $1 AND deleted_at IS NULL;
+
+-- name: ListUsers :many
+SELECT * FROM users
+WHERE deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: CountUsers :one
+SELECT COUNT(*) FROM users
+WHERE deleted_at IS NULL;
+
+-- name: UpdateUser :exec
+UPDATE users SET
+    username = $2, avatar_url = $3, role = $4,
+    books_read = $5, reviews_count = $6, likes_received = $7,
+    profile_visibility = $8, activity_visibility = $9
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: UpdateUserAvatarURL :execrows
+UPDATE users SET avatar_url = $2
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- Мягкое удаление: пользователь не может войти, имя остается занятым до окончательной очистки
+-- name: DeleteUser :execrows
+UPDATE users SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: UpsertUser :exec
+INSERT INTO users (
+    id, username, email, password_hash, avatar_url, role, profile_visibility, activity_visibility
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (id) DO UPDATE SET
+    username = EXCLUDED.username, email = EXCLUDED.email, password_hash = EXCLUDED.password_hash,
+    avatar_url = EXCLUDED.avatar_url, role = EXCLUDED.role,
+    profile_visibility = EXCLUDED.profile_visibility, activity_visibility = EXCLUDED.activity_visibility;
+
+-- name: AddUserStrike :one
+UPDATE users SET strikes = strikes + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING strikes;
+
+-- name: SetUserSuspension :execrows
+UPDATE users SET suspended_until = $2
+WHERE id = $1 AND deleted_at IS NULL;
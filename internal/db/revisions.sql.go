@@ -0,0 +1,170 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: revisions.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const createBaselineRevision = `-- name: CreateBaselineRevision :exec
+INSERT INTO revisions (entity_type, entity_id, version, snapshot)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (entity_type, entity_id, version) DO NOTHING
+`
+
+type CreateBaselineRevisionParams struct {
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	Version    int32  `json:"version"`
+	Snapshot   []byte `json:"snapshot"`
+}
+
+// Исходное состояние записывается перед первой отслеживаемой правкой, если его еще нет
+func (q *Queries) CreateBaselineRevision(ctx context.Context, arg CreateBaselineRevisionParams) error {
+	_, err := q.db.Exec(ctx, createBaselineRevision,
+		arg.EntityType,
+		arg.EntityID,
+		arg.Version,
+		arg.Snapshot,
+	)
+	return err
+}
+
+const createRevision = `-- name: CreateRevision :exec
+INSERT INTO revisions (
+    entity_type, entity_id, version, editor_id, comment, changes, snapshot
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+`
+
+type CreateRevisionParams struct {
+	EntityType string  `json:"entity_type"`
+	EntityID   string  `json:"entity_id"`
+	Version    int32   `json:"version"`
+	EditorID   *string `json:"editor_id"`
+	Comment    *string `json:"comment"`
+	Changes    []byte  `json:"changes"`
+	Snapshot   []byte  `json:"snapshot"`
+}
+
+func (q *Queries) CreateRevision(ctx context.Context, arg CreateRevisionParams) error {
+	_, err := q.db.Exec(ctx, createRevision,
+		arg.EntityType,
+		arg.EntityID,
+		arg.Version,
+		arg.EditorID,
+		arg.Comment,
+		arg.Changes,
+		arg.Snapshot,
+	)
+	return err
+}
+
+const getRevision = `-- name: GetRevision :one
+SELECT entity_type, entity_id, version, editor_id, comment, changes, snapshot, created_at FROM revisions
+WHERE entity_type = $1 AND entity_id = $2 AND version = $3
+`
+
+type GetRevisionParams struct {
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	Version    int32  `json:"version"`
+}
+
+func (q *Queries) GetRevision(ctx context.Context, arg GetRevisionParams) (Revision, error) {
+	row := q.db.QueryRow(ctx, getRevision, arg.EntityType, arg.EntityID, arg.Version)
+	var i Revision
+	err := row.Scan(
+		&i.EntityType,
+		&i.EntityID,
+		&i.Version,
+		&i.EditorID,
+		&i.Comment,
+		&i.Changes,
+		&i.Snapshot,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const hasRevisions = `-- name: HasRevisions :one
+SELECT EXISTS (
+    SELECT 1 FROM revisions
+    WHERE entity_type = $1 AND entity_id = $2
+)
+`
+
+type HasRevisionsParams struct {
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+}
+
+func (q *Queries) HasRevisions(ctx context.Context, arg HasRevisionsParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasRevisions, arg.EntityType, arg.EntityID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listRevisions = `-- name: ListRevisions :many
+SELECT entity_type, entity_id, version, editor_id, comment, changes, created_at
+FROM revisions
+WHERE entity_type = $1 AND entity_id = $2
+ORDER BY version DESC
+LIMIT $3 OFFSET $4
+`
+
+type ListRevisionsParams struct {
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	Limit      int32  `json:"limit"`
+	Offset     int32  `json:"offset"`
+}
+
+type ListRevisionsRow struct {
+	EntityType string    `json:"entity_type"`
+	EntityID   string    `json:"entity_id"`
+	Version    int32     `json:"version"`
+	EditorID   *string   `json:"editor_id"`
+	Comment    *string   `json:"comment"`
+	Changes    []byte    `json:"changes"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (q *Queries) ListRevisions(ctx context.Context, arg ListRevisionsParams) ([]ListRevisionsRow, error) {
+	rows, err := q.db.Query(ctx, listRevisions,
+		arg.EntityType,
+		arg.EntityID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRevisionsRow
+	for rows.Next() {
+		var i ListRevisionsRow
+		if err := rows.Scan(
+			&i.EntityType,
+			&i.EntityID,
+			&i.Version,
+			&i.EditorID,
+			&i.Comment,
+			&i.Changes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
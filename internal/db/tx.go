@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultTxRetries - число повторов транзакции при конфликте сериализации или дедлоке
+const defaultTxRetries = 3
+
+// txBackoff - базовая пауза между повторами (удваивается с каждой попыткой)
+const txBackoff = 20 * time.Millisecond
+
+// txKey - ключ транзакции в context.Context
+type txKey struct{}
+
+// afterCommitKey - ключ списка действий после фиксации внешней транзакции в context.Context
+type afterCommitKey struct{}
+
+// Executor - пул или транзакция: выполнение запросов, вложенные транзакции и COPY.
+// *pgxpool.Pool и pgx.Tx реализуют его одинаково, поэтому репозиторий не знает,
+// вызван ли он внутри транзакции.
+type Executor interface {
+	DBTX
+	Begin(ctx context.Context) (pgx.Tx, error)
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// ExecutorFrom - транзакция из контекста, если она открыта через TxManager, иначе пул
+func ExecutorFrom(ctx context.Context, pool *pgxpool.Pool) Executor {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
+// InTx - открыта ли в контексте транзакция TxManager
+func InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return ok
+}
+
+// AfterCommit - выполнение fn после фиксации внешней транзакции из ctx или сразу, если транзакции нет.
+// При откате транзакции fn не выполняется; действия из отмененной точки сохранения выполняются,
+// поэтому fn должна быть безопасной при лишнем вызове (например, сброс кэша).
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*[]func())
+	if !ok {
+		fn()
+		return
+	}
+	*hooks = append(*hooks, fn)
+}
+
+// TxManager - выполнение нескольких вызовов репозиториев в одной транзакции.
+// Транзакция передается через context.Context; вложенный WithinTx создает точку сохранения.
+type TxManager struct {
+	pool       *pgxpool.Pool
+	maxRetries int
+}
+
+// NewTxManager - создание нового TxManager
+func NewTxManager(pool *pgxpool.Pool) *TxManager {
+	return &TxManager{
+		pool:       pool,
+		maxRetries: defaultTxRetries,
+	}
+}
+
+// WithinTx - выполнение fn в транзакции READ COMMITTED
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.WithinTxOptions(ctx, pgx.TxOptions{}, fn)
+}
+
+// WithinSerializableTx - выполнение fn в транзакции SERIALIZABLE (для пересчетов агрегатов,
+// которые не должны терять конкурентные изменения)
+func (m *TxManager) WithinSerializableTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.WithinTxOptions(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}, fn)
+}
+
+// WithinTxOptions - выполнение fn в транзакции с заданными параметрами.
+// Ошибка fn откатывает транзакцию (или точку сохранения для вложенного вызова).
+// Конфликты сериализации и дедлоки повторяются на внешнем уровне, поэтому fn
+// должна быть безопасной для повторного выполнения.
+func (m *TxManager) WithinTxOptions(ctx context.Context, opts pgx.TxOptions, fn func(ctx context.Context) error) (err error) {
+	// Уже внутри транзакции: параметры внешней сохраняются, откат - до точки сохранения
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return pgx.BeginFunc(ctx, tx, func(savepoint pgx.Tx) error {
+			return fn(context.WithValue(ctx, txKey{}, savepoint))
+		})
+	}
+
+	// Спан транзакции объединяет ее запросы и повторы в трассировке
+	ctx, span := tracing.Start(ctx, "transaction", attribute.String("db.transaction.isolation", string(opts.IsoLevel)))
+	defer func() { tracing.End(span, err) }()
+
+	for attempt := 0; ; attempt++ {
+		// Действия после фиксации собираются заново для каждой попытки
+		var hooks []func()
+		err = pgx.BeginTxFunc(ctx, m.pool, opts, func(tx pgx.Tx) error {
+			txCtx := context.WithValue(ctx, txKey{}, tx)
+			return fn(context.WithValue(txCtx, afterCommitKey{}, &hooks))
+		})
+		if err == nil {
+			for _, hook := range hooks {
+				hook()
+			}
+			return nil
+		}
+		if attempt >= m.maxRetries || !IsRetryable(err) {
+			return err
+		}
+
+		delay := txBackoff<<attempt + rand.N(txBackoff)
+		span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", attempt+1), attribute.String("error", err.Error())))
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// IsRetryable - ошибка конфликта сериализации (40001) или дедлока (40P01)
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
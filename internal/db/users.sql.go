@@ -0,0 +1,306 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: users.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+const addUserStrike = `-- name: AddUserStrike :one
+UPDATE users SET strikes = strikes + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING strikes
+`
+
+func (q *Queries) AddUserStrike(ctx context.Context, id string) (int32, error) {
+	row := q.db.QueryRow(ctx, addUserStrike, id)
+	var strikes int32
+	err := row.Scan(&strikes)
+	return strikes, err
+}
+
+const countUsers = `-- name: CountUsers :one
+SELECT COUNT(*) FROM users
+WHERE deleted_at IS NULL
+`
+
+func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createUser = `-- name: CreateUser :exec
+INSERT INTO users (
+    id, username, email, password_hash, avatar_url, role, created_at,
+    books_read, reviews_count, likes_received, profile_visibility, activity_visibility
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+)
+`
+
+type CreateUserParams struct {
+	ID                 string            `json:"id"`
+	Username           string            `json:"username"`
+	Email              string            `json:"email"`
+	PasswordHash       string            `json:"password_hash"`
+	AvatarURL          *string           `json:"avatar_url"`
+	Role               models.UserRole   `json:"role"`
+	CreatedAt          *time.Time        `json:"created_at"`
+	BooksRead          *int32            `json:"books_read"`
+	ReviewsCount       *int32            `json:"reviews_count"`
+	LikesReceived      *int32            `json:"likes_received"`
+	ProfileVisibility  models.Visibility `json:"profile_visibility"`
+	ActivityVisibility models.Visibility `json:"activity_visibility"`
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
+	_, err := q.db.Exec(ctx, createUser,
+		arg.ID,
+		arg.Username,
+		arg.Email,
+		arg.PasswordHash,
+		arg.AvatarURL,
+		arg.Role,
+		arg.CreatedAt,
+		arg.BooksRead,
+		arg.ReviewsCount,
+		arg.LikesReceived,
+		arg.ProfileVisibility,
+		arg.ActivityVisibility,
+	)
+	return err
+}
+
+const deleteUser = `-- name: DeleteUser :execrows
+UPDATE users SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+// Мягкое удаление: пользователь не может войти, имя остается занятым до окончательной очистки
+func (q *Queries) DeleteUser(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, username, email, password_hash, avatar_url, role, created_at, books_read, reviews_count, likes_received, profile_visibility, activity_visibility, deleted_at, strikes, suspended_until FROM users
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUser(ctx context.Context, id string) (User, error) {
+	row := q.db.QueryRow(ctx, getUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.PasswordHash,
+		&i.AvatarURL,
+		&i.Role,
+		&i.CreatedAt,
+		&i.BooksRead,
+		&i.ReviewsCount,
+		&i.LikesReceived,
+		&i.ProfileVisibility,
+		&i.ActivityVisibility,
+		&i.DeletedAt,
+		&i.Strikes,
+		&i.SuspendedUntil,
+	)
+	return i, err
+}
+
+const getUserByUsername = `-- name: GetUserByUsername :one
+SELECT id, username, email, password_hash, avatar_url, role, created_at, books_read, reviews_count, likes_received, profile_visibility, activity_visibility, deleted_at, strikes, suspended_until FROM users
+WHERE username = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByUsername, username)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.PasswordHash,
+		&i.AvatarURL,
+		&i.Role,
+		&i.CreatedAt,
+		&i.BooksRead,
+		&i.ReviewsCount,
+		&i.LikesReceived,
+		&i.ProfileVisibility,
+		&i.ActivityVisibility,
+		&i.DeletedAt,
+		&i.Strikes,
+		&i.SuspendedUntil,
+	)
+	return i, err
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, username, email, password_hash, avatar_url, role, created_at, books_read, reviews_count, likes_received, profile_visibility, activity_visibility, deleted_at, strikes, suspended_until FROM users
+WHERE deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListUsersParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsers, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.PasswordHash,
+			&i.AvatarURL,
+			&i.Role,
+			&i.CreatedAt,
+			&i.BooksRead,
+			&i.ReviewsCount,
+			&i.LikesReceived,
+			&i.ProfileVisibility,
+			&i.ActivityVisibility,
+			&i.DeletedAt,
+			&i.Strikes,
+			&i.SuspendedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setUserSuspension = `-- name: SetUserSuspension :execrows
+UPDATE users SET suspended_until = $2
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type SetUserSuspensionParams struct {
+	ID             string     `json:"id"`
+	SuspendedUntil *time.Time `json:"suspended_until"`
+}
+
+func (q *Queries) SetUserSuspension(ctx context.Context, arg SetUserSuspensionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setUserSuspension, arg.ID, arg.SuspendedUntil)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateUser = `-- name: UpdateUser :exec
+UPDATE users SET
+    username = $2, avatar_url = $3, role = $4,
+    books_read = $5, reviews_count = $6, likes_received = $7,
+    profile_visibility = $8, activity_visibility = $9
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type UpdateUserParams struct {
+	ID                 string            `json:"id"`
+	Username           string            `json:"username"`
+	AvatarURL          *string           `json:"avatar_url"`
+	Role               models.UserRole   `json:"role"`
+	BooksRead          *int32            `json:"books_read"`
+	ReviewsCount       *int32            `json:"reviews_count"`
+	LikesReceived      *int32            `json:"likes_received"`
+	ProfileVisibility  models.Visibility `json:"profile_visibility"`
+	ActivityVisibility models.Visibility `json:"activity_visibility"`
+}
+
+func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
+	_, err := q.db.Exec(ctx, updateUser,
+		arg.ID,
+		arg.Username,
+		arg.AvatarURL,
+		arg.Role,
+		arg.BooksRead,
+		arg.ReviewsCount,
+		arg.LikesReceived,
+		arg.ProfileVisibility,
+		arg.ActivityVisibility,
+	)
+	return err
+}
+
+const updateUserAvatarURL = `-- name: UpdateUserAvatarURL :execrows
+UPDATE users SET avatar_url = $2
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+type UpdateUserAvatarURLParams struct {
+	ID        string  `json:"id"`
+	AvatarURL *string `json:"avatar_url"`
+}
+
+func (q *Queries) UpdateUserAvatarURL(ctx context.Context, arg UpdateUserAvatarURLParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateUserAvatarURL, arg.ID, arg.AvatarURL)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertUser = `-- name: UpsertUser :exec
+INSERT INTO users (
+    id, username, email, password_hash, avatar_url, role, profile_visibility, activity_visibility
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (id) DO UPDATE SET
+    username = EXCLUDED.username, email = EXCLUDED.email, password_hash = EXCLUDED.password_hash,
+    avatar_url = EXCLUDED.avatar_url, role = EXCLUDED.role,
+    profile_visibility = EXCLUDED.profile_visibility, activity_visibility = EXCLUDED.activity_visibility
+`
+
+type UpsertUserParams struct {
+	ID                 string            `json:"id"`
+	Username           string            `json:"username"`
+	Email              string            `json:"email"`
+	PasswordHash       string            `json:"password_hash"`
+	AvatarURL          *string           `json:"avatar_url"`
+	Role               models.UserRole   `json:"role"`
+	ProfileVisibility  models.Visibility `json:"profile_visibility"`
+	ActivityVisibility models.Visibility `json:"activity_visibility"`
+}
+
+func (q *Queries) UpsertUser(ctx context.Context, arg UpsertUserParams) error {
+	_, err := q.db.Exec(ctx, upsertUser,
+		arg.ID,
+		arg.Username,
+		arg.Email,
+		arg.PasswordHash,
+		arg.AvatarURL,
+		arg.Role,
+		arg.ProfileVisibility,
+		arg.ActivityVisibility,
+	)
+	return err
+}
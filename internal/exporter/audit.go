@@ -0,0 +1,37 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// AuditLogCSV - журнал аудита одной CSV таблицей; before и after записываются как JSON
+func AuditLogCSV(entries []*models.AuditEntry, exportedAt time.Time) (*File, error) {
+	records := [][]string{{
+		"id", "created_at", "actor_id", "actor_username", "actor_role", "action",
+		"target_type", "target_id", "before", "after", "ip", "request_id",
+	}}
+	for _, e := range entries {
+		records = append(records, []string{
+			strconv.FormatInt(e.ID, 10), formatTime(&e.CreatedAt),
+			deref(e.ActorID), deref(e.ActorUsername), deref(e.ActorRole), string(e.Action),
+			string(e.TargetType), e.TargetID, string(e.Before), string(e.After),
+			deref(e.IP), deref(e.RequestID),
+		})
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return &File{
+		Name:        "bookvision-audit-" + exportedAt.Format("20060102-150405") + ".csv",
+		ContentType: "text/csv; charset=utf-8",
+		Data:        buf.Bytes(),
+	}, nil
+}
@@ -0,0 +1,190 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+const epubContainerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+// coverExtensions - расширения файлов обложки по content-type
+var coverExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+// BookEPUB - EPUB 3 (с NCX для старых читалок) из метаданных книги и упорядоченных частей.
+// Если cover не передан, используется обложка, сохраненная в книге как data URL.
+func BookEPUB(book *models.Book, parts []*models.BookPart, cover *Image, exportedAt time.Time) (*File, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	// mimetype должен быть первым файлом и храниться без сжатия
+	w, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write([]byte("application/epub+zip")); err != nil {
+		return nil, err
+	}
+
+	if err := writeZipFile(archive, "META-INF/container.xml", []byte(epubContainerXML)); err != nil {
+		return nil, err
+	}
+
+	if cover == nil && book.CoverURL != nil {
+		if contentType, data, err := decodeDataURL(*book.CoverURL); err == nil {
+			cover = &Image{ContentType: contentType, Data: data}
+		}
+	}
+
+	var coverItem string
+	if cover != nil {
+		if ext, ok := coverExtensions[cover.ContentType]; ok {
+			coverItem = fmt.Sprintf(`<item id="cover-image" href="cover.%s" media-type="%s" properties="cover-image"/>`, ext, cover.ContentType)
+			if err := writeZipFile(archive, "OEBPS/cover."+ext, cover.Data); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	chapterNames := make([]string, len(parts))
+	for i, part := range parts {
+		chapterNames[i] = fmt.Sprintf("chapter-%03d.xhtml", part.OrderNum)
+		if err := writeZipFile(archive, "OEBPS/"+chapterNames[i], epubChapter(part)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeZipFile(archive, "OEBPS/nav.xhtml", epubNav(book, parts, chapterNames)); err != nil {
+		return nil, err
+	}
+	if err := writeZipFile(archive, "OEBPS/toc.ncx", epubNCX(book, parts, chapterNames)); err != nil {
+		return nil, err
+	}
+	if err := writeZipFile(archive, "OEBPS/content.opf", epubOPF(book, chapterNames, coverItem, exportedAt)); err != nil {
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return &File{
+		Name:        slugify(book.Title) + ".epub",
+		ContentType: "application/epub+zip",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// epubOPF - пакетный документ с метаданными, манифестом и spine
+func epubOPF(book *models.Book, chapterNames []string, coverItem string, exportedAt time.Time) []byte {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+`)
+	fmt.Fprintf(&b, "    <dc:identifier id=\"book-id\">urn:uuid:%s</dc:identifier>\n", esc(book.ID))
+	fmt.Fprintf(&b, "    <dc:title>%s</dc:title>\n", esc(book.Title))
+	fmt.Fprintf(&b, "    <dc:creator>%s</dc:creator>\n", esc(book.Author))
+	b.WriteString("    <dc:language>ru</dc:language>\n")
+	if book.Year != nil {
+		fmt.Fprintf(&b, "    <dc:date>%04d</dc:date>\n", *book.Year)
+	}
+	for _, genre := range book.Genres {
+		fmt.Fprintf(&b, "    <dc:subject>%s</dc:subject>\n", esc(genre))
+	}
+	if book.Description != "" {
+		fmt.Fprintf(&b, "    <dc:description>%s</dc:description>\n", esc(book.Description))
+	}
+	fmt.Fprintf(&b, "    <meta property=\"dcterms:modified\">%s</meta>\n", exportedAt.UTC().Format("2006-01-02T15:04:05Z"))
+	if coverItem != "" {
+		b.WriteString("    <meta name=\"cover\" content=\"cover-image\"/>\n")
+	}
+	b.WriteString(`  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+`)
+	if coverItem != "" {
+		fmt.Fprintf(&b, "    %s\n", coverItem)
+	}
+	for i, name := range chapterNames {
+		fmt.Fprintf(&b, "    <item id=\"chapter-%d\" href=\"%s\" media-type=\"application/xhtml+xml\"/>\n", i+1, name)
+	}
+	b.WriteString("  </manifest>\n  <spine toc=\"ncx\">\n")
+	for i := range chapterNames {
+		fmt.Fprintf(&b, "    <itemref idref=\"chapter-%d\"/>\n", i+1)
+	}
+	b.WriteString("  </spine>\n</package>\n")
+	return []byte(b.String())
+}
+
+// epubChapter - XHTML документ части книги
+func epubChapter(part *models.BookPart) []byte {
+	var b strings.Builder
+	writeXHTMLHeader(&b, part.Title)
+	fmt.Fprintf(&b, "  <h1>%s</h1>\n", esc(part.Title))
+	for _, p := range paragraphs(part.Content) {
+		fmt.Fprintf(&b, "  <p>%s</p>\n", esc(p))
+	}
+	b.WriteString("</body>\n</html>\n")
+	return []byte(b.String())
+}
+
+// epubNav - навигационный документ EPUB 3
+func epubNav(book *models.Book, parts []*models.BookPart, chapterNames []string) []byte {
+	var b strings.Builder
+	writeXHTMLHeader(&b, book.Title)
+	b.WriteString("  <nav epub:type=\"toc\">\n    <h1>Содержание</h1>\n    <ol>\n")
+	for i, part := range parts {
+		fmt.Fprintf(&b, "      <li><a href=\"%s\">%s</a></li>\n", chapterNames[i], esc(part.Title))
+	}
+	b.WriteString("    </ol>\n  </nav>\n</body>\n</html>\n")
+	return []byte(b.String())
+}
+
+// epubNCX - оглавление NCX для читалок EPUB 2
+func epubNCX(book *models.Book, parts []*models.BookPart, chapterNames []string) []byte {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <head>
+`)
+	fmt.Fprintf(&b, "    <meta name=\"dtb:uid\" content=\"urn:uuid:%s\"/>\n", esc(book.ID))
+	fmt.Fprintf(&b, "  </head>\n  <docTitle><text>%s</text></docTitle>\n  <navMap>\n", esc(book.Title))
+	for i, part := range parts {
+		fmt.Fprintf(&b, "    <navPoint id=\"nav-%d\" playOrder=\"%d\"><navLabel><text>%s</text></navLabel><content src=\"%s\"/></navPoint>\n",
+			i+1, i+1, esc(part.Title), chapterNames[i])
+	}
+	b.WriteString("  </navMap>\n</ncx>\n")
+	return []byte(b.String())
+}
+
+// writeXHTMLHeader - начало XHTML документа до открывающего body
+func writeXHTMLHeader(b *strings.Builder, title string) {
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="ru" lang="ru">
+<head>
+`)
+	fmt.Fprintf(b, "  <title>%s</title>\n</head>\n<body>\n", esc(title))
+}
+
+// esc - экранирование текста для XML
+func esc(s string) string {
+	return html.EscapeString(s)
+}
@@ -0,0 +1,81 @@
+// Package exporter формирует файлы книг (EPUB, Markdown, JSON), выгрузки данных пользователя и журнала аудита.
+package exporter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+)
+
+// Format - формат экспорта
+type Format string
+
+const (
+	FormatEPUB     Format = "epub"
+	FormatMarkdown Format = "md"
+	FormatJSON     Format = "json"
+	FormatCSV      Format = "csv"
+)
+
+// ErrUnsupportedFormat - неизвестный формат экспорта
+var ErrUnsupportedFormat = apperrors.Validation("unsupported export format: expected epub, md, json or csv")
+
+// File - сформированный файл для отдачи клиенту
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Image - изображение, встраиваемое в файл выгрузки
+type Image struct {
+	ContentType string
+	Data        []byte
+}
+
+// slugPattern - символы, недопустимые в имени файла
+var slugPattern = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// slugify - имя файла из заголовка (буквы любого алфавита сохраняются)
+func slugify(title string) string {
+	slug := strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if slug == "" {
+		return "book"
+	}
+	if runes := []rune(slug); len(runes) > 80 {
+		slug = strings.TrimRight(string(runes[:80]), "-")
+	}
+	return slug
+}
+
+// decodeDataURL - разбор обложки, сохраненной как data URL (data:image/jpeg;base64,...) до появления хранилища файлов
+func decodeDataURL(url string) (contentType string, data []byte, err error) {
+	rest, ok := strings.CutPrefix(url, "data:")
+	if !ok {
+		return "", nil, fmt.Errorf("not a data URL")
+	}
+	meta, payload, ok := strings.Cut(rest, ",")
+	if !ok {
+		return "", nil, fmt.Errorf("malformed data URL")
+	}
+	contentType, isBase64 := strings.CutSuffix(meta, ";base64")
+	if !isBase64 {
+		return "", nil, fmt.Errorf("data URL is not base64 encoded")
+	}
+	data, err = base64.StdEncoding.DecodeString(payload)
+	return contentType, data, err
+}
+
+// paragraphs - разбиение текста части на абзацы
+func paragraphs(content string) []string {
+	var result []string
+	for _, p := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, p)
+		}
+	}
+	return result
+}
@@ -0,0 +1,31 @@
+package exporter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// BookJSON - каноничный JSON документ книги со всеми частями
+func BookJSON(book *models.Book, parts []*models.BookPart, exportedAt time.Time) (*File, error) {
+	doc := &models.BookExportDocument{
+		SchemaVersion: models.BookExportSchemaVersion,
+		ExportedAt:    exportedAt.UTC(),
+		Book:          book.ToResponse(),
+		Parts:         make([]*models.BookPartResponse, len(parts)),
+	}
+	for i, part := range parts {
+		doc.Parts[i] = part.ToResponse()
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &File{
+		Name:        slugify(book.Title) + ".json",
+		ContentType: "application/json",
+		Data:        data,
+	}, nil
+}
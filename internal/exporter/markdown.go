@@ -0,0 +1,84 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// BookMarkdown - zip-архив с index.md (метаданные и оглавление) и файлом на каждую часть
+func BookMarkdown(book *models.Book, parts []*models.BookPart) (*File, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	slug := slugify(book.Title)
+	names := make([]string, len(parts))
+	for i, part := range parts {
+		names[i] = fmt.Sprintf("%02d-%s.md", part.OrderNum, slugify(part.Title))
+	}
+
+	var index strings.Builder
+	fmt.Fprintf(&index, "# %s\n\n", book.Title)
+	fmt.Fprintf(&index, "**Автор:** %s\n\n", book.Author)
+	if book.OriginalTitle != nil {
+		fmt.Fprintf(&index, "**Оригинальное название:** %s\n\n", *book.OriginalTitle)
+	}
+	if book.Year != nil {
+		fmt.Fprintf(&index, "**Год:** %d\n\n", *book.Year)
+	}
+	if len(book.Genres) > 0 {
+		fmt.Fprintf(&index, "**Жанры:** %s\n\n", strings.Join(book.Genres, ", "))
+	}
+	if book.Description != "" {
+		fmt.Fprintf(&index, "%s\n\n", book.Description)
+	}
+	index.WriteString("## Содержание\n\n")
+	for i, part := range parts {
+		fmt.Fprintf(&index, "%d. [%s](%s)\n", part.OrderNum, escapeMarkdown(part.Title), names[i])
+	}
+
+	if err := writeZipFile(archive, slug+"/index.md", []byte(index.String())); err != nil {
+		return nil, err
+	}
+
+	for i, part := range parts {
+		var chapter strings.Builder
+		fmt.Fprintf(&chapter, "# %s\n\n", part.Title)
+		for _, p := range paragraphs(part.Content) {
+			chapter.WriteString(p)
+			chapter.WriteString("\n\n")
+		}
+		if err := writeZipFile(archive, slug+"/"+names[i], []byte(chapter.String())); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return &File{
+		Name:        slug + "-markdown.zip",
+		ContentType: "application/zip",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// escapeMarkdown - экранирование квадратных скобок в тексте ссылки
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("[", `\[`, "]", `\]`).Replace(s)
+}
+
+// writeZipFile - запись файла в zip-архив со сжатием
+func writeZipFile(archive *zip.Writer, name string, data []byte) error {
+	w, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
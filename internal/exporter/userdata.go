@@ -0,0 +1,109 @@
+package exporter
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// UserDataJSON - выгрузка данных пользователя одним JSON документом
+func UserDataJSON(export *models.UserDataExport) (*File, error) {
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &File{
+		Name:        userDataName(export) + ".json",
+		ContentType: "application/json",
+		Data:        data,
+	}, nil
+}
+
+// UserDataCSV - zip-архив с reviews.csv, quotes.csv и progress.csv
+func UserDataCSV(export *models.UserDataExport) (*File, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	reviews := [][]string{{"id", "book_id", "rating", "text", "liked_characters", "disliked_characters", "best_parts", "created_at"}}
+	for _, r := range export.Reviews {
+		reviews = append(reviews, []string{
+			r.ID, r.BookID, strconv.Itoa(r.Rating), r.Text,
+			joinList(r.LikedCharacters), joinList(r.DislikedCharacters), joinList(r.BestParts),
+			formatTime(&r.CreatedAt),
+		})
+	}
+
+	quotes := [][]string{{"id", "book_id", "part_id", "text", "created_at"}}
+	for _, q := range export.Quotes {
+		quotes = append(quotes, []string{q.ID, q.BookID, deref(q.PartID), q.Text, formatTime(&q.CreatedAt)})
+	}
+
+	progress := [][]string{{"id", "book_id", "current_part_id", "completed_part_ids", "is_completed", "completed_at"}}
+	for _, p := range export.Progress {
+		progress = append(progress, []string{
+			p.ID, p.BookID, deref(p.CurrentPartID), joinList(p.CompletedPartIDs),
+			strconv.FormatBool(p.IsCompleted), formatTime(p.CompletedAt),
+		})
+	}
+
+	for name, records := range map[string][][]string{
+		"reviews.csv":  reviews,
+		"quotes.csv":   quotes,
+		"progress.csv": progress,
+	} {
+		if err := writeCSV(archive, name, records); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return &File{
+		Name:        userDataName(export) + "-csv.zip",
+		ContentType: "application/zip",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// writeCSV - запись таблицы в zip-архив
+func writeCSV(archive *zip.Writer, name string, records [][]string) error {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(records); err != nil {
+		return err
+	}
+	return writeZipFile(archive, name, buf.Bytes())
+}
+
+// userDataName - имя файла выгрузки пользователя
+func userDataName(export *models.UserDataExport) string {
+	return "bookvision-" + slugify(export.User.Username) + "-" + export.ExportedAt.Format("20060102")
+}
+
+// joinList - список значений в одной ячейке CSV
+func joinList(values []string) string {
+	return strings.Join(values, "; ")
+}
+
+// deref - значение строкового указателя или пустая строка
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// formatTime - время в RFC 3339 или пустая строка
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -1,17 +1,32 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/models"
 	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/services"
 )
 
 type ArticleHandler struct {
 	articleHandler interfaces.ArticleRepository
+	socialService  *services.SocialService
+	auditService   *services.AuditService
+	txManager      interfaces.TxManager
 }
 
-func NewArticleHandler(articleHandler interfaces.ArticleRepository) *ArticleHandler {
+func NewArticleHandler(articleHandler interfaces.ArticleRepository, socialService *services.SocialService, auditService *services.AuditService, txManager interfaces.TxManager) *ArticleHandler {
 	return &ArticleHandler{
 		articleHandler: articleHandler,
+		socialService:  socialService,
+		auditService:   auditService,
+		txManager:      txManager,
 	}
 }
 
@@ -24,9 +39,11 @@ func NewArticleHandler(articleHandler interfaces.ArticleRepository) *ArticleHand
 // @Param sort query string false "Поле для сортировки (views, likes, created_at)"
 // @Param order query string false "Порядок сортировки (asc, desc)"
 // @Param limit query int false "Количество статей"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
 // @Success 200 {object} []models.ArticleListItem
-// @Failure 400 {object} map[string]interface{}
-// @Failure 403 {object} map[string]interface{}
+// @Success 304 "Список не изменился"
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
 // @Security BearerAuth
 // @Router /api/articles [get]
 func (h *ArticleHandler) GetArticles(c *gin.Context) {
@@ -35,7 +52,15 @@ func (h *ArticleHandler) GetArticles(c *gin.Context) {
 	order := c.Query("order")
 	articles, err := h.articleHandler.GetList(c.Request.Context(), sortBy, order, limit)
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		respondError(c, err)
+		return
+	}
+
+	v := newValidators()
+	for _, article := range articles {
+		v.row(article.ID, article.UpdatedAt)
+	}
+	if notModified(c, v, cacheCatalogList) {
 		return
 	}
 	c.JSON(200, articles)
@@ -44,20 +69,276 @@ func (h *ArticleHandler) GetArticles(c *gin.Context) {
 // GetArticleById - получение статьи по ID
 // @Summary Получение статьи по ID
 // @Description Получение статьи по ID f80b90a5-a9e3-4347-9d0c-1a8b0abdfbf2
+// @Description Скрытая по жалобам статья доступна только модераторам и администраторам.
 // @Tags articles
 // @Accept json
 // @Produce json
 // @Param id path string true "ID статьи"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
 // @Success 200 {object} models.Article
-// @Failure 400 {object} map[string]interface{}
-// @Failure 403 {object} map[string]interface{}
+// @Success 304 "Статья не изменилась"
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
 // @Security BearerAuth
 // @Router /api/articles/{id} [get]
 func (h *ArticleHandler) GetArticleById(c *gin.Context) {
-	article, err := h.articleHandler.GetByID(c.Request.Context(), c.Param("id"))
+	// Скрытую по жалобам статью видят только модераторы
+	get := h.articleHandler.GetVisibleByID
+	if canSeeHidden(c) {
+		get = h.articleHandler.GetByID
+	}
+	article, err := get(c.Request.Context(), c.Param("id"))
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		respondError(c, err)
+		return
+	}
+	if notModified(c, articleValidators(article), itemCacheControl(c)) {
 		return
 	}
 	c.JSON(200, article)
 }
+
+// CreateArticle - создание статьи
+// @Summary Создание статьи
+// @Description Создание статьи (требует прав moderator)
+// @Tags articles
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param request body models.CreateArticleRequest true "Данные статьи"
+// @Success 201 {object} models.Article
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/articles [post]
+func (h *ArticleHandler) CreateArticle(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	var req models.CreateArticleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	blocks := make([]models.ArticleContentBlock, len(req.ContentBlocks))
+	for i, block := range req.ContentBlocks {
+		blocks[i] = models.ArticleContentBlock{
+			BlockType: block.BlockType,
+			Text:      block.Text,
+			OrderNum:  i + 1,
+			BlockID:   block.BlockID,
+		}
+	}
+
+	article := &models.Article{
+		Title:               req.Title,
+		Type:                req.Type,
+		AuthorID:            &currentUser.UserID,
+		BookID:              req.BookID,
+		Excerpt:             req.Excerpt,
+		ReadingMinutes:      req.ReadingMinutes,
+		CoverURL:            req.CoverURL,
+		NoSpoilers:          req.NoSpoilers,
+		ShouldReadReadiness: req.ShouldReadReadiness,
+		Content:             blocks,
+	}
+
+	ctx := c.Request.Context()
+	err := h.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := h.articleHandler.CreateArticle(ctx, article); err != nil {
+			return err
+		}
+		return h.auditService.Record(ctx, models.AuditArticleCreate, models.AuditTargetArticle, article.ID, nil, article)
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if err := h.socialService.Publish(ctx, currentUser.UserID, models.ActivityTypeArticlePublished, article.ID, article.BookID, map[string]interface{}{
+		"title": article.Title,
+	}); err != nil {
+		slog.WarnContext(ctx, "failed to publish activity", "error", err)
+	}
+
+	c.JSON(http.StatusCreated, article)
+}
+
+// UpdateArticle - обновление статьи (требует прав moderator)
+// @Summary Обновление статьи
+// @Description Обновление указанных полей статьи. Требуется заголовок If-Match с ETag статьи
+// @Description или поле version; если статью успели изменить, возвращается 409 с актуальной версией.
+// @Tags articles
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param If-Match header string false "ETag статьи из GET"
+// @Param id path string true "ID статьи"
+// @Param request body models.UpdateArticleRequest true "Данные для обновления"
+// @Success 200 {object} models.Article
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 428 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/articles/{id} [put]
+func (h *ArticleHandler) UpdateArticle(c *gin.Context) {
+	var req models.UpdateArticleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	h.editArticle(c, func(article *models.Article) (*int, error) {
+		if req.Title != nil {
+			article.Title = *req.Title
+		}
+		if req.Type != nil {
+			article.Type = *req.Type
+		}
+		if req.Excerpt != nil {
+			article.Excerpt = *req.Excerpt
+		}
+		if req.ReadingMinutes != nil {
+			article.ReadingMinutes = req.ReadingMinutes
+		}
+		if req.CoverURL != nil {
+			article.CoverURL = req.CoverURL
+		}
+		if req.Verified != nil {
+			article.Verified = *req.Verified
+		}
+		if req.VerificationType != nil {
+			article.VerificationType = req.VerificationType
+		}
+		if req.NoSpoilers != nil {
+			article.NoSpoilers = *req.NoSpoilers
+		}
+		if req.ShouldReadReadiness != nil {
+			article.ShouldReadReadiness = *req.ShouldReadReadiness
+		}
+		return req.Version, nil
+	})
+}
+
+// PatchArticle - частичное обновление статьи в формате JSON Merge Patch (требует прав moderator)
+// @Summary Частичное обновление статьи
+// @Description JSON Merge Patch (RFC 7396) к редактируемым полям статьи: null сбрасывает необязательное поле.
+// @Description Требуется заголовок If-Match с ETag статьи или поле version в патче.
+// @Tags articles
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param If-Match header string false "ETag статьи из GET"
+// @Param id path string true "ID статьи"
+// @Param request body models.ArticleEdit true "Изменяемые поля"
+// @Success 200 {object} models.Article
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 415 {object} middleware.ErrorResponse
+// @Failure 428 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/articles/{id} [patch]
+func (h *ArticleHandler) PatchArticle(c *gin.Context) {
+	h.editArticle(c, func(article *models.Article) (*int, error) {
+		var edit models.ArticleEdit
+		if err := bindMergePatch(c, article.Edit(), &edit); err != nil {
+			return nil, err
+		}
+		article.ApplyEdit(&edit)
+		return edit.Version, nil
+	})
+}
+
+// DeleteArticle - удаление статьи (требует прав admin)
+// @Summary Удаление статьи
+// @Description Перемещает статью в корзину; восстановление - через корзину администратора до окончательной очистки.
+// @Tags articles
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID статьи"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/articles/{id} [delete]
+func (h *ArticleHandler) DeleteArticle(c *gin.Context) {
+	id := c.Param("id")
+
+	err := h.txManager.WithinTx(c.Request.Context(), func(ctx context.Context) error {
+		article, err := h.articleHandler.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := h.articleHandler.Delete(ctx, id); err != nil {
+			return err
+		}
+		return h.auditService.Record(ctx, models.AuditArticleDelete, models.AuditTargetArticle, id, article, nil)
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Article deleted successfully",
+	})
+}
+
+// editArticle - чтение, изменение через apply и запись статьи с проверкой версии и записью в журнал аудита.
+// apply возвращает версию из тела запроса.
+func (h *ArticleHandler) editArticle(c *gin.Context, apply func(article *models.Article) (*int, error)) {
+	id := c.Param("id")
+
+	var article models.Article
+	err := h.txManager.WithinTx(c.Request.Context(), func(ctx context.Context) error {
+		current, err := h.articleHandler.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		article = *current
+		bodyVersion, err := apply(&article)
+		if err != nil {
+			return err
+		}
+		ok, err := editPrecondition(c, articleValidators(current), current.Version, bodyVersion)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return versionConflict(c, interfaces.ErrArticleVersionConflict, current, articleValidators(current))
+		}
+
+		err = h.articleHandler.Update(ctx, &article)
+		if errors.Is(err, interfaces.ErrArticleVersionConflict) {
+			// Статью изменили между чтением и записью
+			if latest, getErr := h.articleHandler.GetByID(ctx, id); getErr == nil {
+				return versionConflict(c, interfaces.ErrArticleVersionConflict, latest, articleValidators(latest))
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		return h.auditService.Record(ctx, models.AuditArticleUpdate, models.AuditTargetArticle, article.ID, current, &article)
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("ETag", articleValidators(&article).etag())
+	c.JSON(http.StatusOK, article)
+}
+
+// articleValidators - ETag и Last-Modified статьи
+func articleValidators(article *models.Article) *validators {
+	v := newValidators()
+	v.row(article.ID, article.UpdatedAt)
+	return v
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// AuditHandler - обработчики журнала аудита администратора
+type AuditHandler struct {
+	auditService *services.AuditService
+}
+
+// NewAuditHandler - создание нового AuditHandler
+func NewAuditHandler(auditService *services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// GetAuditLog - записи журнала аудита (требует прав admin)
+// @Summary Журнал аудита
+// @Description Действия модераторов и администраторов от новых к старым: исполнитель, действие, сущность,
+// @Description состояние до и после, IP и ID запроса. Время в фильтрах from/to - RFC 3339, to не включается.
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param actor_id query string false "ID исполнителя"
+// @Param action query string false "Действие, например book.update"
+// @Param target_type query string false "Тип сущности" Enums(book, book_part, book_proposal, article, character_profile, comment, content_report, user)
+// @Param target_id query string false "ID сущности"
+// @Param from query string false "Начало периода (RFC 3339)"
+// @Param to query string false "Конец периода (RFC 3339)"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/admin/audit [get]
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	var filter models.AuditFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+	limit, offset := paginationParams(c)
+
+	entries, err := h.auditService.List(c.Request.Context(), &filter, limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+// ExportAuditLog - выгрузка журнала аудита в CSV (требует прав admin)
+// @Summary Экспорт журнала аудита
+// @Description CSV с записями журнала по тем же фильтрам, что и список (не больше 100000 последних записей).
+// @Description before и after записываются как JSON.
+// @Tags admin
+// @Produce text/csv
+// @Param Authorization header string true "Bearer токен"
+// @Param actor_id query string false "ID исполнителя"
+// @Param action query string false "Действие, например book.update"
+// @Param target_type query string false "Тип сущности" Enums(book, book_part, book_proposal, article, character_profile, comment, content_report, user)
+// @Param target_id query string false "ID сущности"
+// @Param from query string false "Начало периода (RFC 3339)"
+// @Param to query string false "Конец периода (RFC 3339)"
+// @Success 200 {file} file
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/admin/audit/export [get]
+func (h *AuditHandler) ExportAuditLog(c *gin.Context) {
+	var filter models.AuditFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	file, err := h.auditService.Export(c.Request.Context(), &filter)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	sendExportFile(c, file)
+}
@@ -1,10 +1,10 @@
 package handlers
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
 	"github.com/tukembaev/bookVisionGo/internal/middleware"
 	"github.com/tukembaev/bookVisionGo/internal/models"
 	"github.com/tukembaev/bookVisionGo/internal/services"
@@ -31,23 +31,19 @@ func NewAuthHandler(authService *services.AuthService) *AuthHandler {
 // @Produce json
 // @Param request body models.CreateUserRequest true "Данные для регистрации"
 // @Success 201 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 409 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
 // @Router /api/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req models.CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, apperrors.FromBinding(err))
 		return
 	}
 
 	user, token, err := h.authService.Register(c.Request.Context(), &req)
 	if err != nil {
-		if err.Error() == "user with username "+req.Username+" already exists" {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -59,24 +55,25 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login - вход пользователя
 // @Summary Вход в систему
-// @Description Аутентификация пользователя и получение JWT токена
+// @Description Аутентификация пользователя и получение JWT токена. Заблокированный аккаунт получает 403.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body models.LoginRequest true "Данные для входа"
 // @Success 200 {object} map[string]interface{}
-// @Failure 401 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
 // @Router /api/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req models.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, apperrors.FromBinding(err))
 		return
 	}
 
 	user, token, err := h.authService.Login(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		respondError(c, err)
 		return
 	}
 
@@ -94,19 +91,20 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "Bearer токен"
 // @Success 200 {object} map[string]interface{}
-// @Failure 401 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
 // @Security BearerAuth
 // @Router /api/auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	authHeader := c.GetHeader("Authorization")
 	tokenString, err := utils.ExtractTokenFromHeader(authHeader)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		respondError(c, apperrors.Wrap(apperrors.CodeUnauthorized, err.Error(), err))
 		return
 	}
-	newToken, err := h.authService.RefreshToken(tokenString)
+	newToken, err := h.authService.RefreshToken(c.Request.Context(), tokenString)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		respondError(c, err)
 		return
 	}
 
@@ -122,22 +120,19 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "Bearer токен"
 // @Success 200 {object} map[string]interface{}
-// @Failure 401 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
 // @Security BearerAuth
 // @Router /api/auth/profile [get]
 func (h *AuthHandler) GetProfile(c *gin.Context) {
 	currentUser := middleware.GetCurrentUser(c)
-
-	fmt.Println(currentUser)
-
 	if currentUser == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		respondError(c, apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
 	user, err := h.authService.GetProfile(c.Request.Context(), currentUser.UserID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		respondError(c, err)
 		return
 	}
 
@@ -155,26 +150,26 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 // @Param Authorization header string true "Bearer токен"
 // @Param request body models.UpdateUserRequest true "Данные для обновления"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 401 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
 // @Security BearerAuth
 // @Router /api/auth/profile [put]
 func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	currentUser := middleware.GetCurrentUser(c)
 	if currentUser == nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		respondError(c, apperrors.Unauthorized("user not authenticated"))
 		return
 	}
 
 	var req models.UpdateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, apperrors.FromBinding(err))
 		return
 	}
 
 	user, err := h.authService.UpdateProfile(c.Request.Context(), currentUser.UserID, &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -1,24 +1,35 @@
 package handlers
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/metrics"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
 	"github.com/tukembaev/bookVisionGo/internal/models"
 	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/services"
 )
 
 // BookHandler - обработчики для работы с книгами
 type BookHandler struct {
-	bookRepo interfaces.BookRepository
+	bookRepo        interfaces.BookRepository
+	revisionService *services.RevisionService
+	auditService    *services.AuditService
+	txManager       interfaces.TxManager
 }
 
 // NewBookHandler - создание нового BookHandler
-func NewBookHandler(bookRepo interfaces.BookRepository) *BookHandler {
+func NewBookHandler(bookRepo interfaces.BookRepository, revisionService *services.RevisionService, auditService *services.AuditService, txManager interfaces.TxManager) *BookHandler {
 	return &BookHandler{
-		bookRepo: bookRepo,
+		bookRepo:        bookRepo,
+		revisionService: revisionService,
+		auditService:    auditService,
+		txManager:       txManager,
 	}
 }
 
@@ -31,14 +42,14 @@ func NewBookHandler(bookRepo interfaces.BookRepository) *BookHandler {
 // @Param Authorization header string true "Bearer токен"
 // @Param request body models.CreateBookRequest true "Данные книги"
 // @Success 201 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 403 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
 // @Security BearerAuth
 // @Router /api/books [post]
 func (h *BookHandler) CreateBook(c *gin.Context) {
 	var req models.CreateBookRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, apperrors.FromBinding(err))
 		return
 	}
 
@@ -58,11 +69,17 @@ func (h *BookHandler) CreateBook(c *gin.Context) {
 		Verified:      false, // По умолчанию не верифицирована
 	}
 
-	err := h.bookRepo.Create(c.Request.Context(), book)
+	err := h.txManager.WithinTx(c.Request.Context(), func(ctx context.Context) error {
+		if err := h.bookRepo.Create(ctx, book); err != nil {
+			return err
+		}
+		return h.auditService.Record(ctx, models.AuditBookCreate, models.AuditTargetBook, book.ID, nil, book.ToResponse())
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
+	metrics.BooksCreated.WithLabelValues("api").Inc()
 
 	c.JSON(http.StatusCreated, gin.H{
 		"book": book.ToResponse(),
@@ -82,11 +99,11 @@ func (h *BookHandler) CreateBook(c *gin.Context) {
 // @Param search query string false "Поиск по названию"
 // @Param limit query int false "Лимит" default(20)
 // @Param offset query int false "Смещение" default(0)
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
 // @Success 200 {object} map[string]interface{}
+// @Success 304 "Список не изменился"
 // @Router /api/books [get]
 func (h *BookHandler) GetBooks(c *gin.Context) {
-	fmt.Println("GetBooks called!") // Debug лог
-
 	// Парсинг query параметров
 	filters := interfaces.BookFilters{}
 
@@ -122,7 +139,7 @@ func (h *BookHandler) GetBooks(c *gin.Context) {
 	// Получение книг из БД
 	books, err := h.bookRepo.List(c.Request.Context(), filters, limit, offset)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -135,7 +152,15 @@ func (h *BookHandler) GetBooks(c *gin.Context) {
 	// Получение общего количества
 	total, err := h.bookRepo.Count(c.Request.Context(), filters)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
+		return
+	}
+
+	v := newValidators(strconv.Itoa(total), strconv.Itoa(limit), strconv.Itoa(offset))
+	for _, book := range books {
+		v.row(book.ID, book.UpdatedAt)
+	}
+	if notModified(c, v, cacheCatalogList) {
 		return
 	}
 
@@ -153,15 +178,21 @@ func (h *BookHandler) GetBooks(c *gin.Context) {
 // @Tags books
 // @Produce json
 // @Param id path string true "ID книги"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
 // @Success 200 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
+// @Success 304 "Книга не изменилась"
+// @Failure 404 {object} middleware.ErrorResponse
 // @Router /api/books/{id} [get]
 func (h *BookHandler) GetBook(c *gin.Context) {
 	id := c.Param("id")
 
 	book, err := h.bookRepo.GetByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Book not found"})
+		respondError(c, err)
+		return
+	}
+
+	if notModified(c, bookValidators(book), cacheCatalogItem) {
 		return
 	}
 
@@ -172,82 +203,228 @@ func (h *BookHandler) GetBook(c *gin.Context) {
 
 // UpdateBook - обновление книги (требует прав moderator/admin)
 // @Summary Обновление книги
-// @Description Обновление информации о книге
+// @Description Обновление указанных полей книги. Требуется заголовок If-Match с ETag книги
+// @Description или поле version; если книгу успели изменить, возвращается 409 с актуальной версией.
+// @Description Правка сохраняется в истории ревизий книги вместе с comment.
 // @Tags books
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer токен"
+// @Param If-Match header string false "ETag книги из GET"
 // @Param id path string true "ID книги"
 // @Param request body models.UpdateBookRequest true "Данные для обновления"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 403 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 428 {object} middleware.ErrorResponse
 // @Security BearerAuth
 // @Router /api/books/{id} [put]
 func (h *BookHandler) UpdateBook(c *gin.Context) {
-	id := c.Param("id")
-
 	var req models.UpdateBookRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondError(c, apperrors.FromBinding(err))
 		return
 	}
 
-	// Получение существующей книги
-	book, err := h.bookRepo.GetByID(c.Request.Context(), id)
+	h.editBook(c, models.AuditBookUpdate, func(ctx context.Context, book *models.Book) (models.EditMeta, error) {
+		// Обновление полей если они указаны
+		if req.Title != nil {
+			book.Title = *req.Title
+		}
+		if req.OriginalTitle != nil {
+			book.OriginalTitle = req.OriginalTitle
+		}
+		if req.Author != nil {
+			book.Author = *req.Author
+		}
+		if req.Year != nil {
+			book.Year = req.Year
+		}
+		if req.Genres != nil {
+			book.Genres = req.Genres
+		}
+		if req.AgeRating != nil {
+			book.AgeRating = req.AgeRating
+		}
+		if req.AuthorCountry != nil {
+			book.AuthorCountry = req.AuthorCountry
+		}
+		if req.Description != nil {
+			book.Description = *req.Description
+		}
+		if req.CoverURL != nil {
+			book.CoverURL = req.CoverURL
+		}
+		if req.PagesCount != nil {
+			book.PagesCount = *req.PagesCount
+		}
+		if req.Tags != nil {
+			book.Tags = req.Tags
+		}
+		return req.EditMeta, nil
+	})
+}
+
+// PatchBook - частичное обновление книги в формате JSON Merge Patch (требует прав moderator/admin)
+// @Summary Частичное обновление книги
+// @Description JSON Merge Patch (RFC 7396) к редактируемым полям книги: null сбрасывает необязательное поле.
+// @Description Требуется заголовок If-Match с ETag книги или поле version в патче.
+// @Tags books
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param If-Match header string false "ETag книги из GET"
+// @Param id path string true "ID книги"
+// @Param request body models.BookEdit true "Изменяемые поля"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 415 {object} middleware.ErrorResponse
+// @Failure 428 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id} [patch]
+func (h *BookHandler) PatchBook(c *gin.Context) {
+	h.editBook(c, models.AuditBookUpdate, func(ctx context.Context, book *models.Book) (models.EditMeta, error) {
+		var edit models.BookEdit
+		if err := bindMergePatch(c, book.Edit(), &edit); err != nil {
+			return models.EditMeta{}, err
+		}
+		book.ApplyEdit(&edit)
+		return edit.EditMeta, nil
+	})
+}
+
+// RevertBook - откат книги к ревизии (требует прав moderator/admin)
+// @Summary Откат книги к ревизии
+// @Description Восстанавливает поля книги из ревизии (кроме обложки) новой правкой, которая тоже попадает в историю.
+// @Description Требуется заголовок If-Match с ETag книги или поле version.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param If-Match header string false "ETag книги из GET"
+// @Param id path string true "ID книги"
+// @Param version path int true "Номер ревизии"
+// @Param request body models.RevertRevisionRequest false "Версия и комментарий"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 428 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id}/revisions/{version}/revert [post]
+func (h *BookHandler) RevertBook(c *gin.Context) {
+	version, req, err := bindRevert(c)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Book not found"})
+		respondError(c, err)
 		return
 	}
 
-	// Обновление полей если они указаны
-	if req.Title != nil {
-		book.Title = *req.Title
-	}
-	if req.OriginalTitle != nil {
-		book.OriginalTitle = req.OriginalTitle
-	}
-	if req.Author != nil {
-		book.Author = *req.Author
-	}
-	if req.Year != nil {
-		book.Year = req.Year
-	}
-	if req.Genres != nil {
-		book.Genres = req.Genres
-	}
-	if req.AgeRating != nil {
-		book.AgeRating = req.AgeRating
-	}
-	if req.AuthorCountry != nil {
-		book.AuthorCountry = req.AuthorCountry
-	}
-	if req.Description != nil {
-		book.Description = *req.Description
-	}
-	if req.CoverURL != nil {
-		book.CoverURL = req.CoverURL
-	}
-	if req.PagesCount != nil {
-		book.PagesCount = *req.PagesCount
+	h.editBook(c, models.AuditBookRevert, func(ctx context.Context, book *models.Book) (models.EditMeta, error) {
+		edit := book.Edit()
+		if err := h.revisionService.Restore(ctx, models.RevisionEntityBook, book.ID, version, edit); err != nil {
+			return models.EditMeta{}, err
+		}
+		book.ApplyEdit(edit)
+		return req.EditMeta, nil
+	})
+}
+
+// SetBookVerification - ручная установка или снятие верификации книги (требует прав moderator/admin)
+// @Summary Верификация книги модератором
+// @Description Устанавливает (verification_type обязателен) или снимает признак верификации книги.
+// @Description Через PUT и PATCH книги верификация не меняется. Требуется заголовок If-Match с ETag книги или поле version.
+// @Tags verification
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param If-Match header string false "ETag книги из GET"
+// @Param id path string true "ID книги"
+// @Param request body models.SetVerificationRequest true "Признак и тип верификации"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 428 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id}/verification [put]
+func (h *BookHandler) SetBookVerification(c *gin.Context) {
+	var req models.SetVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
 	}
-	if req.Tags != nil {
-		book.Tags = req.Tags
+	if *req.Verified && req.VerificationType == nil {
+		respondError(c, errVerificationTypeRequired)
+		return
 	}
-	if req.Verified != nil {
+
+	h.editBook(c, models.AuditBookVerify, func(ctx context.Context, book *models.Book) (models.EditMeta, error) {
 		book.Verified = *req.Verified
-	}
-	if req.VerificationType != nil {
-		book.VerificationType = req.VerificationType
-	}
+		book.VerificationType = nil
+		if book.Verified {
+			book.VerificationType = req.VerificationType
+		}
+		return req.EditMeta, nil
+	})
+}
+
+// editBook - чтение, изменение через apply и запись книги с проверкой версии, ревизией в истории и записью action в журнал аудита.
+// apply возвращает версию и комментарий из тела запроса. Чтение идет в транзакции, то есть мимо кэша,
+// чтобы If-Match сравнивался с актуальной книгой.
+func (h *BookHandler) editBook(c *gin.Context, action models.AuditAction, apply func(ctx context.Context, book *models.Book) (models.EditMeta, error)) {
+	id := c.Param("id")
+
+	var book models.Book
+	err := h.txManager.WithinTx(c.Request.Context(), func(ctx context.Context) error {
+		current, err := h.bookRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		book = *current
+		meta, err := apply(ctx, &book)
+		if err != nil {
+			return err
+		}
+		ok, err := editPrecondition(c, bookValidators(current), current.Version, meta.Version)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return versionConflict(c, interfaces.ErrBookVersionConflict, current.ToResponse(), bookValidators(current))
+		}
+
+		err = h.bookRepo.Update(ctx, &book)
+		if errors.Is(err, interfaces.ErrBookVersionConflict) {
+			// Книгу изменили между чтением и записью
+			if latest, getErr := h.bookRepo.GetByID(ctx, id); getErr == nil {
+				return versionConflict(c, interfaces.ErrBookVersionConflict, latest.ToResponse(), bookValidators(latest))
+			}
+		}
+		if err != nil {
+			return err
+		}
 
-	err = h.bookRepo.Update(c.Request.Context(), book)
+		err = h.revisionService.Record(ctx, models.RevisionEntityBook, book.ID, current.Version, book.Version,
+			current.Edit(), book.Edit(), middleware.GetCurrentUser(c).UserID, meta.Comment)
+		if err != nil {
+			return err
+		}
+		return h.auditService.Record(ctx, action, models.AuditTargetBook, book.ID, current.ToResponse(), book.ToResponse())
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
+	c.Header("ETag", bookValidators(&book).etag())
 	c.JSON(http.StatusOK, gin.H{
 		"book": book.ToResponse(),
 	})
@@ -255,22 +432,31 @@ func (h *BookHandler) UpdateBook(c *gin.Context) {
 
 // DeleteBook - удаление книги (требует прав admin)
 // @Summary Удаление книги
-// @Description Удаление книги из каталога
+// @Description Перемещает книгу в корзину вместе с частями; восстановление - через корзину администратора до окончательной очистки.
 // @Tags books
 // @Produce json
 // @Param Authorization header string true "Bearer токен"
 // @Param id path string true "ID книги"
 // @Success 200 {object} map[string]interface{}
-// @Failure 403 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
 // @Security BearerAuth
 // @Router /api/books/{id} [delete]
 func (h *BookHandler) DeleteBook(c *gin.Context) {
 	id := c.Param("id")
 
-	err := h.bookRepo.Delete(c.Request.Context(), id)
+	err := h.txManager.WithinTx(c.Request.Context(), func(ctx context.Context) error {
+		book, err := h.bookRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := h.bookRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		return h.auditService.Record(ctx, models.AuditBookDelete, models.AuditTargetBook, id, book.ToResponse(), nil)
+	})
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Book not found"})
+		respondError(c, err)
 		return
 	}
 
@@ -285,15 +471,25 @@ func (h *BookHandler) DeleteBook(c *gin.Context) {
 // @Tags books
 // @Produce json
 // @Param id path string true "ID книги"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
 // @Success 200 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
+// @Success 304 "Части не изменились"
+// @Failure 404 {object} middleware.ErrorResponse
 // @Router /api/books/{id}/parts [get]
 func (h *BookHandler) GetBookParts(c *gin.Context) {
 	bookID := c.Param("id")
 
 	parts, err := h.bookRepo.GetParts(c.Request.Context(), bookID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Book not found"})
+		respondError(c, err)
+		return
+	}
+
+	v := newValidators(bookID)
+	for _, part := range parts {
+		v.row(part.ID, part.UpdatedAt)
+	}
+	if notModified(c, v, cacheBookContent) {
 		return
 	}
 
@@ -315,15 +511,21 @@ func (h *BookHandler) GetBookParts(c *gin.Context) {
 // @Produce json
 // @Param id path string true "ID книги"
 // @Param partId path string true "ID части"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
 // @Success 200 {object} map[string]interface{}
-// @Failure 404 {object} map[string]interface{}
+// @Success 304 "Часть не изменилась"
+// @Failure 404 {object} middleware.ErrorResponse
 // @Router /api/books/{id}/parts/{partId} [get]
 func (h *BookHandler) GetBookPart(c *gin.Context) {
 	partID := c.Param("partId")
 
 	part, err := h.bookRepo.GetPartByID(c.Request.Context(), partID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Part not found"})
+		respondError(c, err)
+		return
+	}
+
+	if notModified(c, partValidators(part), cacheBookContent) {
 		return
 	}
 
@@ -331,3 +533,229 @@ func (h *BookHandler) GetBookPart(c *gin.Context) {
 		"part": part.ToResponse(),
 	})
 }
+
+// PatchBookPart - частичное обновление части книги в формате JSON Merge Patch (требует прав moderator/admin)
+// @Summary Частичное обновление части книги
+// @Description JSON Merge Patch (RFC 7396) к редактируемым полям главы/части.
+// @Description Требуется заголовок If-Match с ETag части или поле version в патче; правка сохраняется в истории ревизий части.
+// @Tags books
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param If-Match header string false "ETag части из GET"
+// @Param id path string true "ID книги"
+// @Param partId path string true "ID части"
+// @Param request body models.BookPartEdit true "Изменяемые поля"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 415 {object} middleware.ErrorResponse
+// @Failure 428 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id}/parts/{partId} [patch]
+func (h *BookHandler) PatchBookPart(c *gin.Context) {
+	h.editPart(c, models.AuditPartUpdate, func(ctx context.Context, part *models.BookPart) (models.EditMeta, error) {
+		var edit models.BookPartEdit
+		if err := bindMergePatch(c, part.Edit(), &edit); err != nil {
+			return models.EditMeta{}, err
+		}
+		part.ApplyEdit(&edit)
+		return edit.EditMeta, nil
+	})
+}
+
+// RevertBookPart - откат части книги к ревизии (требует прав moderator/admin)
+// @Summary Откат части книги к ревизии
+// @Description Восстанавливает поля главы/части из ревизии новой правкой, которая тоже попадает в историю.
+// @Description Требуется заголовок If-Match с ETag части или поле version.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param If-Match header string false "ETag части из GET"
+// @Param id path string true "ID книги"
+// @Param partId path string true "ID части"
+// @Param version path int true "Номер ревизии"
+// @Param request body models.RevertRevisionRequest false "Версия и комментарий"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 428 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id}/parts/{partId}/revisions/{version}/revert [post]
+func (h *BookHandler) RevertBookPart(c *gin.Context) {
+	version, req, err := bindRevert(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.editPart(c, models.AuditPartRevert, func(ctx context.Context, part *models.BookPart) (models.EditMeta, error) {
+		edit := part.Edit()
+		if err := h.revisionService.Restore(ctx, models.RevisionEntityBookPart, part.ID, version, edit); err != nil {
+			return models.EditMeta{}, err
+		}
+		part.ApplyEdit(edit)
+		return req.EditMeta, nil
+	})
+}
+
+// editPart - чтение, изменение через apply и запись части книги с проверкой версии, ревизией в истории и записью action в журнал аудита
+func (h *BookHandler) editPart(c *gin.Context, action models.AuditAction, apply func(ctx context.Context, part *models.BookPart) (models.EditMeta, error)) {
+	bookID, partID := c.Param("id"), c.Param("partId")
+
+	var part models.BookPart
+	err := h.txManager.WithinTx(c.Request.Context(), func(ctx context.Context) error {
+		current, err := h.bookRepo.GetPartByID(ctx, partID)
+		if err != nil {
+			return err
+		}
+		if current.BookID != bookID {
+			return interfaces.ErrBookPartNotFound
+		}
+
+		part = *current
+		meta, err := apply(ctx, &part)
+		if err != nil {
+			return err
+		}
+		ok, err := editPrecondition(c, partValidators(current), current.Version, meta.Version)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return versionConflict(c, interfaces.ErrBookPartVersionConflict, current.ToResponse(), partValidators(current))
+		}
+
+		err = h.bookRepo.UpdatePart(ctx, &part)
+		if errors.Is(err, interfaces.ErrBookPartVersionConflict) {
+			// Часть изменили между чтением и записью
+			if latest, getErr := h.bookRepo.GetPartByID(ctx, partID); getErr == nil {
+				return versionConflict(c, interfaces.ErrBookPartVersionConflict, latest.ToResponse(), partValidators(latest))
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		err = h.revisionService.Record(ctx, models.RevisionEntityBookPart, part.ID, current.Version, part.Version,
+			current.Edit(), part.Edit(), middleware.GetCurrentUser(c).UserID, meta.Comment)
+		if err != nil {
+			return err
+		}
+		return h.auditService.Record(ctx, action, models.AuditTargetBookPart, part.ID, current.Edit(), part.Edit())
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("ETag", partValidators(&part).etag())
+	c.JSON(http.StatusOK, gin.H{
+		"part": part.ToResponse(),
+	})
+}
+
+// GetBookRevisions - история правок книги
+// @Summary История правок книги
+// @Description Ревизии книги от новых к старым: автор, время, комментарий и измененные поля (old/new).
+// @Description Первая ревизия - исходное состояние книги до первой правки.
+// @Tags books
+// @Produce json
+// @Param id path string true "ID книги"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/books/{id}/revisions [get]
+func (h *BookHandler) GetBookRevisions(c *gin.Context) {
+	book, err := h.bookRepo.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	listRevisions(c, h.revisionService, models.RevisionEntityBook, book.ID)
+}
+
+// DiffBookRevisions - сравнение двух ревизий книги
+// @Summary Сравнение ревизий книги
+// @Description Поля, которые различаются в ревизиях from и to
+// @Tags books
+// @Produce json
+// @Param id path string true "ID книги"
+// @Param from query int true "Номер первой ревизии"
+// @Param to query int true "Номер второй ревизии"
+// @Success 200 {object} models.RevisionDiffResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/books/{id}/revisions/diff [get]
+func (h *BookHandler) DiffBookRevisions(c *gin.Context) {
+	diffRevisions(c, h.revisionService, models.RevisionEntityBook, c.Param("id"))
+}
+
+// GetBookPartRevisions - история правок части книги
+// @Summary История правок части книги
+// @Description Ревизии главы/части от новых к старым
+// @Tags books
+// @Produce json
+// @Param id path string true "ID книги"
+// @Param partId path string true "ID части"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/books/{id}/parts/{partId}/revisions [get]
+func (h *BookHandler) GetBookPartRevisions(c *gin.Context) {
+	part, err := h.bookRepo.GetPartByID(c.Request.Context(), c.Param("partId"))
+	if err == nil && part.BookID != c.Param("id") {
+		err = interfaces.ErrBookPartNotFound
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	listRevisions(c, h.revisionService, models.RevisionEntityBookPart, part.ID)
+}
+
+// DiffBookPartRevisions - сравнение двух ревизий части книги
+// @Summary Сравнение ревизий части книги
+// @Description Поля, которые различаются в ревизиях from и to
+// @Tags books
+// @Produce json
+// @Param id path string true "ID книги"
+// @Param partId path string true "ID части"
+// @Param from query int true "Номер первой ревизии"
+// @Param to query int true "Номер второй ревизии"
+// @Success 200 {object} models.RevisionDiffResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/books/{id}/parts/{partId}/revisions/diff [get]
+func (h *BookHandler) DiffBookPartRevisions(c *gin.Context) {
+	part, err := h.bookRepo.GetPartByID(c.Request.Context(), c.Param("partId"))
+	if err == nil && part.BookID != c.Param("id") {
+		err = interfaces.ErrBookPartNotFound
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	diffRevisions(c, h.revisionService, models.RevisionEntityBookPart, part.ID)
+}
+
+// bookValidators - ETag и Last-Modified карточки книги
+func bookValidators(book *models.Book) *validators {
+	v := newValidators()
+	v.row(book.ID, book.UpdatedAt)
+	return v
+}
+
+// partValidators - ETag и Last-Modified части книги
+func partValidators(part *models.BookPart) *validators {
+	v := newValidators()
+	v.row(part.ID, part.UpdatedAt)
+	return v
+}
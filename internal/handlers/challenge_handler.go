@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// ChallengeHandler - обработчики для работы с челленджами
+type ChallengeHandler struct {
+	challengeRepo interfaces.ChallengeRepository
+	socialService *services.SocialService
+}
+
+// NewChallengeHandler - создание нового ChallengeHandler
+func NewChallengeHandler(challengeRepo interfaces.ChallengeRepository, socialService *services.SocialService) *ChallengeHandler {
+	return &ChallengeHandler{
+		challengeRepo: challengeRepo,
+		socialService: socialService,
+	}
+}
+
+// JoinChallenge - присоединение к челленджу
+// @Summary Присоединение к челленджу
+// @Description Присоединение текущего пользователя к челленджу
+// @Tags challenges
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID челленджа"
+// @Success 200 {object} map[string]interface{}
+// @Success 201 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/challenges/{id}/join [post]
+func (h *ChallengeHandler) JoinChallenge(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+	ctx := c.Request.Context()
+
+	challenge, err := h.challengeRepo.GetByID(ctx, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	progress, created, err := h.challengeRepo.Join(ctx, currentUser.UserID, challenge.ID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+		if err := h.socialService.Publish(ctx, currentUser.UserID, models.ActivityTypeChallengeJoined, challenge.ID, nil, map[string]interface{}{
+			"title": challenge.Title,
+		}); err != nil {
+			slog.WarnContext(ctx, "failed to publish activity", "error", err)
+		}
+	}
+
+	c.JSON(status, gin.H{
+		"progress": progress.ToResponse(),
+	})
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// CharacterHandler - обработчики для работы с профилями персонажей
+type CharacterHandler struct {
+	characterRepo   interfaces.CharacterRepository
+	revisionService *services.RevisionService
+	auditService    *services.AuditService
+	txManager       interfaces.TxManager
+}
+
+// NewCharacterHandler - создание нового CharacterHandler
+func NewCharacterHandler(characterRepo interfaces.CharacterRepository, revisionService *services.RevisionService, auditService *services.AuditService, txManager interfaces.TxManager) *CharacterHandler {
+	return &CharacterHandler{
+		characterRepo:   characterRepo,
+		revisionService: revisionService,
+		auditService:    auditService,
+		txManager:       txManager,
+	}
+}
+
+// GetCharacterProfile - получение профиля персонажа по ID
+// @Summary Получение профиля персонажа
+// @Description Получение расширенного профиля персонажа
+// @Description Скрытый по жалобам профиль доступен только модераторам и администраторам.
+// @Tags characters
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID профиля персонажа"
+// @Param If-None-Match header string false "ETag из предыдущего ответа"
+// @Success 200 {object} map[string]interface{}
+// @Success 304 "Профиль не изменился"
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/characters/{id} [get]
+func (h *CharacterHandler) GetCharacterProfile(c *gin.Context) {
+	profile, err := h.readProfile(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if notModified(c, profileValidators(profile), itemCacheControl(c)) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"character": profile.ToResponse(),
+	})
+}
+
+// PatchCharacterProfile - частичное обновление профиля персонажа в формате JSON Merge Patch (требует прав moderator/admin)
+// @Summary Частичное обновление профиля персонажа
+// @Description JSON Merge Patch (RFC 7396) к редактируемым полям профиля: null сбрасывает необязательное поле.
+// @Description Требуется заголовок If-Match с ETag профиля или поле version в патче; правка сохраняется в истории ревизий.
+// @Tags characters
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param If-Match header string false "ETag профиля из GET"
+// @Param id path string true "ID профиля персонажа"
+// @Param request body models.CharacterProfileEdit true "Изменяемые поля"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 415 {object} middleware.ErrorResponse
+// @Failure 428 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/characters/{id} [patch]
+func (h *CharacterHandler) PatchCharacterProfile(c *gin.Context) {
+	h.editProfile(c, models.AuditCharacterUpdate, func(ctx context.Context, profile *models.CharacterProfile) (models.EditMeta, error) {
+		var edit models.CharacterProfileEdit
+		if err := bindMergePatch(c, profile.Edit(), &edit); err != nil {
+			return models.EditMeta{}, err
+		}
+		profile.ApplyEdit(&edit)
+		return edit.EditMeta, nil
+	})
+}
+
+// GetCharacterRevisions - история правок профиля персонажа
+// @Summary История правок профиля персонажа
+// @Description Ревизии профиля от новых к старым: автор, время, комментарий и измененные поля (old/new).
+// @Description Первая ревизия - исходное состояние профиля до первой правки.
+// @Tags characters
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID профиля персонажа"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/characters/{id}/revisions [get]
+func (h *CharacterHandler) GetCharacterRevisions(c *gin.Context) {
+	profile, err := h.readProfile(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	listRevisions(c, h.revisionService, models.RevisionEntityCharacterProfile, profile.ID)
+}
+
+// DiffCharacterRevisions - сравнение двух ревизий профиля персонажа
+// @Summary Сравнение ревизий профиля персонажа
+// @Description Поля, которые различаются в ревизиях from и to
+// @Tags characters
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID профиля персонажа"
+// @Param from query int true "Номер первой ревизии"
+// @Param to query int true "Номер второй ревизии"
+// @Success 200 {object} models.RevisionDiffResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/characters/{id}/revisions/diff [get]
+func (h *CharacterHandler) DiffCharacterRevisions(c *gin.Context) {
+	profile, err := h.readProfile(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	diffRevisions(c, h.revisionService, models.RevisionEntityCharacterProfile, profile.ID)
+}
+
+// readProfile - профиль персонажа из пути для чтения: скрытый по жалобам видят только модераторы
+func (h *CharacterHandler) readProfile(c *gin.Context) (*models.CharacterProfile, error) {
+	if canSeeHidden(c) {
+		return h.characterRepo.GetProfile(c.Request.Context(), c.Param("id"))
+	}
+	return h.characterRepo.GetVisibleProfile(c.Request.Context(), c.Param("id"))
+}
+
+// RevertCharacterProfile - откат профиля персонажа к ревизии (требует прав moderator/admin)
+// @Summary Откат профиля персонажа к ревизии
+// @Description Восстанавливает поля профиля из ревизии новой правкой, которая тоже попадает в историю.
+// @Description Требуется заголовок If-Match с ETag профиля или поле version.
+// @Tags characters
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param If-Match header string false "ETag профиля из GET"
+// @Param id path string true "ID профиля персонажа"
+// @Param version path int true "Номер ревизии"
+// @Param request body models.RevertRevisionRequest false "Версия и комментарий"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 428 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/characters/{id}/revisions/{version}/revert [post]
+func (h *CharacterHandler) RevertCharacterProfile(c *gin.Context) {
+	version, req, err := bindRevert(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	h.editProfile(c, models.AuditCharacterRevert, func(ctx context.Context, profile *models.CharacterProfile) (models.EditMeta, error) {
+		edit := profile.Edit()
+		if err := h.revisionService.Restore(ctx, models.RevisionEntityCharacterProfile, profile.ID, version, edit); err != nil {
+			return models.EditMeta{}, err
+		}
+		profile.ApplyEdit(edit)
+		return req.EditMeta, nil
+	})
+}
+
+// editProfile - чтение, изменение через apply и запись профиля персонажа с проверкой версии, ревизией в истории и записью action в журнал аудита
+func (h *CharacterHandler) editProfile(c *gin.Context, action models.AuditAction, apply func(ctx context.Context, profile *models.CharacterProfile) (models.EditMeta, error)) {
+	id := c.Param("id")
+
+	var profile models.CharacterProfile
+	err := h.txManager.WithinTx(c.Request.Context(), func(ctx context.Context) error {
+		current, err := h.characterRepo.GetProfile(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		profile = *current
+		meta, err := apply(ctx, &profile)
+		if err != nil {
+			return err
+		}
+		ok, err := editPrecondition(c, profileValidators(current), current.Version, meta.Version)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return versionConflict(c, interfaces.ErrCharacterVersionConflict, current.ToResponse(), profileValidators(current))
+		}
+
+		err = h.characterRepo.UpdateProfile(ctx, &profile)
+		if errors.Is(err, interfaces.ErrCharacterVersionConflict) {
+			// Профиль изменили между чтением и записью
+			if latest, getErr := h.characterRepo.GetProfile(ctx, id); getErr == nil {
+				return versionConflict(c, interfaces.ErrCharacterVersionConflict, latest.ToResponse(), profileValidators(latest))
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		err = h.revisionService.Record(ctx, models.RevisionEntityCharacterProfile, profile.ID, current.Version, profile.Version,
+			current.Edit(), profile.Edit(), middleware.GetCurrentUser(c).UserID, meta.Comment)
+		if err != nil {
+			return err
+		}
+		return h.auditService.Record(ctx, action, models.AuditTargetCharacterProfile, profile.ID, current.ToResponse(), profile.ToResponse())
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("ETag", profileValidators(&profile).etag())
+	c.JSON(http.StatusOK, gin.H{
+		"character": profile.ToResponse(),
+	})
+}
+
+// profileValidators - ETag и Last-Modified профиля персонажа
+func profileValidators(profile *models.CharacterProfile) *validators {
+	v := newValidators()
+	v.row(profile.ID, profile.UpdatedAt)
+	return v
+}
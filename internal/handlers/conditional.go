@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+)
+
+// errPreconditionRequired - правка без If-Match и без поля version
+var errPreconditionRequired = apperrors.New(apperrors.CodePrecondition, "If-Match header or version field is required")
+
+// Политики Cache-Control публичных ответов каталога. Ответы не зависят от пользователя,
+// поэтому их можно хранить в общих кэшах (CDN, reverse proxy).
+const (
+	// cacheCatalogList - списки меняются часто (новые книги, рейтинги, просмотры)
+	cacheCatalogList = "public, max-age=30, stale-while-revalidate=120"
+	// cacheCatalogItem - карточка книги
+	cacheCatalogItem = "public, max-age=120, stale-while-revalidate=600"
+	// cacheBookContent - текст глав меняется редко, а весит много
+	cacheBookContent = "public, max-age=3600, stale-while-revalidate=86400"
+)
+
+// cacheStaffItem - карточка для модератора может содержать скрытый по жалобам контент,
+// поэтому в общие кэши она не попадает
+const cacheStaffItem = "private, no-cache"
+
+// itemCacheControl - политика Cache-Control карточки статьи или персонажа для текущего пользователя
+func itemCacheControl(c *gin.Context) string {
+	if canSeeHidden(c) {
+		return cacheStaffItem
+	}
+	return cacheCatalogItem
+}
+
+// validators - ETag и Last-Modified ответа по версиям строк (id + updated_at)
+type validators struct {
+	hash         hash.Hash
+	lastModified time.Time
+}
+
+// newValidators - создание validators; scope отличает разные представления одних и тех же строк
+func newValidators(scope ...string) *validators {
+	v := &validators{hash: sha256.New()}
+	for _, s := range scope {
+		v.value(s)
+	}
+	return v
+}
+
+// row - учет версии строки
+func (v *validators) row(id string, updatedAt time.Time) {
+	v.value(id)
+	v.value(strconv.FormatInt(updatedAt.UnixMicro(), 10))
+	if updatedAt.After(v.lastModified) {
+		v.lastModified = updatedAt
+	}
+}
+
+// value - учет значения, влияющего на тело ответа (total, limit, ...)
+func (v *validators) value(s string) {
+	v.hash.Write([]byte(s))
+	v.hash.Write([]byte{0})
+}
+
+// etag - слабый ETag: тело ответа эквивалентно, но не обязательно побайтово совпадает
+func (v *validators) etag() string {
+	return `W/"` + hex.EncodeToString(v.hash.Sum(nil)[:16]) + `"`
+}
+
+// notModified - выставление ETag, Last-Modified и Cache-Control и проверка условного запроса.
+// Возвращает true, если клиенту уже отдан 304 Not Modified.
+// If-None-Match имеет приоритет над If-Modified-Since (RFC 9110, 13.2.2).
+func notModified(c *gin.Context, v *validators, cacheControl string) bool {
+	etag := v.etag()
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", cacheControl)
+	if !v.lastModified.IsZero() {
+		c.Header("Last-Modified", v.lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	inm, ims := c.GetHeader("If-None-Match"), c.GetHeader("If-Modified-Since")
+	switch {
+	case inm != "":
+		if !etagMatches(inm, etag) {
+			return false
+		}
+	case ims != "" && !v.lastModified.IsZero():
+		since, err := http.ParseTime(ims)
+		if err != nil || v.lastModified.Truncate(time.Second).After(since) {
+			return false
+		}
+	default:
+		return false
+	}
+
+	c.Status(http.StatusNotModified)
+	c.Writer.WriteHeaderNow()
+	c.Abort()
+	return true
+}
+
+// etagMatches - слабое сравнение ETag со списком из If-None-Match
+func etagMatches(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// editPrecondition - проверка, что клиент правит актуальную версию ресурса.
+// If-Match сравнивается с ETag текущего представления, поле version - с текущей версией;
+// без обоих правка отклоняется (428). false - версия устарела.
+func editPrecondition(c *gin.Context, current *validators, currentVersion int, bodyVersion *int) (bool, error) {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" && bodyVersion == nil {
+		return false, errPreconditionRequired
+	}
+	if ifMatch != "" && !etagMatches(ifMatch, current.etag()) {
+		return false, nil
+	}
+	if bodyVersion != nil && *bodyVersion != currentVersion {
+		return false, nil
+	}
+	return true, nil
+}
+
+// versionConflict - ошибка конфликта версий с актуальным представлением ресурса;
+// ETag актуального представления отдается в заголовке для повторной правки
+func versionConflict(c *gin.Context, conflict *apperrors.Error, current any, v *validators) error {
+	c.Header("ETag", v.etag())
+	return conflict.WithCurrent(current)
+}
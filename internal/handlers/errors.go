@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+)
+
+var (
+	// errFileRequired - в multipart форме нет поля "file"
+	errFileRequired = apperrors.Validation("file is required", apperrors.FieldError{Field: "file", Message: "is required"})
+	// errFileTooLarge - запрос с файлом превышает лимит размера
+	errFileTooLarge = apperrors.New(apperrors.CodeTooLarge, "file is too large")
+	// errMediaNotFound - загруженный файл не найден в хранилище
+	errMediaNotFound = apperrors.NotFound("file not found")
+	// errVerificationTypeRequired - при установке верификации нужно указать ее тип
+	errVerificationTypeRequired = apperrors.Validation("verification_type is required",
+		apperrors.FieldError{Field: "verification_type", Message: "is required when verified is true"})
+)
+
+// Ошибки валидации называют поля так же, как они приходят в запросе (json/form теги),
+// а не именами полей Go структур
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(requestFieldName)
+	}
+}
+
+// requestFieldName - имя поля из тега json или form
+func requestFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// respondError - передача ошибки в middleware.ErrorHandler, который формирует ответ
+// по коду доменной ошибки (apperrors)
+func respondError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/exporter"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// ExportHandler - обработчики экспорта книг и данных пользователя
+type ExportHandler struct {
+	exportService *services.ExportService
+}
+
+// NewExportHandler - создание нового ExportHandler
+func NewExportHandler(exportService *services.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+// ExportBook - выгрузка книги с частями
+// @Summary Экспорт книги
+// @Description Выгрузка книги и ее частей в EPUB, Markdown (zip-архив) или каноничный JSON
+// @Tags books
+// @Produce application/epub+zip
+// @Produce application/zip
+// @Produce json
+// @Param id path string true "ID книги"
+// @Param format query string false "Формат (epub, md, json)" default(epub)
+// @Success 200 {file} file
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/books/{id}/export [get]
+func (h *ExportHandler) ExportBook(c *gin.Context) {
+	format := exporter.Format(c.DefaultQuery("format", string(exporter.FormatEPUB)))
+
+	file, err := h.exportService.ExportBook(c.Request.Context(), c.Param("id"), format)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	sendExportFile(c, file)
+}
+
+// ExportMyData - выгрузка данных текущего пользователя
+// @Summary Экспорт моих данных
+// @Description Выгрузка отзывов, цитат и прогресса чтения текущего пользователя в JSON или CSV (zip-архив)
+// @Tags users
+// @Produce json
+// @Produce application/zip
+// @Param Authorization header string true "Bearer токен"
+// @Param format query string false "Формат (json, csv)" default(json)
+// @Success 200 {file} file
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/me/export [get]
+func (h *ExportHandler) ExportMyData(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+	format := exporter.Format(c.DefaultQuery("format", string(exporter.FormatJSON)))
+
+	file, err := h.exportService.ExportUserData(c.Request.Context(), currentUser.UserID, format)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	sendExportFile(c, file)
+}
+
+// sendExportFile - отправка файла выгрузки как вложения
+func sendExportFile(c *gin.Context, file *exporter.File) {
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
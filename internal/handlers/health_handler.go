@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/health"
+)
+
+// HealthHandler - пробы живости и готовности
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+// NewHealthHandler - создание нового HealthHandler
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{
+		checker: checker,
+	}
+}
+
+// Livez - процесс жив и обрабатывает запросы; зависимости не проверяются,
+// чтобы недоступная база не приводила к перезапуску контейнера
+// @Summary Проба живости
+// @Description Процесс отвечает на запросы (без проверки зависимостей)
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /livez [get]
+func (h *HealthHandler) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusOK})
+}
+
+// Readyz - сервис готов принимать трафик: база данных, схема и зарегистрированные зависимости доступны
+// @Summary Проба готовности
+// @Description Проверка базы данных, примененных миграций и хранилища; 503, если что-то недоступно или сервис останавливается
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.Report
+// @Failure 503 {object} health.Report
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	report := h.checker.Check(c.Request.Context())
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
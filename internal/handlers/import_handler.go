@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// maxImportFileSize - максимальный размер загружаемого файла книги
+const maxImportFileSize = 50 << 20
+
+// ImportHandler - обработчики импорта книг
+type ImportHandler struct {
+	importService *services.ImportService
+}
+
+// NewImportHandler - создание нового ImportHandler
+func NewImportHandler(importService *services.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+// ImportBook - импорт книги из EPUB или FB2 (требует прав moderator/admin)
+// @Summary Импорт книги
+// @Description Создание книги и ее частей из файла EPUB, FB2 или FB2.zip. С dry_run=true возвращает предпросмотр без сохранения
+// @Tags books
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param file formData file true "Файл книги (.epub, .fb2, .fb2.zip)"
+// @Param dry_run query bool false "Только предпросмотр"
+// @Success 200 {object} models.ImportResponse
+// @Success 201 {object} models.ImportResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 413 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/import [post]
+func (h *ImportHandler) ImportBook(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize+1<<20)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			respondError(c, errFileTooLarge)
+			return
+		}
+		respondError(c, errFileRequired)
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		respondError(c, errFileTooLarge)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		respondError(c, errFileRequired)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if c.Query("dry_run") == "true" {
+		preview, err := h.importService.Preview(fileHeader.Filename, data)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, preview)
+		return
+	}
+
+	result, err := h.importService.Import(c.Request.Context(), fileHeader.Filename, data, &currentUser.UserID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
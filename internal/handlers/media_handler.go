@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+	"github.com/tukembaev/bookVisionGo/internal/storage"
+)
+
+// immutableCacheControl - ключи файлов зависят от содержимого, поэтому ответ кэшируется навсегда
+const immutableCacheControl = "public, max-age=31536000, immutable"
+
+// MediaHandler - обработчики загрузки и отдачи изображений
+type MediaHandler struct {
+	mediaService *services.MediaService
+}
+
+// NewMediaHandler - создание нового MediaHandler
+func NewMediaHandler(mediaService *services.MediaService) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+	}
+}
+
+// UploadBookCover - загрузка обложки книги (требует прав moderator/admin)
+// @Summary Загрузка обложки книги
+// @Description Принимает JPEG, PNG, GIF или WebP до 5 МБ (тип определяется по содержимому). Создает уменьшенные копии в JPEG и WebP и заменяет cover_url книги
+// @Tags books
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID книги"
+// @Param file formData file true "Изображение"
+// @Success 200 {object} models.UploadResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 413 {object} middleware.ErrorResponse
+// @Failure 415 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id}/cover [post]
+func (h *MediaHandler) UploadBookCover(c *gin.Context) {
+	data, ok := readUpload(c)
+	if !ok {
+		return
+	}
+
+	upload, err := h.mediaService.UploadBookCover(c.Request.Context(), c.Param("id"), data)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, upload)
+}
+
+// UploadArticleCover - загрузка обложки статьи (требует прав moderator/admin)
+// @Summary Загрузка обложки статьи
+// @Description Принимает JPEG, PNG, GIF или WebP до 5 МБ. Создает уменьшенные копии в JPEG и WebP и заменяет cover_url статьи
+// @Tags articles
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID статьи"
+// @Param file formData file true "Изображение"
+// @Success 200 {object} models.UploadResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 413 {object} middleware.ErrorResponse
+// @Failure 415 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/articles/{id}/cover [post]
+func (h *MediaHandler) UploadArticleCover(c *gin.Context) {
+	data, ok := readUpload(c)
+	if !ok {
+		return
+	}
+
+	upload, err := h.mediaService.UploadArticleCover(c.Request.Context(), c.Param("id"), data)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, upload)
+}
+
+// UploadAvatar - загрузка аватара текущего пользователя
+// @Summary Загрузка аватара
+// @Description Принимает JPEG, PNG, GIF или WebP до 2 МБ. Создает квадратные копии 64 и 256 px в JPEG и WebP и заменяет avatar_url
+// @Tags users
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param file formData file true "Изображение"
+// @Success 200 {object} models.UploadResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 413 {object} middleware.ErrorResponse
+// @Failure 415 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/me/avatar [put]
+func (h *MediaHandler) UploadAvatar(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	data, ok := readUpload(c)
+	if !ok {
+		return
+	}
+
+	upload, err := h.mediaService.UploadAvatar(c.Request.Context(), currentUser.UserID, data)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, upload)
+}
+
+// DeleteAvatar - удаление аватара текущего пользователя
+// @Summary Удаление аватара
+// @Tags users
+// @Param Authorization header string true "Bearer токен"
+// @Success 204
+// @Failure 401 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/me/avatar [delete]
+func (h *MediaHandler) DeleteAvatar(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	if err := h.mediaService.DeleteAvatar(c.Request.Context(), currentUser.UserID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UploadIllustration - загрузка иллюстрации персонажа (требует прав moderator/admin)
+// @Summary Загрузка иллюстрации персонажа
+// @Description Принимает JPEG, PNG, GIF или WebP до 10 МБ и привязывает иллюстрацию к профилю персонажа
+// @Tags characters
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID профиля персонажа"
+// @Param file formData file true "Изображение"
+// @Param author_name formData string true "Автор иллюстрации"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 413 {object} middleware.ErrorResponse
+// @Failure 415 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/characters/{id}/illustrations [post]
+func (h *MediaHandler) UploadIllustration(c *gin.Context) {
+	data, ok := readUpload(c)
+	if !ok {
+		return
+	}
+
+	authorName := strings.TrimSpace(c.PostForm("author_name"))
+	if authorName == "" {
+		respondError(c, apperrors.Validation("author_name is required", apperrors.FieldError{Field: "author_name", Message: "is required"}))
+		return
+	}
+
+	illustration, upload, err := h.mediaService.AddIllustration(c.Request.Context(), c.Param("id"), authorName, data)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"illustration": illustration.ToResponse(),
+		"upload":       upload,
+	})
+}
+
+// ServeMedia - отдача загруженного файла.
+// Файлы из S3 отдаются редиректом на подписанную ссылку, локальные - напрямую с вечным кэшированием.
+func (h *MediaHandler) ServeMedia(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if !storage.ValidKey(key) {
+		respondError(c, errMediaNotFound)
+		return
+	}
+
+	url, ttl, err := h.mediaService.SignedURL(c.Request.Context(), key)
+	if err == nil {
+		c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", int(ttl.Seconds())/2))
+		c.Redirect(http.StatusFound, url)
+		return
+	}
+	if !errors.Is(err, storage.ErrPresignNotSupported) {
+		respondError(c, err)
+		return
+	}
+
+	reader, info, err := h.mediaService.Open(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			respondError(c, errMediaNotFound)
+			return
+		}
+		respondError(c, err)
+		return
+	}
+	defer reader.Close()
+
+	c.Header("Cache-Control", immutableCacheControl)
+	c.Header("ETag", info.ETag)
+	if c.GetHeader("If-None-Match") == info.ETag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.DataFromReader(http.StatusOK, info.Size, info.ContentType, reader, map[string]string{
+		"Last-Modified":          info.ModTime.Format(http.TimeFormat),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// readUpload - чтение поля "file" из multipart формы с ограничением размера запроса.
+// При ошибке ответ уже отправлен клиенту.
+func readUpload(c *gin.Context) ([]byte, bool) {
+	maxSize := services.MaxMediaSize()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			respondError(c, errFileTooLarge)
+			return nil, false
+		}
+		respondError(c, errFileRequired)
+		return nil, false
+	}
+	if fileHeader.Size > maxSize {
+		respondError(c, errFileTooLarge)
+		return nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		respondError(c, errFileRequired)
+		return nil, false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		respondError(c, err)
+		return nil, false
+	}
+	return data, true
+}
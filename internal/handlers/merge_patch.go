@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+)
+
+// mergePatchContentType - тип тела PATCH запросов (JSON Merge Patch, RFC 7396)
+const mergePatchContentType = "application/merge-patch+json"
+
+var (
+	// errMergePatchMediaType - тело PATCH не JSON
+	errMergePatchMediaType = apperrors.New(apperrors.CodeUnsupportedMedia, "PATCH body must be "+mergePatchContentType)
+	// errMergePatchNotObject - патч не JSON объект
+	errMergePatchNotObject = apperrors.Validation("merge patch must be a JSON object")
+)
+
+// bindMergePatch - применение JSON Merge Patch из тела запроса к current и разбор результата в target.
+// null в патче удаляет поле (для необязательных полей - сброс в null), неизвестные поля отклоняются,
+// результат проверяется правилами binding, как обычное тело запроса.
+func bindMergePatch(c *gin.Context, current, target any) error {
+	if ct := c.ContentType(); ct != mergePatchContentType && ct != binding.MIMEJSON {
+		return errMergePatchMediaType
+	}
+
+	patchData, err := requestBody(c)
+	if err != nil {
+		return apperrors.FromBinding(err)
+	}
+	var patch any
+	if err := decodeJSON(patchData, &patch); err != nil {
+		return apperrors.FromBinding(err)
+	}
+	if _, ok := patch.(map[string]any); !ok {
+		return errMergePatchNotObject
+	}
+
+	currentData, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	var doc any
+	if err := decodeJSON(currentData, &doc); err != nil {
+		return err
+	}
+
+	merged, err := json.Marshal(mergePatch(doc, patch))
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return apperrors.FromBinding(err)
+	}
+	if err := binding.Validator.ValidateStruct(target); err != nil {
+		return apperrors.FromBinding(err)
+	}
+	return nil
+}
+
+// mergePatch - алгоритм MergePatch из RFC 7396: объекты сливаются рекурсивно,
+// null удаляет ключ, любое другое значение (включая массивы) заменяет целиком
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// requestBody - тело запроса; сохраняется в контексте, поэтому повтор транзакции
+// может разобрать его еще раз
+func requestBody(c *gin.Context) ([]byte, error) {
+	if cached, ok := c.Get(gin.BodyBytesKey); ok {
+		if body, ok := cached.([]byte); ok {
+			return body, nil
+		}
+	}
+	body, err := c.GetRawData()
+	if err != nil {
+		return nil, err
+	}
+	c.Set(gin.BodyBytesKey, body)
+	return body, nil
+}
+
+// decodeJSON - разбор JSON с сохранением чисел без потери точности
+func decodeJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// QuoteHandler - обработчики для работы с цитатами
+type QuoteHandler struct {
+	quoteRepo     interfaces.QuoteRepository
+	bookRepo      interfaces.BookRepository
+	socialService *services.SocialService
+}
+
+// NewQuoteHandler - создание нового QuoteHandler
+func NewQuoteHandler(quoteRepo interfaces.QuoteRepository, bookRepo interfaces.BookRepository, socialService *services.SocialService) *QuoteHandler {
+	return &QuoteHandler{
+		quoteRepo:     quoteRepo,
+		bookRepo:      bookRepo,
+		socialService: socialService,
+	}
+}
+
+// CreateQuote - сохранение цитаты из книги
+// @Summary Сохранение цитаты
+// @Description Сохранение цитаты из книги текущим пользователем
+// @Tags quotes
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param request body models.CreateQuoteRequest true "Данные цитаты"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/quotes [post]
+func (h *QuoteHandler) CreateQuote(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	var req models.CreateQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	quote := &models.Quote{
+		UserID: currentUser.UserID,
+		BookID: req.BookID,
+		PartID: req.PartID,
+		Text:   req.Text,
+	}
+
+	// Книга в корзине не принимает цитаты: внешний ключ видит удаленную строку
+	ctx := c.Request.Context()
+	if _, err := h.bookRepo.GetByID(ctx, quote.BookID); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := h.quoteRepo.Create(ctx, quote); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if err := h.socialService.Publish(ctx, currentUser.UserID, models.ActivityTypeQuoteSaved, quote.ID, &quote.BookID, map[string]interface{}{
+		"text": quote.Text,
+	}); err != nil {
+		slog.WarnContext(ctx, "failed to publish activity", "error", err)
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"quote": quote.ToResponse(),
+	})
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// ReadingHandler - обработчики для работы с прогрессом чтения
+type ReadingHandler struct {
+	readingService *services.ReadingService
+}
+
+// NewReadingHandler - создание нового ReadingHandler
+func NewReadingHandler(readingService *services.ReadingService) *ReadingHandler {
+	return &ReadingHandler{
+		readingService: readingService,
+	}
+}
+
+// UpdateProgress - обновление прогресса чтения книги
+// @Summary Обновление прогресса чтения
+// @Description Обновление прогресса чтения книги текущим пользователем
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID книги"
+// @Param request body models.UpdateBookProgressRequest true "Прогресс чтения"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id}/progress [put]
+func (h *ReadingHandler) UpdateProgress(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	var req models.UpdateBookProgressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	progress, err := h.readingService.UpdateProgress(c.Request.Context(), currentUser.UserID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"progress": progress.ToResponse(),
+	})
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// RecommendationHandler - обработчики рекомендаций книг
+type RecommendationHandler struct {
+	recommendationService *services.RecommendationService
+}
+
+// NewRecommendationHandler - создание нового RecommendationHandler
+func NewRecommendationHandler(recommendationService *services.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{
+		recommendationService: recommendationService,
+	}
+}
+
+// GetRecommendations - рекомендации для текущего пользователя
+// @Summary Вам может понравиться
+// @Description Рекомендации на основе положительных отзывов, избранного и прочитанных книг пользователя
+// @Tags recommendations
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param limit query int false "Лимит" default(20)
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/recommendations [get]
+func (h *RecommendationHandler) GetRecommendations(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+	limit, _ := paginationParams(c)
+
+	books, err := h.recommendationService.ForUser(c.Request.Context(), currentUser.UserID, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"books": books,
+	})
+}
+
+// GetSimilarBooks - похожие книги
+// @Summary Похожие книги
+// @Description Похожие книги по общим читателям, жанрам, тегам и настроению частей
+// @Tags recommendations
+// @Produce json
+// @Param id path string true "ID книги"
+// @Param limit query int false "Лимит" default(20)
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/books/{id}/similar [get]
+func (h *RecommendationHandler) GetSimilarBooks(c *gin.Context) {
+	limit, _ := paginationParams(c)
+
+	books, err := h.recommendationService.SimilarBooks(c.Request.Context(), c.Param("id"), limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"books": books,
+	})
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+	"github.com/tukembaev/bookVisionGo/internal/utils"
+)
+
+// ReportHandler - обработчики жалоб на пользовательский контент и очереди жалоб для модераторов
+type ReportHandler struct {
+	reportService *services.ReportService
+}
+
+// NewReportHandler - создание нового ReportHandler
+func NewReportHandler(reportService *services.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+// CreateReport - жалоба на рецензию, комментарий, цитату, статью или профиль персонажа
+// @Summary Жалоба на контент
+// @Description На один объект у пользователя может быть одна открытая жалоба; на свой контент жаловаться нельзя.
+// @Description После жалоб нескольких разных пользователей (MODERATION_AUTO_HIDE_REPORTS) контент скрывается до решения модератора.
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param request body models.CreateReportRequest true "Объект, причина и описание"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/reports [post]
+func (h *ReportHandler) CreateReport(c *gin.Context) {
+	var req models.CreateReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	report, err := h.reportService.Report(c.Request.Context(), middleware.GetCurrentUser(c).UserID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"report": report,
+	})
+}
+
+// GetReportQueue - очередь жалоб (требует прав moderator/admin)
+// @Summary Очередь жалоб
+// @Description Жалобы от давних к новым, по умолчанию открытые. open_reports - число открытых жалоб на тот же объект,
+// @Description target_hidden - скрыт ли объект сейчас.
+// @Tags moderation
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param status query string false "Статус" Enums(open, resolved, dismissed) default(open)
+// @Param target_type query string false "Тип объекта" Enums(review, comment, quote, article, character_profile)
+// @Param target_id query string false "ID объекта"
+// @Param claimed_by query string false "ID модератора, взявшего жалобу в работу"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/moderation/reports [get]
+func (h *ReportHandler) GetReportQueue(c *gin.Context) {
+	var filter models.ReportFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+	limit, offset := paginationParams(c)
+
+	reports, err := h.reportService.Queue(c.Request.Context(), &filter, limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reports": reports,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+// GetReport - жалоба по ID (требует прав moderator/admin)
+// @Summary Жалоба
+// @Tags moderation
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID жалобы"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/moderation/reports/{id} [get]
+func (h *ReportHandler) GetReport(c *gin.Context) {
+	report, err := h.reportService.GetReport(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report": report,
+	})
+}
+
+// ClaimReport - взятие жалобы в работу (требует прав moderator/admin)
+// @Summary Взятие жалобы в работу
+// @Description Пока жалоба за модератором, другие модераторы не могут ее решить или отпустить (администратор может)
+// @Tags moderation
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID жалобы"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/moderation/reports/{id}/claim [post]
+func (h *ReportHandler) ClaimReport(c *gin.Context) {
+	h.reportAction(c, h.reportService.Claim)
+}
+
+// ReleaseReport - возврат жалобы в общую очередь (требует прав moderator/admin)
+// @Summary Возврат жалобы в очередь
+// @Tags moderation
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID жалобы"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/moderation/reports/{id}/claim [delete]
+func (h *ReportHandler) ReleaseReport(c *gin.Context) {
+	h.reportAction(c, h.reportService.Release)
+}
+
+// ResolveReport - жалоба обоснована (требует прав moderator/admin)
+// @Summary Подтверждение жалобы
+// @Description Закрывает все открытые жалобы на объект, скрывает его и выдает автору предупреждение.
+// @Description Каждые MODERATION_STRIKES_TO_SUSPEND предупреждений блокируют аккаунт автора на MODERATION_SUSPENSION_DAYS дней.
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID жалобы"
+// @Param request body models.ResolveReportRequest false "Комментарий модератора"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/moderation/reports/{id}/resolve [post]
+func (h *ReportHandler) ResolveReport(c *gin.Context) {
+	h.decideReport(c, h.reportService.Resolve)
+}
+
+// DismissReport - жалоба отклонена (требует прав moderator/admin)
+// @Summary Отклонение жалобы
+// @Description Закрывает все открытые жалобы на объект и возвращает автоматически скрытый объект
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID жалобы"
+// @Param request body models.ResolveReportRequest false "Комментарий модератора"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/moderation/reports/{id}/dismiss [post]
+func (h *ReportHandler) DismissReport(c *gin.Context) {
+	h.decideReport(c, h.reportService.Dismiss)
+}
+
+// BulkReports - одно действие над несколькими жалобами (требует прав moderator/admin)
+// @Summary Пакетные действия с жалобами
+// @Description Каждая жалоба обрабатывается отдельно: ошибки по отдельным жалобам возвращаются в failed
+// @Description и не отменяют остальные действия.
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param request body models.BulkReportRequest true "ID жалоб, действие и комментарий"
+// @Success 200 {object} models.BulkReportResult
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/moderation/reports/bulk [post]
+func (h *ReportHandler) BulkReports(c *gin.Context) {
+	var req models.BulkReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, h.reportService.Bulk(c.Request.Context(), middleware.GetCurrentUser(c), &req))
+}
+
+// reportAction - действие модератора над жалобой без тела запроса
+func (h *ReportHandler) reportAction(c *gin.Context, action func(ctx context.Context, moderator *utils.Claims, id string) (*models.ContentReport, error)) {
+	report, err := action(c.Request.Context(), middleware.GetCurrentUser(c), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report": report,
+	})
+}
+
+// decideReport - решение модератора с необязательным комментарием в теле запроса
+func (h *ReportHandler) decideReport(c *gin.Context, decide func(ctx context.Context, moderator *utils.Claims, id string, note *string) (*models.ContentReport, error)) {
+	var req models.ResolveReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	report, err := decide(c.Request.Context(), middleware.GetCurrentUser(c), c.Param("id"), req.Note)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report": report,
+	})
+}
+
+// canSeeHidden - модераторы и администраторы видят скрытый по жалобам контент, чтобы разобрать жалобы
+// и исправить его; остальным он недоступен
+func canSeeHidden(c *gin.Context) bool {
+	user := middleware.GetOptionalUser(c)
+	return user != nil && (user.Role == models.UserRoleModerator || user.Role == models.UserRoleAdmin)
+}
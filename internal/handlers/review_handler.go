@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/metrics"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// ReviewHandler - обработчики для работы с отзывами
+type ReviewHandler struct {
+	reviewRepo    interfaces.ReviewRepository
+	bookRepo      interfaces.BookRepository
+	txManager     interfaces.TxManager
+	socialService *services.SocialService
+}
+
+// NewReviewHandler - создание нового ReviewHandler
+func NewReviewHandler(reviewRepo interfaces.ReviewRepository, bookRepo interfaces.BookRepository, txManager interfaces.TxManager, socialService *services.SocialService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewRepo:    reviewRepo,
+		bookRepo:      bookRepo,
+		txManager:     txManager,
+		socialService: socialService,
+	}
+}
+
+// CreateReview - создание отзыва на книгу
+// @Summary Создание отзыва
+// @Description Создание отзыва текущего пользователя на книгу
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param request body models.CreateReviewRequest true "Данные отзыва"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/reviews [post]
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	var req models.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	review := &models.Review{
+		UserID:             currentUser.UserID,
+		BookID:             req.BookID,
+		Rating:             req.Rating,
+		Text:               req.Text,
+		LikedCharacters:    req.LikedCharacters,
+		DislikedCharacters: req.DislikedCharacters,
+		BestParts:          req.BestParts,
+	}
+
+	// Книга в корзине не принимает отзывы: внешний ключ видит удаленную строку
+	ctx := c.Request.Context()
+	if _, err := h.bookRepo.GetByID(ctx, review.BookID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Отзыв и пересчет рейтинга книги фиксируются вместе; SERIALIZABLE не дает
+	// конкурентным отзывам на ту же книгу потерять друг друга в среднем значении
+	err := h.txManager.WithinSerializableTx(ctx, func(ctx context.Context) error {
+		if err := h.reviewRepo.Create(ctx, review); err != nil {
+			return err
+		}
+		return h.reviewRepo.RefreshBookRating(ctx, review.BookID)
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	metrics.ReviewsPosted.Inc()
+
+	if err := h.socialService.Publish(ctx, currentUser.UserID, models.ActivityTypeReviewCreated, review.ID, &review.BookID, map[string]interface{}{
+		"rating": review.Rating,
+	}); err != nil {
+		slog.WarnContext(ctx, "failed to publish activity", "error", err)
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"review": review.ToResponse(),
+	})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// revisionDiffQuery - номера сравниваемых ревизий
+type revisionDiffQuery struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"required,min=1"`
+}
+
+// listRevisions - ответ со списком ревизий сущности (limit/offset из query)
+func listRevisions(c *gin.Context, revisions *services.RevisionService, entity models.RevisionEntity, entityID string) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	list, err := revisions.List(c.Request.Context(), entity, entityID, limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"revisions": list,
+	})
+}
+
+// diffRevisions - ответ с отличиями двух ревизий сущности (from/to из query)
+func diffRevisions(c *gin.Context, revisions *services.RevisionService, entity models.RevisionEntity, entityID string) {
+	var query revisionDiffQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	diff, err := revisions.Diff(c.Request.Context(), entity, entityID, query.From, query.To)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// bindRevert - номер ревизии из пути и необязательное тело запроса отката.
+// Без комментария ревизия отката подписывается номером восстановленной ревизии.
+func bindRevert(c *gin.Context) (int, *models.RevertRevisionRequest, error) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		return 0, nil, interfaces.ErrRevisionNotFound
+	}
+
+	var req models.RevertRevisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		return 0, nil, apperrors.FromBinding(err)
+	}
+	if req.Comment == nil {
+		comment := fmt.Sprintf("Revert to revision %d", version)
+		req.Comment = &comment
+	}
+	return version, &req, nil
+}
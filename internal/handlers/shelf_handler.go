@@ -0,0 +1,445 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// ShelfHandler - обработчики полок и избранного
+type ShelfHandler struct {
+	shelfService *services.ShelfService
+}
+
+// NewShelfHandler - создание нового ShelfHandler
+func NewShelfHandler(shelfService *services.ShelfService) *ShelfHandler {
+	return &ShelfHandler{
+		shelfService: shelfService,
+	}
+}
+
+// GetShelves - обзор полок текущего пользователя
+// @Summary Полки пользователя
+// @Description Количество книг на встроенных полках и список пользовательских полок
+// @Tags shelves
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Success 200 {object} models.ShelvesOverviewResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/shelves [get]
+func (h *ShelfHandler) GetShelves(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	overview, err := h.shelfService.Overview(c.Request.Context(), currentUser.UserID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, overview)
+}
+
+// GetShelfStatusBooks - книги на встроенной полке
+// @Summary Книги на встроенной полке
+// @Description Получение книг текущего пользователя на полке want_to_read, reading, read или abandoned
+// @Tags shelves
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param status path string true "Статус полки" Enums(want_to_read, reading, read, abandoned)
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/shelves/status/{status} [get]
+func (h *ShelfHandler) GetShelfStatusBooks(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+	limit, offset := paginationParams(c)
+
+	books, err := h.shelfService.ListByStatus(c.Request.Context(), currentUser.UserID, models.ShelfStatus(c.Param("status")), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"books":  books,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// SetBookShelfStatus - перемещение книги на встроенную полку
+// @Summary Перемещение книги на полку
+// @Description Перемещение книги на встроенную полку. "reading" создает прогресс чтения, "read" отмечает книгу прочитанной,
+// @Description "reading" и "want_to_read" снимают отметку о прочтении
+// @Tags shelves
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID книги"
+// @Param request body models.SetShelfStatusRequest true "Статус полки"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id}/shelf [put]
+func (h *ShelfHandler) SetBookShelfStatus(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	var req models.SetShelfStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	entry, err := h.shelfService.SetStatus(c.Request.Context(), currentUser.UserID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"shelf": entry,
+	})
+}
+
+// RemoveBookShelfStatus - удаление книги со встроенной полки
+// @Summary Удаление книги с полки
+// @Description Удаление книги со встроенной полки текущего пользователя
+// @Tags shelves
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID книги"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id}/shelf [delete]
+func (h *ShelfHandler) RemoveBookShelfStatus(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	if err := h.shelfService.RemoveStatus(c.Request.Context(), currentUser.UserID, c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Book removed from shelf",
+	})
+}
+
+// CreateShelf - создание пользовательской полки
+// @Summary Создание полки
+// @Description Создание именованной полки текущего пользователя
+// @Tags shelves
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param request body models.CreateShelfRequest true "Данные полки"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/shelves [post]
+func (h *ShelfHandler) CreateShelf(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	var req models.CreateShelfRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	shelf, err := h.shelfService.CreateShelf(c.Request.Context(), currentUser.UserID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"shelf": shelf,
+	})
+}
+
+// UpdateShelf - обновление пользовательской полки
+// @Summary Обновление полки
+// @Description Переименование или изменение позиции полки
+// @Tags shelves
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID полки"
+// @Param request body models.UpdateShelfRequest true "Данные для обновления"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/shelves/{id} [put]
+func (h *ShelfHandler) UpdateShelf(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	var req models.UpdateShelfRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	shelf, err := h.shelfService.UpdateShelf(c.Request.Context(), currentUser.UserID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"shelf": shelf,
+	})
+}
+
+// DeleteShelf - удаление пользовательской полки
+// @Summary Удаление полки
+// @Description Удаление полки вместе со списком книг на ней
+// @Tags shelves
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID полки"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/shelves/{id} [delete]
+func (h *ShelfHandler) DeleteShelf(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	if err := h.shelfService.DeleteShelf(c.Request.Context(), currentUser.UserID, c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Shelf deleted successfully",
+	})
+}
+
+// GetShelfBooks - книги на пользовательской полке
+// @Summary Книги на полке
+// @Description Получение книг на пользовательской полке в порядке position
+// @Tags shelves
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID полки"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/shelves/{id}/books [get]
+func (h *ShelfHandler) GetShelfBooks(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+	limit, offset := paginationParams(c)
+
+	items, err := h.shelfService.ListItems(c.Request.Context(), currentUser.UserID, c.Param("id"), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"books":  items,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// AddShelfBook - добавление книги на пользовательскую полку
+// @Summary Добавление книги на полку
+// @Description Добавление книги на пользовательскую полку с заметкой
+// @Tags shelves
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID полки"
+// @Param request body models.AddShelfItemRequest true "Книга"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/shelves/{id}/books [post]
+func (h *ShelfHandler) AddShelfBook(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	var req models.AddShelfItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	item, err := h.shelfService.AddItem(c.Request.Context(), currentUser.UserID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"book": item,
+	})
+}
+
+// UpdateShelfBook - обновление книги на пользовательской полке
+// @Summary Обновление книги на полке
+// @Description Изменение заметки или позиции книги на полке
+// @Tags shelves
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID полки"
+// @Param bookId path string true "ID книги"
+// @Param request body models.UpdateShelfItemRequest true "Данные для обновления"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/shelves/{id}/books/{bookId} [put]
+func (h *ShelfHandler) UpdateShelfBook(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	var req models.UpdateShelfItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	item, err := h.shelfService.UpdateItem(c.Request.Context(), currentUser.UserID, c.Param("id"), c.Param("bookId"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"book": item,
+	})
+}
+
+// RemoveShelfBook - удаление книги с пользовательской полки
+// @Summary Удаление книги с полки
+// @Description Удаление книги с пользовательской полки
+// @Tags shelves
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID полки"
+// @Param bookId path string true "ID книги"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/shelves/{id}/books/{bookId} [delete]
+func (h *ShelfHandler) RemoveShelfBook(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	if err := h.shelfService.RemoveItem(c.Request.Context(), currentUser.UserID, c.Param("id"), c.Param("bookId")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Book removed from shelf",
+	})
+}
+
+// GetFavorites - избранные книги текущего пользователя
+// @Summary Избранные книги
+// @Description Получение избранных книг текущего пользователя
+// @Tags shelves
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/shelves/favorites [get]
+func (h *ShelfHandler) GetFavorites(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+	limit, offset := paginationParams(c)
+
+	favorites, err := h.shelfService.ListFavorites(c.Request.Context(), currentUser.UserID, limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	responses := make([]*models.UserFavoriteResponse, len(favorites))
+	for i, favorite := range favorites {
+		responses[i] = favorite.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"favorites": responses,
+		"limit":     limit,
+		"offset":    offset,
+	})
+}
+
+// AddFavorite - добавление книги в избранное
+// @Summary Добавление в избранное
+// @Description Добавление книги в избранное текущего пользователя
+// @Tags shelves
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID книги"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id}/favorite [post]
+func (h *ShelfHandler) AddFavorite(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	favorite, err := h.shelfService.AddFavorite(c.Request.Context(), currentUser.UserID, c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"favorite": favorite.ToResponse(),
+	})
+}
+
+// RemoveFavorite - удаление книги из избранного
+// @Summary Удаление из избранного
+// @Description Удаление книги из избранного текущего пользователя
+// @Tags shelves
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID книги"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id}/favorite [delete]
+func (h *ShelfHandler) RemoveFavorite(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	if err := h.shelfService.RemoveFavorite(c.Request.Context(), currentUser.UserID, c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Book removed from favorites",
+	})
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// SocialHandler - обработчики подписок и ленты активности
+type SocialHandler struct {
+	socialService *services.SocialService
+}
+
+// NewSocialHandler - создание нового SocialHandler
+func NewSocialHandler(socialService *services.SocialService) *SocialHandler {
+	return &SocialHandler{
+		socialService: socialService,
+	}
+}
+
+// FollowUser - подписка на пользователя
+// @Summary Подписка на пользователя
+// @Description Подписка текущего пользователя на пользователя username
+// @Tags social
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param username path string true "Имя пользователя"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/{username}/follow [post]
+func (h *SocialHandler) FollowUser(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	if err := h.socialService.Follow(c.Request.Context(), currentUser.UserID, c.Param("username")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Followed successfully",
+	})
+}
+
+// UnfollowUser - отписка от пользователя
+// @Summary Отписка от пользователя
+// @Description Отписка текущего пользователя от пользователя username
+// @Tags social
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param username path string true "Имя пользователя"
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/{username}/follow [delete]
+func (h *SocialHandler) UnfollowUser(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+
+	if err := h.socialService.Unfollow(c.Request.Context(), currentUser.UserID, c.Param("username")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Unfollowed successfully",
+	})
+}
+
+// GetFollowers - получение подписчиков пользователя
+// @Summary Подписчики пользователя
+// @Description Получение списка подписчиков с учетом profile_visibility
+// @Tags social
+// @Produce json
+// @Param username path string true "Имя пользователя"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/users/{username}/followers [get]
+func (h *SocialHandler) GetFollowers(c *gin.Context) {
+	limit, offset := paginationParams(c)
+
+	users, err := h.socialService.ListFollowers(c.Request.Context(), middleware.GetOptionalUser(c), c.Param("username"), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users":  users,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetFollowing - получение подписок пользователя
+// @Summary Подписки пользователя
+// @Description Получение списка пользователей, на которых подписан username, с учетом profile_visibility
+// @Tags social
+// @Produce json
+// @Param username path string true "Имя пользователя"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/users/{username}/following [get]
+func (h *SocialHandler) GetFollowing(c *gin.Context) {
+	limit, offset := paginationParams(c)
+
+	users, err := h.socialService.ListFollowing(c.Request.Context(), middleware.GetOptionalUser(c), c.Param("username"), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users":  users,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetFeed - получение персональной ленты активности
+// @Summary Лента активности
+// @Description Лента событий от пользователей, на которых подписан текущий пользователь (курсорная пагинация)
+// @Tags social
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param cursor query string false "Курсор из next_cursor предыдущей страницы"
+// @Param limit query int false "Лимит" default(20)
+// @Success 200 {object} models.FeedPageResponse
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 401 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/feed [get]
+func (h *SocialHandler) GetFeed(c *gin.Context) {
+	currentUser := middleware.GetCurrentUser(c)
+	limit, _ := paginationParams(c)
+
+	page, err := h.socialService.GetFeed(c.Request.Context(), currentUser.UserID, c.Query("cursor"), limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// TrashHandler - обработчики корзины администратора
+type TrashHandler struct {
+	trashService *services.TrashService
+}
+
+// NewTrashHandler - создание нового TrashHandler
+func NewTrashHandler(trashService *services.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// GetTrash - содержимое корзины (требует прав admin)
+// @Summary Корзина
+// @Description Удаленные книги, статьи, комментарии и пользователи от недавно удаленных к давним.
+// @Description purge_at - время окончательного удаления задачей очистки.
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param type query string false "Тип сущности" Enums(book, article, comment, user)
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/admin/trash [get]
+func (h *TrashHandler) GetTrash(c *gin.Context) {
+	limit, offset := paginationParams(c)
+
+	items, err := h.trashService.List(c.Request.Context(), models.TrashKind(c.Query("type")), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items":  items,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// RestoreTrashItem - восстановление сущности из корзины (требует прав admin)
+// @Summary Восстановление из корзины
+// @Description Снимает отметку об удалении: сущность снова видна в обычных запросах
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param type path string true "Тип сущности" Enums(book, article, comment, user)
+// @Param id path string true "ID сущности"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/admin/trash/{type}/{id}/restore [post]
+func (h *TrashHandler) RestoreTrashItem(c *gin.Context) {
+	err := h.trashService.Restore(c.Request.Context(), models.TrashKind(c.Param("type")), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Item restored successfully",
+	})
+}
+
+// PurgeTrashItem - окончательное удаление сущности из корзины (требует прав admin)
+// @Summary Окончательное удаление
+// @Description Удаляет сущность из корзины без ожидания срока хранения вместе со связанными данными; отменить нельзя
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param type path string true "Тип сущности" Enums(book, article, comment, user)
+// @Param id path string true "ID сущности"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/admin/trash/{type}/{id} [delete]
+func (h *TrashHandler) PurgeTrashItem(c *gin.Context) {
+	err := h.trashService.Purge(c.Request.Context(), models.TrashKind(c.Param("type")), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Item purged successfully",
+	})
+}
@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// UserHandler - обработчики публичных профилей пользователей
+type UserHandler struct {
+	userService *services.UserService
+}
+
+// NewUserHandler - создание нового UserHandler
+func NewUserHandler(userService *services.UserService) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+	}
+}
+
+// GetUserProfile - получение публичного профиля пользователя
+// @Summary Публичный профиль
+// @Description Получение публичного профиля пользователя с учетом profile_visibility
+// @Tags users
+// @Produce json
+// @Param username path string true "Имя пользователя"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/users/{username} [get]
+func (h *UserHandler) GetUserProfile(c *gin.Context) {
+	user, err := h.userService.GetPublicProfile(c.Request.Context(), middleware.GetOptionalUser(c), c.Param("username"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": user,
+	})
+}
+
+// GetUserReviews - получение отзывов пользователя
+// @Summary Отзывы пользователя
+// @Description Получение отзывов пользователя с учетом activity_visibility
+// @Tags users
+// @Produce json
+// @Param username path string true "Имя пользователя"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/users/{username}/reviews [get]
+func (h *UserHandler) GetUserReviews(c *gin.Context) {
+	limit, offset := paginationParams(c)
+
+	reviews, err := h.userService.GetUserReviews(c.Request.Context(), middleware.GetOptionalUser(c), c.Param("username"), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reviews": reviews,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+// GetUserQuotes - получение цитат пользователя
+// @Summary Цитаты пользователя
+// @Description Получение сохраненных цитат пользователя с учетом activity_visibility
+// @Tags users
+// @Produce json
+// @Param username path string true "Имя пользователя"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/users/{username}/quotes [get]
+func (h *UserHandler) GetUserQuotes(c *gin.Context) {
+	limit, offset := paginationParams(c)
+
+	quotes, err := h.userService.GetUserQuotes(c.Request.Context(), middleware.GetOptionalUser(c), c.Param("username"), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"quotes": quotes,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetUserProgress - получение прогресса чтения пользователя
+// @Summary Прогресс чтения пользователя
+// @Description Получение прогресса чтения книг пользователя с учетом activity_visibility
+// @Tags users
+// @Produce json
+// @Param username path string true "Имя пользователя"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/users/{username}/progress [get]
+func (h *UserHandler) GetUserProgress(c *gin.Context) {
+	limit, offset := paginationParams(c)
+
+	progress, err := h.userService.GetUserProgress(c.Request.Context(), middleware.GetOptionalUser(c), c.Param("username"), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"progress": progress,
+		"limit":    limit,
+		"offset":   offset,
+	})
+}
+
+// GetUserSessions - получение сессий чтения пользователя
+// @Summary Сессии чтения пользователя
+// @Description Получение сессий чтения пользователя с учетом activity_visibility
+// @Tags users
+// @Produce json
+// @Param username path string true "Имя пользователя"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/users/{username}/sessions [get]
+func (h *UserHandler) GetUserSessions(c *gin.Context) {
+	limit, offset := paginationParams(c)
+
+	sessions, err := h.userService.GetUserSessions(c.Request.Context(), middleware.GetOptionalUser(c), c.Param("username"), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sessions": sessions,
+		"limit":    limit,
+		"offset":   offset,
+	})
+}
+
+// GetUserFavorites - получение избранных книг пользователя
+// @Summary Избранные книги пользователя
+// @Description Получение избранных книг пользователя с учетом activity_visibility
+// @Tags users
+// @Produce json
+// @Param username path string true "Имя пользователя"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/users/{username}/favorites [get]
+func (h *UserHandler) GetUserFavorites(c *gin.Context) {
+	limit, offset := paginationParams(c)
+
+	favorites, err := h.userService.GetUserFavorites(c.Request.Context(), middleware.GetOptionalUser(c), c.Param("username"), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"favorites": favorites,
+		"limit":     limit,
+		"offset":    offset,
+	})
+}
+
+// DeleteUser - удаление пользователя (требует прав admin)
+// @Summary Удаление пользователя
+// @Description Перемещает пользователя в корзину: профиль и активность скрываются, вход и обновление токена запрещены.
+// @Description Восстановление - через корзину администратора до окончательной очистки.
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param username path string true "Имя пользователя"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/{username} [delete]
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	err := h.userService.DeleteUser(c.Request.Context(), middleware.GetCurrentUser(c).UserID, c.Param("username"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User deleted successfully",
+	})
+}
+
+// UpdateUserRole - смена роли пользователя (требует прав admin)
+// @Summary Смена роли пользователя
+// @Description Назначение роли user, moderator или admin; собственную роль сменить нельзя. Действие попадает в журнал аудита.
+// @Description Новая роль действует с выпуска следующего токена пользователя.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param username path string true "Имя пользователя"
+// @Param request body models.UpdateUserRoleRequest true "Новая роль"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/{username}/role [put]
+func (h *UserHandler) UpdateUserRole(c *gin.Context) {
+	var req models.UpdateUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	user, err := h.userService.ChangeRole(c.Request.Context(), middleware.GetCurrentUser(c).UserID, c.Param("username"), req.Role)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": user,
+	})
+}
+
+// ListUsers - список пользователей (требует прав admin)
+// @Summary Список пользователей
+// @Description Пользователи от новых к старым с ролью, числом предупреждений (strikes) и сроком блокировки (suspended_until)
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users [get]
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	limit, offset := paginationParams(c)
+
+	users, total, err := h.userService.ListUsers(c.Request.Context(), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users":  users,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// SuspendUser - блокировка пользователя (требует прав admin)
+// @Summary Блокировка пользователя
+// @Description Запрещает вход и обновление токена на days дней; уже выданный токен действует до истечения срока.
+// @Description Заменяет действующую блокировку. Пользователей также блокирует накопление предупреждений за нарушения.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param username path string true "Имя пользователя"
+// @Param request body models.SuspendUserRequest true "Срок блокировки"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/{username}/suspension [put]
+func (h *UserHandler) SuspendUser(c *gin.Context) {
+	var req models.SuspendUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	user, err := h.userService.Suspend(c.Request.Context(), middleware.GetCurrentUser(c).UserID, c.Param("username"), req.Days)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": user,
+	})
+}
+
+// UnsuspendUser - снятие блокировки пользователя (требует прав admin)
+// @Summary Снятие блокировки
+// @Description Число предупреждений сохраняется
+// @Tags users
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param username path string true "Имя пользователя"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/{username}/suspension [delete]
+func (h *UserHandler) UnsuspendUser(c *gin.Context) {
+	user, err := h.userService.Unsuspend(c.Request.Context(), middleware.GetCurrentUser(c).UserID, c.Param("username"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": user,
+	})
+}
+
+// paginationParams - разбор limit/offset из query параметров
+func paginationParams(c *gin.Context) (int, int) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
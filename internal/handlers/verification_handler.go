@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// VerificationHandler - обработчики верификации книг: предложения, голосование и очередь модераторов
+type VerificationHandler struct {
+	verificationService *services.VerificationService
+}
+
+// NewVerificationHandler - создание нового VerificationHandler
+func NewVerificationHandler(verificationService *services.VerificationService) *VerificationHandler {
+	return &VerificationHandler{
+		verificationService: verificationService,
+	}
+}
+
+// CreateProposal - предложение исправлений метаданных книги
+// @Summary Предложение исправлений книги
+// @Description Исправления метаданных книги выносятся на голосование. Без changes - подтверждение текущих метаданных.
+// @Description Когда перевес голосов доверенных пользователей достигает порога, исправления применяются
+// @Description и книга становится верифицированной сообществом (verification_type=Community).
+// @Tags verification
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID книги"
+// @Param request body models.CreateProposalRequest true "Исправления и комментарий"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id}/proposals [post]
+func (h *VerificationHandler) CreateProposal(c *gin.Context) {
+	var req models.CreateProposalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	proposal, err := h.verificationService.CreateProposal(c.Request.Context(), middleware.GetCurrentUser(c).UserID, c.Param("id"), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"proposal": proposal,
+	})
+}
+
+// GetBookProposals - предложения исправлений книги
+// @Summary Предложения исправлений книги
+// @Description Предложения от новых к старым с итогами голосования и вердиктом автоматической проверки
+// @Tags verification
+// @Produce json
+// @Param id path string true "ID книги"
+// @Param status query string false "Статус" Enums(pending, needs_review, accepted, rejected)
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/books/{id}/proposals [get]
+func (h *VerificationHandler) GetBookProposals(c *gin.Context) {
+	limit, offset := paginationParams(c)
+
+	proposals, err := h.verificationService.ListBookProposals(c.Request.Context(), c.Param("id"),
+		models.ProposalStatus(c.Query("status")), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"proposals": proposals,
+		"limit":     limit,
+		"offset":    offset,
+	})
+}
+
+// GetProposal - предложение исправлений по ID
+// @Summary Предложение исправлений
+// @Tags verification
+// @Produce json
+// @Param id path string true "ID предложения"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} middleware.ErrorResponse
+// @Router /api/proposals/{id} [get]
+func (h *VerificationHandler) GetProposal(c *gin.Context) {
+	proposal, err := h.verificationService.GetProposal(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"proposal": proposal,
+	})
+}
+
+// VoteProposal - голос за предложение или против него
+// @Summary Голос за предложение
+// @Description Повторный голос заменяет прежний. Порог считается по голосам доверенных пользователей:
+// @Description модераторов, администраторов и пользователей с достаточно старым аккаунтом и рецензиями.
+// @Description Голосовать можно только за предложения в статусе pending и не за свои.
+// @Tags verification
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID предложения"
+// @Param request body models.VoteProposalRequest true "Голос"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/proposals/{id}/votes [post]
+func (h *VerificationHandler) VoteProposal(c *gin.Context) {
+	var req models.VoteProposalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	proposal, err := h.verificationService.Vote(c.Request.Context(), middleware.GetCurrentUser(c).UserID, c.Param("id"), *req.Approve)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"proposal": proposal,
+	})
+}
+
+// GetModerationQueue - очередь предложений для модераторов (требует прав moderator/admin)
+// @Summary Очередь предложений
+// @Description Предложения от давних к новым. По умолчанию needs_review: порог набран, но книгу изменили
+// @Description после предложения или автоматическая проверка его отклонила.
+// @Tags verification
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param status query string false "Статус" Enums(pending, needs_review, accepted, rejected) default(needs_review)
+// @Param limit query int false "Лимит" default(20)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} middleware.ErrorResponse
+// @Failure 403 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/moderation/proposals [get]
+func (h *VerificationHandler) GetModerationQueue(c *gin.Context) {
+	limit, offset := paginationParams(c)
+
+	proposals, err := h.verificationService.Queue(c.Request.Context(), models.ProposalStatus(c.Query("status")), limit, offset)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"proposals": proposals,
+		"limit":     limit,
+		"offset":    offset,
+	})
+}
+
+// AcceptProposal - принятие предложения модератором (требует прав moderator/admin)
+// @Summary Принятие предложения
+// @Description Исправления применяются к текущей версии книги независимо от голосов, книга становится
+// @Description верифицированной сообществом. Доступно для предложений в статусе pending и needs_review.
+// @Tags verification
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID предложения"
+// @Param request body models.ResolveProposalRequest false "Комментарий модератора"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/moderation/proposals/{id}/accept [post]
+func (h *VerificationHandler) AcceptProposal(c *gin.Context) {
+	h.resolveProposal(c, h.verificationService.Accept)
+}
+
+// RejectProposal - отклонение предложения модератором (требует прав moderator/admin)
+// @Summary Отклонение предложения
+// @Description Доступно для предложений в статусе pending и needs_review
+// @Tags verification
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID предложения"
+// @Param request body models.ResolveProposalRequest false "Комментарий модератора"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/moderation/proposals/{id}/reject [post]
+func (h *VerificationHandler) RejectProposal(c *gin.Context) {
+	h.resolveProposal(c, h.verificationService.Reject)
+}
+
+// RunAIVerification - автоматическая проверка книги (требует прав moderator/admin)
+// @Summary Автоматическая проверка книги
+// @Description Запускает настроенную проверку (VERIFICATION_VERIFIER). Если она одобряет книгу с уверенностью
+// @Description не ниже VERIFICATION_AI_MIN_CONFIDENCE и книга еще не верифицирована, книга получает verification_type=AI.
+// @Tags verification
+// @Produce json
+// @Param Authorization header string true "Bearer токен"
+// @Param id path string true "ID книги"
+// @Success 200 {object} map[string]interface{}
+// @Failure 403 {object} middleware.ErrorResponse
+// @Failure 404 {object} middleware.ErrorResponse
+// @Failure 409 {object} middleware.ErrorResponse
+// @Failure 422 {object} middleware.ErrorResponse
+// @Security BearerAuth
+// @Router /api/books/{id}/verification/ai [post]
+func (h *VerificationHandler) RunAIVerification(c *gin.Context) {
+	book, verdict, err := h.verificationService.RunVerifier(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"book":    book.ToResponse(),
+		"verdict": verdict,
+	})
+}
+
+// resolveProposal - решение модератора с необязательным комментарием в теле запроса
+func (h *VerificationHandler) resolveProposal(c *gin.Context, resolve func(ctx context.Context, moderatorID, proposalID string, note *string) (*models.BookProposal, error)) {
+	var req models.ResolveProposalRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(c, apperrors.FromBinding(err))
+		return
+	}
+
+	proposal, err := resolve(c.Request.Context(), middleware.GetCurrentUser(c).UserID, c.Param("id"), req.Note)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"proposal": proposal,
+	})
+}
@@ -0,0 +1,120 @@
+// Package health - проверки готовности сервиса для /readyz: реестр зависимостей
+// (база данных, схема, хранилище и т.д.) и признак остановки на время graceful shutdown.
+package health
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Статусы проверок
+const (
+	StatusOK       = "ok"
+	StatusFail     = "fail"
+	StatusDraining = "draining"
+)
+
+// defaultCheckTimeout - ограничение времени одной проверки
+const defaultCheckTimeout = 2 * time.Second
+
+// Check - проверка зависимости; ошибка означает, что сервис не готов принимать трафик
+type Check func(ctx context.Context) error
+
+// Result - результат одной проверки. Текст ошибки пишется в лог, но не отдается клиенту.
+type Result struct {
+	Name       string  `json:"name" example:"database"`
+	Status     string  `json:"status" example:"ok"`
+	DurationMs float64 `json:"duration_ms" example:"1.2"`
+}
+
+// Report - сводный результат проверок готовности
+type Report struct {
+	Status string   `json:"status" example:"ok"`
+	Checks []Result `json:"checks"`
+}
+
+// Ready - все проверки прошли и сервис не останавливается
+func (r *Report) Ready() bool {
+	return r.Status == StatusOK
+}
+
+// namedCheck - зарегистрированная проверка
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker - реестр проверок готовности
+type Checker struct {
+	mu       sync.RWMutex
+	checks   []namedCheck
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+// NewChecker - создание нового Checker
+func NewChecker() *Checker {
+	return &Checker{timeout: defaultCheckTimeout}
+}
+
+// Register - добавление проверки зависимости под именем name
+func (c *Checker) Register(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// SetDraining - сервис начал останавливаться: /readyz отвечает 503,
+// чтобы балансировщик перестал присылать новые запросы, пока дорабатывают текущие
+func (c *Checker) SetDraining() {
+	c.draining.Store(true)
+}
+
+// Check - параллельный запуск всех проверок, каждая со своим таймаутом
+func (c *Checker) Check(ctx context.Context) *Report {
+	if c.draining.Load() {
+		return &Report{Status: StatusDraining, Checks: []Result{}}
+	}
+
+	c.mu.RLock()
+	checks := append([]namedCheck(nil), c.checks...)
+	c.mu.RUnlock()
+
+	report := &Report{Status: StatusOK, Checks: make([]Result, len(checks))}
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Go(func() {
+			report.Checks[i] = c.run(ctx, check)
+		})
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+// run - выполнение одной проверки с таймаутом
+func (c *Checker) run(ctx context.Context, check namedCheck) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := check.check(ctx)
+	result := Result{
+		Name:       check.name,
+		Status:     StatusOK,
+		DurationMs: float64(time.Since(started).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = StatusFail
+		slog.WarnContext(ctx, "readiness check failed", "check", check.name, "error", err)
+	}
+	return result
+}
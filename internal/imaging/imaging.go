@@ -0,0 +1,128 @@
+// Package imaging - проверка загружаемых изображений и построение уменьшенных копий
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // регистрация декодера GIF
+	"image/jpeg"
+	_ "image/png" // регистрация декодера PNG
+	"net/http"
+
+	"github.com/HugoSmits86/nativewebp"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // регистрация декодера WebP
+)
+
+var (
+	// ErrUnsupportedImage - содержимое не является поддерживаемым изображением
+	ErrUnsupportedImage = errors.New("unsupported image format")
+	// ErrImageTooLarge - слишком большое разрешение изображения
+	ErrImageTooLarge = errors.New("image dimensions are too large")
+)
+
+const (
+	// maxPixels - ограничение разрешения, защищает от "бомб" с огромными размерами
+	maxPixels = 40_000_000
+	// jpegQuality - качество JPEG для уменьшенных копий
+	jpegQuality = 85
+)
+
+// extensions - расширения файлов для поддерживаемых типов
+var extensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+// Size - размер уменьшенной копии.
+// Если заданы обе стороны, изображение обрезается по центру до нужных пропорций,
+// если только ширина - высота считается по пропорциям оригинала.
+type Size struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// Sniff - определение типа изображения по содержимому (расширение и заголовки клиента не учитываются)
+func Sniff(data []byte) (contentType, ext string, err error) {
+	contentType = http.DetectContentType(data)
+	ext, ok := extensions[contentType]
+	if !ok {
+		return "", "", ErrUnsupportedImage
+	}
+	return contentType, ext, nil
+}
+
+// Decode - декодирование изображения с предварительной проверкой разрешения
+func Decode(data []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	return img, nil
+}
+
+// Resize - уменьшенная копия изображения; изображения меньше целевого размера не увеличиваются
+func Resize(src image.Image, size Size) image.Image {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	width, height := size.Width, size.Height
+	if height == 0 {
+		if srcW <= width {
+			return src
+		}
+		height = max(1, srcH*width/srcW)
+	} else {
+		// обрезка по центру до пропорций width:height
+		cropW, cropH := srcW, srcW*height/width
+		if cropH > srcH {
+			cropW, cropH = srcH*width/height, srcH
+		}
+		x0 := bounds.Min.X + (srcW-cropW)/2
+		y0 := bounds.Min.Y + (srcH-cropH)/2
+		bounds = image.Rect(x0, y0, x0+cropW, y0+cropH)
+		if cropW < width {
+			width, height = cropW, cropH
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return dst
+}
+
+// EncodeJPEG - кодирование в JPEG; прозрачные области заливаются белым
+func EncodeJPEG(img image.Image) ([]byte, error) {
+	flat := image.NewRGBA(img.Bounds())
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// EncodeWebP - кодирование в WebP (lossless, сохраняет прозрачность)
+func EncodeWebP(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := nativewebp.Encode(&buf, img, nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,215 @@
+package importer
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// epubContainer - META-INF/container.xml
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// epubPackage - OPF документ с метаданными, манифестом и порядком чтения
+type epubPackage struct {
+	Metadata struct {
+		Titles      []string `xml:"title"`
+		Creators    []string `xml:"creator"`
+		Dates       []string `xml:"date"`
+		Subjects    []string `xml:"subject"`
+		Description string   `xml:"description"`
+		Metas       []struct {
+			Name    string `xml:"name,attr"`
+			Content string `xml:"content,attr"`
+		} `xml:"meta"`
+	} `xml:"metadata"`
+	Manifest []epubItem `xml:"manifest>item"`
+	Spine    []struct {
+		IDRef  string `xml:"idref,attr"`
+		Linear string `xml:"linear,attr"`
+	} `xml:"spine>itemref"`
+}
+
+// epubItem - элемент манифеста EPUB
+type epubItem struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr"`
+}
+
+// parseEPUB - разбор EPUB 2/3: метаданные из OPF, главы по spine
+func parseEPUB(archive *zip.Reader, unpacker *zipUnpacker) (*Result, error) {
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	var container epubContainer
+	if err := decodeZipXML(unpacker, files, "META-INF/container.xml", &container); err != nil {
+		return nil, err
+	}
+	if len(container.Rootfiles) == 0 {
+		return nil, fmt.Errorf("container.xml has no rootfile")
+	}
+
+	opfPath := container.Rootfiles[0].FullPath
+	var pkg epubPackage
+	if err := decodeZipXML(unpacker, files, opfPath, &pkg); err != nil {
+		return nil, err
+	}
+	baseDir := path.Dir(opfPath)
+
+	meta := pkg.Metadata
+	book := &models.Book{
+		Title:       firstNonEmpty(meta.Titles),
+		Author:      strings.Join(trimAll(meta.Creators), ", "),
+		Description: htmlToText(meta.Description),
+		Genres:      trimAll(meta.Subjects),
+	}
+	for _, date := range meta.Dates {
+		if year := parseYear(date); year != nil {
+			book.Year = year
+			break
+		}
+	}
+
+	result := &Result{Format: FormatEPUB, Book: book}
+
+	manifest := make(map[string]epubItem, len(pkg.Manifest))
+	for _, item := range pkg.Manifest {
+		manifest[item.ID] = item
+	}
+
+	result.Cover = epubCover(unpacker, files, baseDir, pkg, manifest)
+
+	for _, ref := range pkg.Spine {
+		if ref.Linear == "no" {
+			continue
+		}
+		item, ok := manifest[ref.IDRef]
+		if !ok || strings.Contains(item.Properties, "nav") {
+			continue
+		}
+
+		f, ok := files[resolveHref(baseDir, item.Href)]
+		if !ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("spine item %s not found in archive", item.Href))
+			continue
+		}
+		data, err := unpacker.read(f)
+		if err != nil {
+			return nil, err
+		}
+
+		doc, err := parseXHTML(data)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("failed to parse %s: %v", item.Href, err))
+			continue
+		}
+		// Обложки и оглавления без текста пропускаются молча
+		if len(doc.Paragraphs) == 0 {
+			continue
+		}
+
+		title := doc.Heading
+		if title == "" {
+			title = doc.Title
+		}
+		result.Parts = append(result.Parts, &models.BookPart{
+			Title:   title,
+			Content: strings.Join(doc.Paragraphs, "\n\n"),
+		})
+	}
+
+	return result, nil
+}
+
+// epubCover - поиск обложки по meta name="cover" (EPUB 2) или properties="cover-image" (EPUB 3)
+func epubCover(unpacker *zipUnpacker, files map[string]*zip.File, baseDir string, pkg epubPackage, manifest map[string]epubItem) *Cover {
+	var item *epubItem
+	for _, m := range pkg.Metadata.Metas {
+		if m.Name == "cover" {
+			if found, ok := manifest[m.Content]; ok {
+				item = &found
+			}
+		}
+	}
+	if item == nil {
+		for i := range pkg.Manifest {
+			if strings.Contains(pkg.Manifest[i].Properties, "cover-image") {
+				item = &pkg.Manifest[i]
+				break
+			}
+		}
+	}
+	if item == nil || !strings.HasPrefix(item.MediaType, "image/") {
+		return nil
+	}
+
+	f, ok := files[resolveHref(baseDir, item.Href)]
+	if !ok {
+		return nil
+	}
+	data, err := unpacker.read(f)
+	if err != nil {
+		return nil
+	}
+	return &Cover{ContentType: item.MediaType, Data: data}
+}
+
+// decodeZipXML - разбор XML файла из архива
+func decodeZipXML(unpacker *zipUnpacker, files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("%s not found in archive", name)
+	}
+	data, err := unpacker.read(f)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return nil
+}
+
+// resolveHref - путь файла в архиве относительно OPF документа
+func resolveHref(baseDir, href string) string {
+	if i := strings.IndexByte(href, '#'); i >= 0 {
+		href = href[:i]
+	}
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+	return path.Join(baseDir, href)
+}
+
+// firstNonEmpty - первое непустое значение
+func firstNonEmpty(values []string) string {
+	for _, v := range values {
+		if v = normalizeSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// trimAll - значения без пробелов по краям и без пустых строк
+func trimAll(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = normalizeSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,301 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"golang.org/x/net/html/charset"
+)
+
+// fb2Genres - названия распространенных жанров FB2 для каталога
+var fb2Genres = map[string]string{
+	"prose_rus_classic":  "Русская классика",
+	"prose_classic":      "Классическая проза",
+	"prose_contemporary": "Современная проза",
+	"prose_history":      "Историческая проза",
+	"prose_military":     "Военная проза",
+	"sf":                 "Фантастика",
+	"sf_fantasy":         "Фэнтези",
+	"sf_social":          "Социальная фантастика",
+	"detective":          "Детектив",
+	"det_classic":        "Классический детектив",
+	"love":               "Любовный роман",
+	"adventure":          "Приключения",
+	"poetry":             "Поэзия",
+	"dramaturgy":         "Драматургия",
+	"humor":              "Юмор",
+	"children":           "Детская литература",
+	"sci_history":        "История",
+	"sci_philosophy":     "Философия",
+	"sci_psychology":     "Психология",
+	"nonfiction":         "Документальная литература",
+}
+
+// fb2Description - метаданные FB2 документа
+type fb2Description struct {
+	TitleInfo struct {
+		Genres  []string `xml:"genre"`
+		Authors []struct {
+			FirstName string `xml:"first-name"`
+			LastName  string `xml:"last-name"`
+			Nickname  string `xml:"nickname"`
+		} `xml:"author"`
+		BookTitle  string `xml:"book-title"`
+		Annotation struct {
+			Paragraphs []string `xml:"p"`
+		} `xml:"annotation"`
+		Keywords  string `xml:"keywords"`
+		Date      string `xml:"date"`
+		Coverpage struct {
+			Images []struct {
+				Href string `xml:"href,attr"`
+			} `xml:"image"`
+		} `xml:"coverpage"`
+	} `xml:"title-info"`
+	SrcTitleInfo struct {
+		BookTitle string `xml:"book-title"`
+	} `xml:"src-title-info"`
+	PublishInfo struct {
+		Year string `xml:"year"`
+	} `xml:"publish-info"`
+}
+
+// fb2Section - раздел тела FB2 с вложенными разделами
+type fb2Section struct {
+	Title      string
+	Paragraphs []string
+	Children   []*fb2Section
+}
+
+// parseFB2 - разбор FictionBook 2: метаданные из description, главы из разделов основного body
+func parseFB2(data []byte) (*Result, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	var (
+		desc     fb2Description
+		bodies   []*fb2Section
+		binaries = make(map[string]*Cover)
+	)
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid FB2 document: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "description":
+			if err := decoder.DecodeElement(&desc, &start); err != nil {
+				return nil, fmt.Errorf("invalid FB2 description: %w", err)
+			}
+		case "body":
+			// Сноски и комментарии хранятся в отдельных body и главами не являются
+			if name := attrValue(start, "name"); name == "notes" || name == "comments" {
+				if err := decoder.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			body, err := readFB2Section(decoder)
+			if err != nil {
+				return nil, fmt.Errorf("invalid FB2 body: %w", err)
+			}
+			bodies = append(bodies, body)
+		case "binary":
+			var content string
+			if err := decoder.DecodeElement(&content, &start); err != nil {
+				return nil, fmt.Errorf("invalid FB2 binary: %w", err)
+			}
+			raw, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(content), ""))
+			if err != nil {
+				continue
+			}
+			binaries[attrValue(start, "id")] = &Cover{ContentType: attrValue(start, "content-type"), Data: raw}
+		}
+	}
+
+	info := desc.TitleInfo
+	book := &models.Book{
+		Title:       normalizeSpace(info.BookTitle),
+		Description: strings.Join(trimAll(info.Annotation.Paragraphs), "\n\n"),
+		Tags:        splitKeywords(info.Keywords),
+	}
+	if original := normalizeSpace(desc.SrcTitleInfo.BookTitle); original != "" && original != book.Title {
+		book.OriginalTitle = &original
+	}
+
+	authors := make([]string, 0, len(info.Authors))
+	for _, a := range info.Authors {
+		name := normalizeSpace(a.FirstName + " " + a.LastName)
+		if name == "" {
+			name = normalizeSpace(a.Nickname)
+		}
+		if name != "" {
+			authors = append(authors, name)
+		}
+	}
+	book.Author = strings.Join(authors, ", ")
+
+	for _, code := range trimAll(info.Genres) {
+		if name, ok := fb2Genres[code]; ok {
+			book.Genres = append(book.Genres, name)
+		} else {
+			book.Genres = append(book.Genres, code)
+		}
+	}
+
+	book.Year = parseYear(info.Date)
+	if book.Year == nil {
+		book.Year = parseYear(desc.PublishInfo.Year)
+	}
+
+	result := &Result{Format: FormatFB2, Book: book}
+
+	if images := info.Coverpage.Images; len(images) > 0 {
+		if cover, ok := binaries[strings.TrimPrefix(images[0].Href, "#")]; ok {
+			result.Cover = cover
+		}
+	}
+
+	if len(bodies) == 0 {
+		return result, nil
+	}
+	for _, section := range bodies[0].Children {
+		result.Parts = append(result.Parts, flattenFB2Section(section, "")...)
+	}
+	// Книга без разделов - один текст целиком
+	if len(result.Parts) == 0 && len(bodies[0].Paragraphs) > 0 {
+		result.Parts = append(result.Parts, &models.BookPart{
+			Title:   book.Title,
+			Content: strings.Join(bodies[0].Paragraphs, "\n\n"),
+		})
+	}
+
+	return result, nil
+}
+
+// readFB2Section - чтение body или section до закрывающего тега
+func readFB2Section(decoder *xml.Decoder) (*fb2Section, error) {
+	section := &fb2Section{}
+	var (
+		text    strings.Builder
+		inTitle bool
+		titles  []string
+	)
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "section":
+				child, err := readFB2Section(decoder)
+				if err != nil {
+					return nil, err
+				}
+				section.Children = append(section.Children, child)
+			case "title":
+				inTitle = true
+			case "image", "binary":
+				if err := decoder.Skip(); err != nil {
+					return nil, err
+				}
+			case "p", "v", "subtitle", "text-author":
+				text.Reset()
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "section", "body":
+				section.Title = strings.Join(titles, ". ")
+				return section, nil
+			case "title":
+				inTitle = false
+			case "p", "v", "subtitle", "text-author":
+				paragraph := normalizeSpace(text.String())
+				text.Reset()
+				if paragraph == "" {
+					continue
+				}
+				if inTitle {
+					titles = append(titles, paragraph)
+				} else {
+					section.Paragraphs = append(section.Paragraphs, paragraph)
+				}
+			}
+		case xml.CharData:
+			text.Write(t)
+		}
+	}
+}
+
+// flattenFB2Section - листовые разделы становятся главами, заголовки родителей
+// добавляются в начало ("Часть первая. Глава I")
+func flattenFB2Section(section *fb2Section, prefix string) []*models.BookPart {
+	title := section.Title
+	if prefix != "" && title != "" {
+		title = prefix + ". " + title
+	} else if title == "" {
+		title = prefix
+	}
+
+	if len(section.Children) == 0 {
+		return []*models.BookPart{{
+			Title:   title,
+			Content: strings.Join(section.Paragraphs, "\n\n"),
+		}}
+	}
+
+	var parts []*models.BookPart
+	// Текст перед вложенными разделами (пролог части) сохраняется отдельной главой
+	if len(section.Paragraphs) > 0 {
+		parts = append(parts, &models.BookPart{
+			Title:   title,
+			Content: strings.Join(section.Paragraphs, "\n\n"),
+		})
+	}
+	for _, child := range section.Children {
+		parts = append(parts, flattenFB2Section(child, title)...)
+	}
+	return parts
+}
+
+// attrValue - значение атрибута по локальному имени
+func attrValue(start xml.StartElement, name string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// splitKeywords - теги из поля keywords, разделенного запятыми
+func splitKeywords(keywords string) []string {
+	var tags []string
+	for _, k := range strings.Split(keywords, ",") {
+		if k = strings.ToLower(normalizeSpace(k)); k != "" {
+			tags = append(tags, k)
+		}
+	}
+	return tags
+}
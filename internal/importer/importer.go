@@ -0,0 +1,224 @@
+// Package importer разбирает файлы EPUB и FB2 в книгу и упорядоченные части.
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// Format - формат импортируемого файла
+type Format string
+
+const (
+	FormatEPUB Format = "epub"
+	FormatFB2  Format = "fb2"
+)
+
+// charsPerPage - условный объем страницы для расчета page_start/page_end
+const charsPerPage = 1800
+
+// Пределы распаковки zip-архива: размер загрузки ограничивает только сжатые данные,
+// а один файл архива может распаковаться в гигабайты (zip-бомба)
+const (
+	maxZipEntrySize = 64 << 20  // один файл архива
+	maxZipTotalSize = 256 << 20 // все прочитанные файлы архива
+)
+
+var (
+	// ErrUnsupportedFormat - файл не является EPUB или FB2
+	ErrUnsupportedFormat = errors.New("unsupported file format: expected .epub, .fb2 or .fb2.zip")
+	// ErrNoChapters - в файле не найдено ни одной главы с текстом
+	ErrNoChapters = errors.New("no chapters with text found")
+	// ErrArchiveTooLarge - файлы архива в распакованном виде превышают допустимый размер
+	ErrArchiveTooLarge = errors.New("archive is too large when unpacked")
+)
+
+// Cover - обложка, извлеченная из файла
+type Cover struct {
+	ContentType string
+	Data        []byte
+}
+
+// Result - результат разбора файла
+type Result struct {
+	Format   Format
+	Book     *models.Book
+	Parts    []*models.BookPart
+	Cover    *Cover
+	Warnings []string
+}
+
+// Parse - разбор EPUB или FB2 (в том числе FB2 в zip-архиве).
+// Формат определяется по содержимому, имя файла используется как подсказка.
+func Parse(filename string, data []byte) (*Result, error) {
+	var (
+		result *Result
+		err    error
+	)
+
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		result, err = parseZip(data)
+	case looksLikeFB2(data):
+		result, err = parseFB2(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	if err := result.finalize(); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	return result, nil
+}
+
+// parseZip - EPUB или zip-архив с одним FB2 файлом
+func parseZip(data []byte) (*Result, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+
+	unpacker := &zipUnpacker{remaining: maxZipTotalSize}
+	for _, f := range archive.File {
+		if f.Name == "META-INF/container.xml" {
+			return parseEPUB(archive, unpacker)
+		}
+	}
+
+	for _, f := range archive.File {
+		if strings.EqualFold(path.Ext(f.Name), ".fb2") {
+			content, err := unpacker.read(f)
+			if err != nil {
+				return nil, err
+			}
+			return parseFB2(content)
+		}
+	}
+
+	return nil, ErrUnsupportedFormat
+}
+
+// looksLikeFB2 - проверка наличия корневого элемента FictionBook в начале файла
+func looksLikeFB2(data []byte) bool {
+	head := data
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	return bytes.Contains(head, []byte("<FictionBook"))
+}
+
+// finalize - нумерация частей, расчет страниц и значения по умолчанию
+func (r *Result) finalize() error {
+	parts := r.Parts[:0]
+	for _, part := range r.Parts {
+		if strings.TrimSpace(part.Content) == "" {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("skipped empty chapter %q", part.Title))
+			continue
+		}
+		parts = append(parts, part)
+	}
+	r.Parts = parts
+
+	if len(r.Parts) == 0 {
+		return ErrNoChapters
+	}
+
+	page := 1
+	for i, part := range r.Parts {
+		part.OrderNum = i + 1
+		if part.Title == "" {
+			part.Title = fmt.Sprintf("Глава %d", i+1)
+		}
+		if part.MoodTags == nil {
+			part.MoodTags = []string{}
+		}
+
+		pages := (utf8.RuneCountInString(part.Content) + charsPerPage - 1) / charsPerPage
+		if pages < 1 {
+			pages = 1
+		}
+		start, end := page, page+pages-1
+		part.PageStart, part.PageEnd = &start, &end
+		page = end + 1
+	}
+
+	book := r.Book
+	book.PagesCount = page - 1
+	book.CreatedAt = time.Now()
+	if book.Title == "" {
+		book.Title = r.Parts[0].Title
+		r.Warnings = append(r.Warnings, "book title not found, using first chapter title")
+	}
+	if book.Author == "" {
+		book.Author = "Неизвестный автор"
+		r.Warnings = append(r.Warnings, "author not found")
+	}
+	if book.Description == "" {
+		r.Warnings = append(r.Warnings, "description not found")
+	}
+	if book.Genres == nil {
+		book.Genres = []string{}
+	}
+	if book.Tags == nil {
+		book.Tags = []string{}
+	}
+	return nil
+}
+
+// zipUnpacker - чтение файлов zip-архива с ограничением распакованного размера
+type zipUnpacker struct {
+	remaining int64 // сколько байт еще можно распаковать из архива
+}
+
+// read - чтение файла из архива не больше maxZipEntrySize и оставшегося лимита архива.
+// Размер из заголовка проверяется заранее, но читается не больше лимита, так как заголовок может врать.
+func (u *zipUnpacker) read(f *zip.File) ([]byte, error) {
+	limit := min(int64(maxZipEntrySize), u.remaining)
+	if f.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("%s: %w", f.Name, ErrArchiveTooLarge)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s: %w", f.Name, ErrArchiveTooLarge)
+	}
+	u.remaining -= int64(len(data))
+	return data, nil
+}
+
+// parseYear - первые четыре цифры даты как год
+func parseYear(value string) *int {
+	value = strings.TrimSpace(value)
+	if len(value) < 4 {
+		return nil
+	}
+	year := 0
+	for _, ch := range value[:4] {
+		if ch < '0' || ch > '9' {
+			return nil
+		}
+		year = year*10 + int(ch-'0')
+	}
+	return &year
+}
@@ -0,0 +1,137 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// blockElements - элементы XHTML, которые начинают новый абзац
+var blockElements = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "blockquote": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"tr": true, "pre": true, "section": true,
+}
+
+// skippedElements - элементы XHTML, текст которых не входит в содержимое
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "head": true,
+}
+
+// xhtmlDocument - текст XHTML документа главы
+type xhtmlDocument struct {
+	Title      string
+	Heading    string
+	Paragraphs []string
+}
+
+// parseXHTML - извлечение заголовка и абзацев из XHTML.
+// Разбор нестрогий: EPUB нередко содержит HTML-сущности и незакрытые теги.
+func parseXHTML(data []byte) (*xhtmlDocument, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	doc := &xhtmlDocument{}
+	var (
+		paragraph strings.Builder
+		heading   strings.Builder
+		title     strings.Builder
+		skipDepth int
+		inHeading bool
+		inTitle   bool
+	)
+
+	flush := func() {
+		if text := normalizeSpace(paragraph.String()); text != "" {
+			doc.Paragraphs = append(doc.Paragraphs, text)
+		}
+		paragraph.Reset()
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			if name == "title" && skipDepth > 0 {
+				inTitle = true
+			}
+			if skippedElements[name] {
+				skipDepth++
+			}
+			if blockElements[name] {
+				flush()
+			}
+			if isHeading(name) && doc.Heading == "" {
+				inHeading = true
+			}
+		case xml.EndElement:
+			name := strings.ToLower(t.Name.Local)
+			if skippedElements[name] && skipDepth > 0 {
+				skipDepth--
+			}
+			if name == "title" {
+				inTitle = false
+				doc.Title = normalizeSpace(title.String())
+			}
+			if isHeading(name) && inHeading {
+				inHeading = false
+				doc.Heading = normalizeSpace(heading.String())
+			}
+			if blockElements[name] {
+				flush()
+			}
+		case xml.CharData:
+			if inTitle {
+				title.Write(t)
+			}
+			if skipDepth > 0 {
+				continue
+			}
+			if inHeading {
+				heading.Write(t)
+				heading.WriteByte(' ')
+			}
+			paragraph.Write(t)
+		}
+	}
+	flush()
+
+	// Заголовок главы не дублируется в тексте
+	if doc.Heading != "" && len(doc.Paragraphs) > 0 && doc.Paragraphs[0] == doc.Heading {
+		doc.Paragraphs = doc.Paragraphs[1:]
+	}
+	return doc, nil
+}
+
+// htmlToText - текст из HTML-фрагмента (например, описания книги)
+func htmlToText(fragment string) string {
+	if !strings.Contains(fragment, "<") {
+		return normalizeSpace(fragment)
+	}
+	doc, err := parseXHTML([]byte("<div>" + fragment + "</div>"))
+	if err != nil {
+		return normalizeSpace(fragment)
+	}
+	return strings.Join(doc.Paragraphs, "\n\n")
+}
+
+// normalizeSpace - схлопывание пробельных символов
+func normalizeSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// isHeading - проверка, что элемент является заголовком h1-h6
+func isHeading(name string) bool {
+	return len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6'
+}
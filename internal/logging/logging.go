@@ -0,0 +1,101 @@
+// Package logging - структурированное логирование на log/slog: уровень и формат из конфигурации,
+// маскирование секретов и идентификатор запроса из context.Context в каждой записи.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Форматы вывода
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options - параметры логгера
+type Options struct {
+	// Level - минимальный уровень: debug, info, warn, error
+	Level string
+	// Format - text или json
+	Format string
+}
+
+// New - логгер с маскированием секретов, пишущий в w
+func New(w io.Writer, opts Options) (*slog.Logger, error) {
+	var level slog.Level
+	if opts.Level != "" {
+		if err := level.UnmarshalText([]byte(opts.Level)); err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", opts.Level, err)
+		}
+	}
+
+	handlerOpts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	}
+
+	var handler slog.Handler
+	switch strings.ToLower(opts.Format) {
+	case "", FormatText:
+		handler = slog.NewTextHandler(w, handlerOpts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	default:
+		return nil, fmt.Errorf("invalid log format %q: expected text or json", opts.Format)
+	}
+
+	return slog.New(contextHandler{handler}), nil
+}
+
+// Setup - создание логгера и установка его по умолчанию для slog и стандартного log
+func Setup(w io.Writer, opts Options) (*slog.Logger, error) {
+	logger, err := New(w, opts)
+	if err != nil {
+		return nil, err
+	}
+	slog.SetDefault(logger)
+	return logger, nil
+}
+
+// requestIDKey - ключ идентификатора запроса в context.Context
+type requestIDKey struct{}
+
+// WithRequestID - контекст с идентификатором запроса; он попадает во все записи, сделанные с этим контекстом
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID - идентификатор запроса из контекста (пустая строка, если его нет)
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// contextHandler - добавление request_id и trace_id из контекста к каждой записи
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		r.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,120 @@
+// Package metrics - метрики Prometheus: HTTP запросы, пул соединений pgx,
+// ошибки аутентификации и доменные счетчики.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace - префикс имен всех метрик приложения
+const namespace = "bookvision"
+
+// Registry - реестр метрик приложения (вместо глобального prometheus.DefaultRegisterer,
+// чтобы в /metrics попадало только то, что зарегистрировано явно)
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequests - количество обработанных запросов по шаблону маршрута и статусу
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "HTTP requests processed, by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPDuration - длительность обработки запросов
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency, by method, route template and status code.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"method", "route", "status"})
+
+	// HTTPInFlight - запросы, обрабатываемые в данный момент
+	HTTPInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "HTTP requests currently being served.",
+	})
+
+	// AuthFailures - отказы в аутентификации и авторизации по причине
+	AuthFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "failures_total",
+		Help:      "Rejected authentication and authorization attempts, by reason.",
+	}, []string{"reason"})
+
+	// SessionsStarted - выданные токены доступа (вход и регистрация)
+	SessionsStarted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "sessions_started_total",
+		Help:      "Sessions started (access tokens issued), by method.",
+	}, []string{"method"})
+
+	// BooksCreated - созданные книги по источнику (api, import)
+	BooksCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "books_created_total",
+		Help:      "Books added to the catalog, by source.",
+	}, []string{"source"})
+
+	// ReviewsPosted - опубликованные отзывы
+	ReviewsPosted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reviews_posted_total",
+		Help:      "Reviews posted.",
+	})
+
+	// RateLimited - запросы, отклоненные ограничителем частоты, по политике
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_total",
+		Help:      "Requests rejected by the rate limiter, by policy.",
+	}, []string{"policy"})
+
+	// CacheRequests - обращения к кэшам в памяти процесса по кэшу и результату (hit, miss)
+	CacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "In-process cache lookups, by cache and result.",
+	}, []string{"cache", "result"})
+)
+
+// Причины отказа для AuthFailures
+const (
+	AuthMissingToken       = "missing_token"
+	AuthInvalidToken       = "invalid_token"
+	AuthInvalidCredentials = "invalid_credentials"
+	AuthForbidden          = "forbidden"
+	AuthInactiveAccount    = "inactive_account"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPDuration,
+		HTTPInFlight,
+		AuthFailures,
+		SessionsStarted,
+		BooksCreated,
+		ReviewsPosted,
+		RateLimited,
+		CacheRequests,
+	)
+}
+
+// Handler - HTTP обработчик, отдающий метрики из Registry в формате Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
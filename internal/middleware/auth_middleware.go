@@ -1,11 +1,12 @@
 package middleware
 
 import (
-	"fmt"
-	"net/http"
+	"errors"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/metrics"
 	"github.com/tukembaev/bookVisionGo/internal/models"
 	"github.com/tukembaev/bookVisionGo/internal/services"
 	"github.com/tukembaev/bookVisionGo/internal/utils"
@@ -18,17 +19,22 @@ func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 		authHeader := c.GetHeader("Authorization")
 		tokenString, err := utils.ExtractTokenFromHeader(authHeader)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
-			c.Abort()
+			metrics.AuthFailures.WithLabelValues(metrics.AuthMissingToken).Inc()
+			abortWithError(c, apperrors.Wrap(apperrors.CodeUnauthorized, err.Error(), err))
 			return
 		}
 
-		// Валидация токена
-		fmt.Println("AuthHeader:", authHeader)
-		claims, err := authService.ValidateToken(tokenString)
+		// Валидация токена и аккаунта: удаленный или заблокированный пользователь отклоняется,
+		// роль берется из базы данных
+		claims, err := authService.ValidateToken(c.Request.Context(), tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
+			switch {
+			case errors.Is(err, services.ErrAccountDeleted), errors.Is(err, services.ErrAccountSuspended):
+				metrics.AuthFailures.WithLabelValues(metrics.AuthInactiveAccount).Inc()
+			case errors.Is(err, apperrors.CodeUnauthorized):
+				metrics.AuthFailures.WithLabelValues(metrics.AuthInvalidToken).Inc()
+			}
+			abortWithError(c, err)
 			return
 		}
 
@@ -36,6 +42,13 @@ func AuthMiddleware(authService *services.AuthService) gin.HandlerFunc {
 		c.Set("user_id", claims.UserID)
 		c.Set("username", claims.Username)
 		c.Set("user_role", claims.Role)
+		// Исполнитель для журнала аудита доступен сервисам через context.Context
+		c.Request = c.Request.WithContext(services.WithAuditActor(c.Request.Context(), &models.AuditActor{
+			UserID:   claims.UserID,
+			Username: claims.Username,
+			Role:     claims.Role,
+			IP:       c.ClientIP(),
+		}))
 
 		c.Next()
 	}
@@ -46,22 +59,20 @@ func RequireRole(requiredRole models.UserRole) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userRole, exists := c.Get("user_role")
 		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User role not found"})
-			c.Abort()
+			abortWithError(c, apperrors.Unauthorized("user not authenticated"))
 			return
 		}
 
 		role, ok := userRole.(models.UserRole)
 		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user role type"})
-			c.Abort()
+			abortWithError(c, errors.New("invalid user role type in context"))
 			return
 		}
 
 		// Проверка роли
 		if !hasRequiredRole(role, requiredRole) {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
-			c.Abort()
+			metrics.AuthFailures.WithLabelValues(metrics.AuthForbidden).Inc()
+			abortWithError(c, apperrors.Forbidden("insufficient permissions"))
 			return
 		}
 
@@ -85,7 +96,7 @@ func OptionalAuth(authService *services.AuthService) gin.HandlerFunc {
 		}
 
 		tokenString := authHeader[len(bearerPrefix):]
-		claims, err := authService.ValidateToken(tokenString)
+		claims, err := authService.ValidateToken(c.Request.Context(), tokenString)
 		if err != nil {
 			c.Next()
 			return
@@ -134,3 +145,11 @@ func hasRequiredRole(userRole, requiredRole models.UserRole) bool {
 	// Обычный пользователь имеет доступ только к пользовательским функциям
 	return userRole == requiredRole && requiredRole == models.UserRoleUser
 }
+
+// GetOptionalUser - получение текущего пользователя, если запрос аутентифицирован
+func GetOptionalUser(c *gin.Context) *utils.Claims {
+	if !IsAuthenticated(c) {
+		return nil
+	}
+	return GetCurrentUser(c)
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExtendDeadline - middleware, заменяющий таймауты чтения и записи http.Server для маршрута.
+// Нужен загрузкам файлов: тело в десятки мегабайт не успевает прийти за общий read_timeout.
+// 0 снимает ограничение.
+func ExtendDeadline(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var deadline time.Time
+		if timeout > 0 {
+			deadline = time.Now().Add(timeout)
+		}
+
+		rc := http.NewResponseController(c.Writer)
+		if err := rc.SetReadDeadline(deadline); err != nil {
+			slog.WarnContext(c.Request.Context(), "failed to extend read deadline", "error", err)
+		}
+		if err := rc.SetWriteDeadline(deadline); err != nil {
+			slog.WarnContext(c.Request.Context(), "failed to extend write deadline", "error", err)
+		}
+
+		c.Next()
+	}
+}
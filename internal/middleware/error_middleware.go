@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+)
+
+// ErrorBody - тело ответа с ошибкой
+type ErrorBody struct {
+	Code      apperrors.Code         `json:"code" example:"not_found"`
+	Message   string                 `json:"message" example:"book not found"`
+	Details   []apperrors.FieldError `json:"details,omitempty"`
+	RequestID string                 `json:"request_id,omitempty" example:"3f0c9a4e-1b7d-4a53-9f0e-2d6b8c1e5a71"`
+	// Current - актуальное состояние ресурса при конфликте версий (409)
+	Current any `json:"current,omitempty"`
+}
+
+// ErrorResponse - единый формат ответа с ошибкой
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorHandler - middleware, превращающий ошибки из c.Error в единый JSON ответ.
+// Статус определяется кодом доменной ошибки; ошибки без кода отдаются как 500
+// без подробностей, а исходная ошибка пишется в лог.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		appErr := apperrors.As(err)
+		if appErr.Code == apperrors.CodeInternal {
+			slog.ErrorContext(c.Request.Context(), "request failed",
+				"method", c.Request.Method, "path", c.Request.URL.Path, "error", err)
+		}
+
+		c.JSON(appErr.Code.Status(), ErrorResponse{Error: ErrorBody{
+			Code:      appErr.Code,
+			Message:   appErr.Message,
+			Details:   appErr.Details,
+			RequestID: GetRequestID(c),
+			Current:   appErr.Current,
+		}})
+	}
+}
+
+// abortWithError - передача ошибки в ErrorHandler и остановка цепочки обработчиков
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
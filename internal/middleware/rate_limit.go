@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/config"
+	"github.com/tukembaev/bookVisionGo/internal/metrics"
+	"github.com/tukembaev/bookVisionGo/internal/ratelimit"
+	"github.com/tukembaev/bookVisionGo/internal/utils"
+)
+
+// Ключи клиента для ограничения частоты
+const (
+	RateLimitKeyIP    = "ip"
+	RateLimitKeyUser  = "user"
+	RateLimitKeyToken = "token"
+)
+
+// RateLimitHeaders - заголовки ответа ограничителя (для CORS ExposeHeaders)
+var RateLimitHeaders = []string{"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After"}
+
+// errRateLimited - клиент исчерпал лимит запросов
+var errRateLimited = apperrors.New(apperrors.CodeRateLimited, "too many requests, retry later")
+
+// RateLimits - middleware ограничения частоты для групп маршрутов
+type RateLimits struct {
+	Default gin.HandlerFunc
+	Auth    gin.HandlerFunc
+	Write   gin.HandlerFunc
+	Search  gin.HandlerFunc
+
+	// idle - после такого простоя любая корзина уже полная и ее можно удалить
+	idle time.Duration
+}
+
+// NewRateLimits - middleware политик из конфигурации.
+// При выключенном ограничении все middleware пропускают запросы без изменений.
+func NewRateLimits(cfg config.RateLimitConfig, store ratelimit.Store) *RateLimits {
+	limits := &RateLimits{}
+	build := func(name string, policy config.RateLimitPolicy) gin.HandlerFunc {
+		if !cfg.Enabled {
+			return func(c *gin.Context) { c.Next() }
+		}
+		p := ratelimit.Policy{Name: name, RequestsPerMinute: policy.RequestsPerMinute, Burst: policy.Burst}
+		limits.idle = max(limits.idle, p.FillTime())
+		return RateLimit(store, p, policy.Key)
+	}
+
+	limits.Default = build("default", cfg.Default)
+	limits.Auth = build("auth", cfg.Auth)
+	limits.Write = build("write", cfg.Write)
+	limits.Search = build("search", cfg.Search)
+	return limits
+}
+
+// IdleTimeout - через сколько простоя корзины можно удалять (ratelimit.RunJanitor)
+func (l *RateLimits) IdleTimeout() time.Duration {
+	return l.idle
+}
+
+// RateLimit - middleware token bucket: один запрос - один токен из корзины клиента.
+// Ключи user и token требуют AuthMiddleware раньше в цепочке; для анонимных запросов используется IP.
+// Ответ содержит заголовки RateLimit-*; отказ - 429 с Retry-After.
+// Если хранилище недоступно, запрос пропускается (fail open) с предупреждением в логе.
+func RateLimit(store ratelimit.Store, policy ratelimit.Policy, keyBy string) gin.HandlerFunc {
+	window := ceilSeconds(policy.FillTime())
+
+	return func(c *gin.Context) {
+		result, err := store.Take(c.Request.Context(), rateLimitKey(c, keyBy), policy)
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "rate limiter unavailable, request allowed", "policy", policy.Name, "error", err)
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+		header.Set("RateLimit-Policy", strconv.Itoa(policy.Burst)+";w="+strconv.Itoa(window))
+
+		if !result.Allowed {
+			header.Set("Retry-After", strconv.Itoa(max(ceilSeconds(result.RetryAfter), 1)))
+			metrics.RateLimited.WithLabelValues(policy.Name).Inc()
+			abortWithError(c, errRateLimited)
+			return
+		}
+		c.Next()
+	}
+}
+
+// rateLimitKey - идентификатор клиента: IP, ID пользователя или хеш API токена.
+// Токен учитывается только после проверки AuthMiddleware, иначе подделанные токены
+// давали бы новые корзины.
+func rateLimitKey(c *gin.Context, keyBy string) string {
+	userID := c.GetString("user_id")
+	switch {
+	case keyBy == RateLimitKeyUser && userID != "":
+		return "user:" + userID
+	case keyBy == RateLimitKeyToken && userID != "":
+		if token, err := utils.ExtractTokenFromHeader(c.GetHeader("Authorization")); err == nil {
+			sum := sha256.Sum256([]byte(token))
+			return "token:" + hex.EncodeToString(sum[:16])
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds - длительность в целых секундах с округлением вверх
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/tukembaev/bookVisionGo/internal/logging"
+)
+
+// RequestIDHeader - заголовок с идентификатором запроса
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey - ключ идентификатора запроса в gin.Context
+const requestIDKey = "request_id"
+
+// maxRequestIDLength - ограничение длины идентификатора, пришедшего от клиента
+const maxRequestIDLength = 128
+
+// RequestID - middleware, присваивающий запросу идентификатор.
+// Идентификатор клиента из X-Request-ID используется, если он задан, иначе генерируется UUID.
+// Идентификатор кладется и в context.Context запроса, поэтому попадает в логи сервисов и репозиториев.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = uuid.NewString()
+		}
+
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+
+		c.Next()
+	}
+}
+
+// GetRequestID - идентификатор текущего запроса
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
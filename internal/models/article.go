@@ -110,19 +110,23 @@ type Article struct {
 	NoSpoilers          bool              `json:"no_spoilers" db:"no_spoilers"`
 	ShouldReadReadiness ArticleReadiness  `json:"should_read_readiness" db:"readiness"`
 	Content             interface{}       `json:"content" db:"content"`
+	UpdatedAt           time.Time         `json:"updated_at" db:"updated_at"`
+	Version             int               `json:"version" db:"version"`
 }
 
 // ArticleListItem - сокращенная модель статьи для списков
 type ArticleListItem struct {
-	ID       string      `json:"id" db:"id"`
-	Title    string      `json:"title" db:"title"`
-	Type     ArticleType `json:"type" db:"type"`
-	AuthorID *string     `json:"author_id" db:"author_id"`
-	BookID   *string     `json:"book_id" db:"book_id"`
-	Excerpt  string      `json:"excerpt" db:"excerpt"`
-	Likes    int         `json:"likes" db:"likes"`
-	Views    int         `json:"views" db:"views"`
-	CoverURL *string     `json:"cover_url" db:"cover_url"`
+	ID        string      `json:"id" db:"id"`
+	Title     string      `json:"title" db:"title"`
+	Type      ArticleType `json:"type" db:"type"`
+	AuthorID  *string     `json:"author_id" db:"author_id"`
+	BookID    *string     `json:"book_id" db:"book_id"`
+	Excerpt   string      `json:"excerpt" db:"excerpt"`
+	Likes     int         `json:"likes" db:"likes"`
+	Views     int         `json:"views" db:"views"`
+	CoverURL  *string     `json:"cover_url" db:"cover_url"`
+	UpdatedAt time.Time   `json:"updated_at" db:"updated_at"`
+	Version   int         `json:"version" db:"version"`
 }
 
 // ArticleContentBlock - модель контент-блока статьи
@@ -166,6 +170,22 @@ type UpdateArticleRequest struct {
 	VerificationType    *VerificationType `json:"verification_type"`
 	NoSpoilers          *bool             `json:"no_spoilers"`
 	ShouldReadReadiness *ArticleReadiness `json:"should_read_readiness"`
+	// Version - версия статьи, с которой начата правка (если не передан заголовок If-Match)
+	Version *int `json:"version"`
+}
+
+// ArticleEdit - редактируемые поля статьи: документ, к которому применяется JSON Merge Patch
+type ArticleEdit struct {
+	Title               string            `json:"title" binding:"required,max=255"`
+	Type                ArticleType       `json:"type" binding:"required"`
+	Excerpt             string            `json:"excerpt" binding:"required"`
+	ReadingMinutes      *int              `json:"reading_minutes"`
+	CoverURL            *string           `json:"cover_url"`
+	Verified            bool              `json:"verified"`
+	VerificationType    *VerificationType `json:"verification_type"`
+	NoSpoilers          bool              `json:"no_spoilers"`
+	ShouldReadReadiness ArticleReadiness  `json:"should_read_readiness"`
+	Version             *int              `json:"version,omitempty"`
 }
 
 // ArticleResponse - DTO для ответа API
@@ -185,6 +205,7 @@ type ArticleResponse struct {
 	VerificationType    *VerificationType `json:"verification_type"`
 	NoSpoilers          bool              `json:"no_spoilers"`
 	ShouldReadReadiness ArticleReadiness  `json:"should_read_readiness"`
+	Version             int               `json:"version"`
 }
 
 // ArticleContentBlockResponse - DTO для ответа API контент-блока
@@ -215,6 +236,7 @@ func (a *Article) ToResponse() *ArticleResponse {
 		VerificationType:    a.VerificationType,
 		NoSpoilers:          a.NoSpoilers,
 		ShouldReadReadiness: a.ShouldReadReadiness,
+		Version:             a.Version,
 	}
 }
 
@@ -229,3 +251,31 @@ func (acb *ArticleContentBlock) ToResponse() *ArticleContentBlockResponse {
 		BlockID:   acb.BlockID,
 	}
 }
+
+// Edit - редактируемые поля статьи
+func (a *Article) Edit() *ArticleEdit {
+	return &ArticleEdit{
+		Title:               a.Title,
+		Type:                a.Type,
+		Excerpt:             a.Excerpt,
+		ReadingMinutes:      a.ReadingMinutes,
+		CoverURL:            a.CoverURL,
+		Verified:            a.Verified,
+		VerificationType:    a.VerificationType,
+		NoSpoilers:          a.NoSpoilers,
+		ShouldReadReadiness: a.ShouldReadReadiness,
+	}
+}
+
+// ApplyEdit - замена редактируемых полей статьи
+func (a *Article) ApplyEdit(e *ArticleEdit) {
+	a.Title = e.Title
+	a.Type = e.Type
+	a.Excerpt = e.Excerpt
+	a.ReadingMinutes = e.ReadingMinutes
+	a.CoverURL = e.CoverURL
+	a.Verified = e.Verified
+	a.VerificationType = e.VerificationType
+	a.NoSpoilers = e.NoSpoilers
+	a.ShouldReadReadiness = e.ShouldReadReadiness
+}
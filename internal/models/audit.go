@@ -0,0 +1,88 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditAction - действие модератора или администратора в журнале аудита
+type AuditAction string
+
+const (
+	AuditBookCreate            AuditAction = "book.create"
+	AuditBookImport            AuditAction = "book.import"
+	AuditBookUpdate            AuditAction = "book.update"
+	AuditBookRevert            AuditAction = "book.revert"
+	AuditBookCover             AuditAction = "book.cover"
+	AuditBookDelete            AuditAction = "book.delete"
+	AuditBookVerify            AuditAction = "book.verify"
+	AuditPartUpdate            AuditAction = "book_part.update"
+	AuditPartRevert            AuditAction = "book_part.revert"
+	AuditArticleCreate         AuditAction = "article.create"
+	AuditArticleUpdate         AuditAction = "article.update"
+	AuditArticleCover          AuditAction = "article.cover"
+	AuditArticleDelete         AuditAction = "article.delete"
+	AuditCharacterUpdate       AuditAction = "character_profile.update"
+	AuditCharacterRevert       AuditAction = "character_profile.revert"
+	AuditCharacterIllustration AuditAction = "character_profile.illustration"
+	AuditUserRole              AuditAction = "user.role"
+	AuditUserDelete            AuditAction = "user.delete"
+	AuditUserStrike            AuditAction = "user.strike"
+	AuditUserSuspend           AuditAction = "user.suspend"
+	AuditUserUnsuspend         AuditAction = "user.unsuspend"
+	AuditTrashRestore          AuditAction = "trash.restore"
+	AuditTrashPurge            AuditAction = "trash.purge"
+	AuditProposalAccept        AuditAction = "book_proposal.accept"
+	AuditProposalReject        AuditAction = "book_proposal.reject"
+	AuditReportResolve         AuditAction = "content_report.resolve"
+	AuditReportDismiss         AuditAction = "content_report.dismiss"
+)
+
+// AuditTarget - тип сущности, над которой выполнено действие
+type AuditTarget string
+
+const (
+	AuditTargetBook             AuditTarget = "book"
+	AuditTargetBookPart         AuditTarget = "book_part"
+	AuditTargetBookProposal     AuditTarget = "book_proposal"
+	AuditTargetArticle          AuditTarget = "article"
+	AuditTargetCharacterProfile AuditTarget = "character_profile"
+	AuditTargetComment          AuditTarget = "comment"
+	AuditTargetContentReport    AuditTarget = "content_report"
+	AuditTargetUser             AuditTarget = "user"
+)
+
+// AuditActor - кто выполняет запрос: пользователь из токена и адрес клиента
+type AuditActor struct {
+	UserID   string
+	Username string
+	Role     UserRole
+	IP       string
+}
+
+// AuditEntry - запись журнала аудита
+type AuditEntry struct {
+	ID            int64       `json:"id"`
+	ActorID       *string     `json:"actor_id"`
+	ActorUsername *string     `json:"actor_username"`
+	ActorRole     *string     `json:"actor_role"`
+	Action        AuditAction `json:"action"`
+	TargetType    AuditTarget `json:"target_type"`
+	TargetID      string      `json:"target_id"`
+	// Before и After - состояние сущности до и после действия (null для создания и удаления соответственно)
+	Before    json.RawMessage `json:"before" swaggertype:"object"`
+	After     json.RawMessage `json:"after" swaggertype:"object"`
+	IP        *string         `json:"ip"`
+	RequestID *string         `json:"request_id"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// AuditFilter - фильтры журнала аудита (пустое поле не ограничивает выборку)
+type AuditFilter struct {
+	ActorID    *string    `form:"actor_id" binding:"omitempty,uuid"`
+	Action     *string    `form:"action"`
+	TargetType *string    `form:"target_type"`
+	TargetID   *string    `form:"target_id"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
@@ -77,6 +77,8 @@ type Book struct {
 	VerificationType *VerificationType `json:"verification_type" db:"verification_type"`
 	CreatedBy        *string           `json:"created_by" db:"created_by"`
 	CreatedAt        time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at" db:"updated_at"`
+	Version          int               `json:"version" db:"version"`
 	Tags             []string          `json:"tags" db:"tags"`
 	AverageRating    float64           `json:"average_rating" db:"average_rating"`
 	RatingCount      int               `json:"rating_count" db:"rating_count"`
@@ -84,15 +86,17 @@ type Book struct {
 
 // BookPart - модель части/главы книги
 type BookPart struct {
-	ID            string   `json:"id" db:"id"`
-	BookID        string   `json:"book_id" db:"book_id"`
-	Title         string   `json:"title" db:"title"`
-	Content       string   `json:"content" db:"content"`
-	OrderNum      int      `json:"order_num" db:"order_num"`
-	PageStart     *int     `json:"page_start" db:"page_start"`
-	PageEnd       *int     `json:"page_end" db:"page_end"`
-	MoodTags      []string `json:"mood_tags" db:"mood_tags"`
-	AverageRating *float64 `json:"average_rating" db:"average_rating"`
+	ID            string    `json:"id" db:"id"`
+	BookID        string    `json:"book_id" db:"book_id"`
+	Title         string    `json:"title" db:"title"`
+	Content       string    `json:"content" db:"content"`
+	OrderNum      int       `json:"order_num" db:"order_num"`
+	PageStart     *int      `json:"page_start" db:"page_start"`
+	PageEnd       *int      `json:"page_end" db:"page_end"`
+	MoodTags      []string  `json:"mood_tags" db:"mood_tags"`
+	AverageRating *float64  `json:"average_rating" db:"average_rating"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+	Version       int       `json:"version" db:"version"`
 }
 
 // CreateBookRequest - DTO для создания книги
@@ -112,19 +116,46 @@ type CreateBookRequest struct {
 
 // UpdateBookRequest - DTO для обновления книги
 type UpdateBookRequest struct {
-	Title            *string           `json:"title"`
-	OriginalTitle    *string           `json:"original_title"`
-	Author           *string           `json:"author"`
-	Year             *int              `json:"year"`
-	Genres           []string          `json:"genres"`
-	AgeRating        *AgeRating        `json:"age_rating"`
-	AuthorCountry    *string           `json:"author_country"`
-	Description      *string           `json:"description"`
-	CoverURL         *string           `json:"cover_url"`
-	PagesCount       *int              `json:"pages_count"`
-	Tags             []string          `json:"tags"`
-	Verified         *bool             `json:"verified"`
-	VerificationType *VerificationType `json:"verification_type"`
+	Title         *string    `json:"title"`
+	OriginalTitle *string    `json:"original_title"`
+	Author        *string    `json:"author"`
+	Year          *int       `json:"year"`
+	Genres        []string   `json:"genres"`
+	AgeRating     *AgeRating `json:"age_rating"`
+	AuthorCountry *string    `json:"author_country"`
+	Description   *string    `json:"description"`
+	CoverURL      *string    `json:"cover_url"`
+	PagesCount    *int       `json:"pages_count"`
+	Tags          []string   `json:"tags"`
+	EditMeta
+}
+
+// BookEdit - редактируемые поля книги: документ, к которому применяется JSON Merge Patch.
+// Признак верификации сюда не входит: он меняется только через проверку (VerificationService).
+type BookEdit struct {
+	Title         string     `json:"title" binding:"required,max=255"`
+	OriginalTitle *string    `json:"original_title"`
+	Author        string     `json:"author" binding:"required,max=255"`
+	Year          *int       `json:"year"`
+	Genres        []string   `json:"genres"`
+	AgeRating     *AgeRating `json:"age_rating"`
+	AuthorCountry *string    `json:"author_country"`
+	Description   string     `json:"description" binding:"required"`
+	CoverURL      *string    `json:"cover_url"`
+	PagesCount    int        `json:"pages_count" binding:"required,min=1"`
+	Tags          []string   `json:"tags"`
+	EditMeta
+}
+
+// BookPartEdit - редактируемые поля части книги для JSON Merge Patch
+type BookPartEdit struct {
+	Title     string   `json:"title" binding:"required,max=255"`
+	Content   string   `json:"content"`
+	OrderNum  int      `json:"order_num" binding:"min=1"`
+	PageStart *int     `json:"page_start"`
+	PageEnd   *int     `json:"page_end"`
+	MoodTags  []string `json:"mood_tags"`
+	EditMeta
 }
 
 // BookResponse - DTO для ответа API
@@ -144,6 +175,8 @@ type BookResponse struct {
 	VerificationType *VerificationType `json:"verification_type"`
 	CreatedBy        *string           `json:"created_by"`
 	CreatedAt        time.Time         `json:"created_at"`
+	UpdatedAt        time.Time         `json:"updated_at"`
+	Version          int               `json:"version"`
 	Tags             []string          `json:"tags"`
 	AverageRating    float64           `json:"average_rating"`
 	RatingCount      int               `json:"rating_count"`
@@ -151,15 +184,17 @@ type BookResponse struct {
 
 // BookPartResponse - DTO для ответа API части книги
 type BookPartResponse struct {
-	ID            string   `json:"id"`
-	BookID        string   `json:"book_id"`
-	Title         string   `json:"title"`
-	Content       string   `json:"content"`
-	OrderNum      int      `json:"order_num"`
-	PageStart     *int     `json:"page_start"`
-	PageEnd       *int     `json:"page_end"`
-	MoodTags      []string `json:"mood_tags"`
-	AverageRating *float64 `json:"average_rating"`
+	ID            string    `json:"id"`
+	BookID        string    `json:"book_id"`
+	Title         string    `json:"title"`
+	Content       string    `json:"content"`
+	OrderNum      int       `json:"order_num"`
+	PageStart     *int      `json:"page_start"`
+	PageEnd       *int      `json:"page_end"`
+	MoodTags      []string  `json:"mood_tags"`
+	AverageRating *float64  `json:"average_rating"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Version       int       `json:"version"`
 }
 
 // ToResponse - конвертация Book в BookResponse
@@ -180,6 +215,8 @@ func (b *Book) ToResponse() *BookResponse {
 		VerificationType: b.VerificationType,
 		CreatedBy:        b.CreatedBy,
 		CreatedAt:        b.CreatedAt,
+		UpdatedAt:        b.UpdatedAt,
+		Version:          b.Version,
 		Tags:             b.Tags,
 		AverageRating:    b.AverageRating,
 		RatingCount:      b.RatingCount,
@@ -198,5 +235,61 @@ func (bp *BookPart) ToResponse() *BookPartResponse {
 		PageEnd:       bp.PageEnd,
 		MoodTags:      bp.MoodTags,
 		AverageRating: bp.AverageRating,
+		UpdatedAt:     bp.UpdatedAt,
+		Version:       bp.Version,
 	}
 }
+
+// Edit - редактируемые поля книги
+func (b *Book) Edit() *BookEdit {
+	return &BookEdit{
+		Title:         b.Title,
+		OriginalTitle: b.OriginalTitle,
+		Author:        b.Author,
+		Year:          b.Year,
+		Genres:        b.Genres,
+		AgeRating:     b.AgeRating,
+		AuthorCountry: b.AuthorCountry,
+		Description:   b.Description,
+		CoverURL:      b.CoverURL,
+		PagesCount:    b.PagesCount,
+		Tags:          b.Tags,
+	}
+}
+
+// ApplyEdit - замена редактируемых полей книги
+func (b *Book) ApplyEdit(e *BookEdit) {
+	b.Title = e.Title
+	b.OriginalTitle = e.OriginalTitle
+	b.Author = e.Author
+	b.Year = e.Year
+	b.Genres = e.Genres
+	b.AgeRating = e.AgeRating
+	b.AuthorCountry = e.AuthorCountry
+	b.Description = e.Description
+	b.CoverURL = e.CoverURL
+	b.PagesCount = e.PagesCount
+	b.Tags = e.Tags
+}
+
+// Edit - редактируемые поля части книги
+func (bp *BookPart) Edit() *BookPartEdit {
+	return &BookPartEdit{
+		Title:     bp.Title,
+		Content:   bp.Content,
+		OrderNum:  bp.OrderNum,
+		PageStart: bp.PageStart,
+		PageEnd:   bp.PageEnd,
+		MoodTags:  bp.MoodTags,
+	}
+}
+
+// ApplyEdit - замена редактируемых полей части книги
+func (bp *BookPart) ApplyEdit(e *BookPartEdit) {
+	bp.Title = e.Title
+	bp.Content = e.Content
+	bp.OrderNum = e.OrderNum
+	bp.PageStart = e.PageStart
+	bp.PageEnd = e.PageEnd
+	bp.MoodTags = e.MoodTags
+}
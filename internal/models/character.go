@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql/driver"
 	"errors"
+	"time"
 )
 
 // CharacterSource - enum для источника персонажа
@@ -44,17 +45,21 @@ type Character struct {
 
 // CharacterProfile - расширенный профиль персонажа
 type CharacterProfile struct {
-	ID                    string   `json:"id" db:"id"`
-	Aliases               []string `json:"aliases" db:"aliases"`
-	ImageURL              *string  `json:"image_url" db:"image_url"`
-	Age                   *string  `json:"age" db:"age"`
-	Height                *string  `json:"height" db:"height"`
-	Weight                *string  `json:"weight" db:"weight"`
-	SocialStatus          *string  `json:"social_status" db:"social_status"`
-	DescriptionNoSpoilers string   `json:"description_no_spoilers" db:"description_no_spoilers"`
-	DescriptionSpoilers   string   `json:"description_spoilers" db:"description_spoilers"`
-	QuotesNoSpoilers      []string `json:"quotes_no_spoilers" db:"quotes_no_spoilers"`
-	QuotesSpoilers        []string `json:"quotes_spoilers" db:"quotes_spoilers"`
+	ID                    string    `json:"id" db:"id"`
+	BookID                *string   `json:"book_id" db:"book_id"`
+	Name                  string    `json:"name" db:"name"`
+	Aliases               []string  `json:"aliases" db:"aliases"`
+	ImageURL              *string   `json:"image_url" db:"image_url"`
+	Age                   *string   `json:"age" db:"age"`
+	Height                *string   `json:"height" db:"height"`
+	Weight                *string   `json:"weight" db:"weight"`
+	SocialStatus          *string   `json:"social_status" db:"social_status"`
+	DescriptionNoSpoilers string    `json:"description_no_spoilers" db:"description_no_spoilers"`
+	DescriptionSpoilers   string    `json:"description_spoilers" db:"description_spoilers"`
+	QuotesNoSpoilers      []string  `json:"quotes_no_spoilers" db:"quotes_no_spoilers"`
+	QuotesSpoilers        []string  `json:"quotes_spoilers" db:"quotes_spoilers"`
+	UpdatedAt             time.Time `json:"updated_at" db:"updated_at"`
+	Version               int       `json:"version" db:"version"`
 }
 
 // CharacterIllustration - иллюстрация персонажа
@@ -97,6 +102,22 @@ type CreateCharacterProfileRequest struct {
 	QuotesSpoilers        []string `json:"quotes_spoilers"`
 }
 
+// CharacterProfileEdit - редактируемые поля профиля персонажа для JSON Merge Patch
+type CharacterProfileEdit struct {
+	Name                  string   `json:"name" binding:"required,max=255"`
+	Aliases               []string `json:"aliases"`
+	ImageURL              *string  `json:"image_url"`
+	Age                   *string  `json:"age"`
+	Height                *string  `json:"height"`
+	Weight                *string  `json:"weight"`
+	SocialStatus          *string  `json:"social_status"`
+	DescriptionNoSpoilers string   `json:"description_no_spoilers" binding:"required"`
+	DescriptionSpoilers   string   `json:"description_spoilers" binding:"required"`
+	QuotesNoSpoilers      []string `json:"quotes_no_spoilers"`
+	QuotesSpoilers        []string `json:"quotes_spoilers"`
+	EditMeta
+}
+
 // CharacterResponse - DTO для ответа API
 type CharacterResponse struct {
 	ID              string          `json:"id"`
@@ -110,17 +131,21 @@ type CharacterResponse struct {
 
 // CharacterProfileResponse - DTO для ответа API профиля
 type CharacterProfileResponse struct {
-	ID                    string   `json:"id"`
-	Aliases               []string `json:"aliases"`
-	ImageURL              *string  `json:"image_url"`
-	Age                   *string  `json:"age"`
-	Height                *string  `json:"height"`
-	Weight                *string  `json:"weight"`
-	SocialStatus          *string  `json:"social_status"`
-	DescriptionNoSpoilers string   `json:"description_no_spoilers"`
-	DescriptionSpoilers   string   `json:"description_spoilers"`
-	QuotesNoSpoilers      []string `json:"quotes_no_spoilers"`
-	QuotesSpoilers        []string `json:"quotes_spoilers"`
+	ID                    string    `json:"id"`
+	BookID                *string   `json:"book_id"`
+	Name                  string    `json:"name"`
+	Aliases               []string  `json:"aliases"`
+	ImageURL              *string   `json:"image_url"`
+	Age                   *string   `json:"age"`
+	Height                *string   `json:"height"`
+	Weight                *string   `json:"weight"`
+	SocialStatus          *string   `json:"social_status"`
+	DescriptionNoSpoilers string    `json:"description_no_spoilers"`
+	DescriptionSpoilers   string    `json:"description_spoilers"`
+	QuotesNoSpoilers      []string  `json:"quotes_no_spoilers"`
+	QuotesSpoilers        []string  `json:"quotes_spoilers"`
+	UpdatedAt             time.Time `json:"updated_at"`
+	Version               int       `json:"version"`
 }
 
 // CharacterIllustrationResponse - DTO для ответа API иллюстрации
@@ -148,6 +173,8 @@ func (c *Character) ToResponse() *CharacterResponse {
 func (cp *CharacterProfile) ToResponse() *CharacterProfileResponse {
 	return &CharacterProfileResponse{
 		ID:                    cp.ID,
+		BookID:                cp.BookID,
+		Name:                  cp.Name,
 		Aliases:               cp.Aliases,
 		ImageURL:              cp.ImageURL,
 		Age:                   cp.Age,
@@ -158,9 +185,43 @@ func (cp *CharacterProfile) ToResponse() *CharacterProfileResponse {
 		DescriptionSpoilers:   cp.DescriptionSpoilers,
 		QuotesNoSpoilers:      cp.QuotesNoSpoilers,
 		QuotesSpoilers:        cp.QuotesSpoilers,
+		UpdatedAt:             cp.UpdatedAt,
+		Version:               cp.Version,
 	}
 }
 
+// Edit - редактируемые поля профиля персонажа
+func (cp *CharacterProfile) Edit() *CharacterProfileEdit {
+	return &CharacterProfileEdit{
+		Name:                  cp.Name,
+		Aliases:               cp.Aliases,
+		ImageURL:              cp.ImageURL,
+		Age:                   cp.Age,
+		Height:                cp.Height,
+		Weight:                cp.Weight,
+		SocialStatus:          cp.SocialStatus,
+		DescriptionNoSpoilers: cp.DescriptionNoSpoilers,
+		DescriptionSpoilers:   cp.DescriptionSpoilers,
+		QuotesNoSpoilers:      cp.QuotesNoSpoilers,
+		QuotesSpoilers:        cp.QuotesSpoilers,
+	}
+}
+
+// ApplyEdit - замена редактируемых полей профиля персонажа
+func (cp *CharacterProfile) ApplyEdit(e *CharacterProfileEdit) {
+	cp.Name = e.Name
+	cp.Aliases = e.Aliases
+	cp.ImageURL = e.ImageURL
+	cp.Age = e.Age
+	cp.Height = e.Height
+	cp.Weight = e.Weight
+	cp.SocialStatus = e.SocialStatus
+	cp.DescriptionNoSpoilers = e.DescriptionNoSpoilers
+	cp.DescriptionSpoilers = e.DescriptionSpoilers
+	cp.QuotesNoSpoilers = e.QuotesNoSpoilers
+	cp.QuotesSpoilers = e.QuotesSpoilers
+}
+
 // ToResponse - конвертация CharacterIllustration в CharacterIllustrationResponse
 func (ci *CharacterIllustration) ToResponse() *CharacterIllustrationResponse {
 	return &CharacterIllustrationResponse{
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// BookExportSchemaVersion - версия схемы каноничного JSON документа книги
+const BookExportSchemaVersion = 1
+
+// BookExportDocument - каноничный JSON документ книги
+type BookExportDocument struct {
+	SchemaVersion int                 `json:"schema_version"`
+	ExportedAt    time.Time           `json:"exported_at"`
+	Book          *BookResponse       `json:"book"`
+	Parts         []*BookPartResponse `json:"parts"`
+}
+
+// UserDataExport - выгрузка данных пользователя для резервной копии
+type UserDataExport struct {
+	ExportedAt time.Time                   `json:"exported_at"`
+	User       *UserResponse               `json:"user"`
+	Reviews    []*ReviewResponse           `json:"reviews"`
+	Quotes     []*QuoteResponse            `json:"quotes"`
+	Progress   []*UserBookProgressResponse `json:"progress"`
+}
@@ -0,0 +1,28 @@
+package models
+
+// ImportResponse - DTO результата импорта книги (или предпросмотра при dry_run)
+type ImportResponse struct {
+	DryRun   bool                 `json:"dry_run"`
+	Format   string               `json:"format"`
+	Book     *BookResponse        `json:"book"`
+	Parts    []*ImportPartPreview `json:"parts"`
+	Cover    *ImportCoverPreview  `json:"cover"`
+	Warnings []string             `json:"warnings"`
+}
+
+// ImportPartPreview - DTO главы импортируемой книги
+type ImportPartPreview struct {
+	ID         string `json:"id,omitempty"`
+	OrderNum   int    `json:"order_num"`
+	Title      string `json:"title"`
+	PageStart  *int   `json:"page_start"`
+	PageEnd    *int   `json:"page_end"`
+	Characters int    `json:"characters"`
+	Excerpt    string `json:"excerpt"`
+}
+
+// ImportCoverPreview - DTO обложки импортируемой книги
+type ImportCoverPreview struct {
+	ContentType string `json:"content_type"`
+	Size        int    `json:"size"`
+}
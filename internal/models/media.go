@@ -0,0 +1,20 @@
+package models
+
+// MediaVariant - DTO уменьшенной копии загруженного изображения
+type MediaVariant struct {
+	Name        string `json:"name" example:"thumb"`
+	URL         string `json:"url" example:"/media/books/0b7c.../cover-3f9a1c2b4d5e6f70_thumb.webp"`
+	ContentType string `json:"content_type" example:"image/webp"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+// UploadResponse - DTO загруженного изображения с вариантами
+type UploadResponse struct {
+	URL         string          `json:"url" example:"/media/books/0b7c.../cover-3f9a1c2b4d5e6f70.jpg"`
+	ContentType string          `json:"content_type" example:"image/jpeg"`
+	Size        int64           `json:"size"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	Variants    []*MediaVariant `json:"variants"`
+}
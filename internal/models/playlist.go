@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql/driver"
 	"errors"
+	"time"
 )
 
 // PlaylistCreator - enum для создателя плейлиста
@@ -81,13 +82,13 @@ type BookPlaylist struct {
 
 // UserBookProgress - модель прогресса чтения книги пользователем
 type UserBookProgress struct {
-	ID               string   `json:"id" db:"id"`
-	UserID           string   `json:"user_id" db:"user_id"`
-	BookID           string   `json:"book_id" db:"book_id"`
-	CompletedPartIDs []string `json:"completed_part_ids" db:"completed_part_ids"`
-	CurrentPartID    *string  `json:"current_part_id" db:"current_part_id"`
-	IsCompleted      bool     `json:"is_completed" db:"is_completed"`
-	CompletedAt      *string  `json:"completed_at" db:"completed_at"`
+	ID               string     `json:"id" db:"id"`
+	UserID           string     `json:"user_id" db:"user_id"`
+	BookID           string     `json:"book_id" db:"book_id"`
+	CompletedPartIDs []string   `json:"completed_part_ids" db:"completed_part_ids"`
+	CurrentPartID    *string    `json:"current_part_id" db:"current_part_id"`
+	IsCompleted      bool       `json:"is_completed" db:"is_completed"`
+	CompletedAt      *time.Time `json:"completed_at" db:"completed_at"`
 }
 
 // ReadBookForm - модель формы прочитанной книги
@@ -105,12 +106,12 @@ type ReadBookForm struct {
 
 // Quote - модель цитаты
 type Quote struct {
-	ID        string  `json:"id" db:"id"`
-	UserID    string  `json:"user_id" db:"user_id"`
-	BookID    string  `json:"book_id" db:"book_id"`
-	PartID    *string `json:"part_id" db:"part_id"`
-	Text      string  `json:"text" db:"text"`
-	CreatedAt string  `json:"created_at" db:"created_at"`
+	ID        string    `json:"id" db:"id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	BookID    string    `json:"book_id" db:"book_id"`
+	PartID    *string   `json:"part_id" db:"part_id"`
+	Text      string    `json:"text" db:"text"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
 // CreatePlaylistRequest - DTO для создания плейлиста
@@ -173,23 +174,23 @@ type BookPlaylistResponse struct {
 
 // UserBookProgressResponse - DTO для ответа API прогресса
 type UserBookProgressResponse struct {
-	ID               string   `json:"id"`
-	UserID           string   `json:"user_id"`
-	BookID           string   `json:"book_id"`
-	CompletedPartIDs []string `json:"completed_part_ids"`
-	CurrentPartID    *string  `json:"current_part_id"`
-	IsCompleted      bool     `json:"is_completed"`
-	CompletedAt      *string  `json:"completed_at"`
+	ID               string     `json:"id"`
+	UserID           string     `json:"user_id"`
+	BookID           string     `json:"book_id"`
+	CompletedPartIDs []string   `json:"completed_part_ids"`
+	CurrentPartID    *string    `json:"current_part_id"`
+	IsCompleted      bool       `json:"is_completed"`
+	CompletedAt      *time.Time `json:"completed_at"`
 }
 
 // QuoteResponse - DTO для ответа API цитаты
 type QuoteResponse struct {
-	ID        string  `json:"id"`
-	UserID    string  `json:"user_id"`
-	BookID    string  `json:"book_id"`
-	PartID    *string `json:"part_id"`
-	Text      string  `json:"text"`
-	CreatedAt string  `json:"created_at"`
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	BookID    string    `json:"book_id"`
+	PartID    *string   `json:"part_id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // ToResponse - конвертация Playlist в PlaylistResponse
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+)
+
+// ReadingSession - модель сессии чтения пользователя
+type ReadingSession struct {
+	ID              string     `json:"id" db:"id"`
+	UserID          string     `json:"user_id" db:"user_id"`
+	BookID          string     `json:"book_id" db:"book_id"`
+	PartID          *string    `json:"part_id" db:"part_id"`
+	StartedAt       time.Time  `json:"started_at" db:"started_at"`
+	EndedAt         *time.Time `json:"ended_at" db:"ended_at"`
+	PagesRead       int        `json:"pages_read" db:"pages_read"`
+	DurationMinutes *int       `json:"duration_minutes" db:"duration_minutes"`
+}
+
+// UserFavorite - модель избранной книги пользователя
+type UserFavorite struct {
+	ID        string    `json:"id" db:"id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	BookID    string    `json:"book_id" db:"book_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// ReadingSessionResponse - DTO для ответа API сессии чтения
+type ReadingSessionResponse struct {
+	ID              string     `json:"id"`
+	UserID          string     `json:"user_id"`
+	BookID          string     `json:"book_id"`
+	PartID          *string    `json:"part_id"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"`
+	PagesRead       int        `json:"pages_read"`
+	DurationMinutes *int       `json:"duration_minutes"`
+}
+
+// UserFavoriteResponse - DTO для ответа API избранной книги
+type UserFavoriteResponse struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	BookID    string    `json:"book_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToResponse - конвертация ReadingSession в ReadingSessionResponse
+func (rs *ReadingSession) ToResponse() *ReadingSessionResponse {
+	return &ReadingSessionResponse{
+		ID:              rs.ID,
+		UserID:          rs.UserID,
+		BookID:          rs.BookID,
+		PartID:          rs.PartID,
+		StartedAt:       rs.StartedAt,
+		EndedAt:         rs.EndedAt,
+		PagesRead:       rs.PagesRead,
+		DurationMinutes: rs.DurationMinutes,
+	}
+}
+
+// ToResponse - конвертация UserFavorite в UserFavoriteResponse
+func (uf *UserFavorite) ToResponse() *UserFavoriteResponse {
+	return &UserFavoriteResponse{
+		ID:        uf.ID,
+		UserID:    uf.UserID,
+		BookID:    uf.BookID,
+		CreatedAt: uf.CreatedAt,
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// Причины, по которым книги считаются похожими
+const (
+	SimilarityReasonCoReaders = "co_readers"
+	SimilarityReasonGenres    = "genres"
+	SimilarityReasonTags      = "tags"
+	SimilarityReasonMoodTags  = "mood_tags"
+)
+
+// BookFeatures - признаки книги для расчета похожести
+type BookFeatures struct {
+	BookID   string   `db:"book_id"`
+	Genres   []string `db:"genres"`
+	Tags     []string `db:"tags"`
+	MoodTags []string `db:"mood_tags"`
+}
+
+// UserBookSignal - положительный сигнал пользователя по книге (отзыв, избранное, полка)
+type UserBookSignal struct {
+	UserID string  `db:"user_id"`
+	BookID string  `db:"book_id"`
+	Weight float64 `db:"weight"`
+}
+
+// BookSimilarity - предрассчитанная похожесть двух книг
+type BookSimilarity struct {
+	BookID        string    `json:"book_id" db:"book_id"`
+	SimilarBookID string    `json:"similar_book_id" db:"similar_book_id"`
+	Score         float64   `json:"score" db:"score"`
+	Reasons       []string  `json:"reasons" db:"reasons"`
+	ComputedAt    time.Time `json:"computed_at" db:"computed_at"`
+}
+
+// RecommendedBook - DTO рекомендованной книги
+type RecommendedBook struct {
+	BookID        string   `json:"book_id" db:"book_id"`
+	Title         string   `json:"title" db:"title"`
+	Author        string   `json:"author" db:"author"`
+	CoverURL      *string  `json:"cover_url" db:"cover_url"`
+	AverageRating float64  `json:"average_rating" db:"average_rating"`
+	Score         float64  `json:"score" db:"score"`
+	Reasons       []string `json:"reasons" db:"reasons"`
+}
@@ -0,0 +1,137 @@
+package models
+
+import "time"
+
+// ReportTargetType - тип пользовательского контента, на который можно пожаловаться
+type ReportTargetType string
+
+const (
+	ReportTargetReview           ReportTargetType = "review"
+	ReportTargetComment          ReportTargetType = "comment"
+	ReportTargetQuote            ReportTargetType = "quote"
+	ReportTargetArticle          ReportTargetType = "article"
+	ReportTargetCharacterProfile ReportTargetType = "character_profile"
+)
+
+// IsValid - проверка типа объекта жалобы
+func (t ReportTargetType) IsValid() bool {
+	switch t {
+	case ReportTargetReview, ReportTargetComment, ReportTargetQuote, ReportTargetArticle, ReportTargetCharacterProfile:
+		return true
+	}
+	return false
+}
+
+// ReportReason - категория нарушения
+type ReportReason string
+
+const (
+	ReportReasonSpam          ReportReason = "spam"
+	ReportReasonHarassment    ReportReason = "harassment"
+	ReportReasonHateSpeech    ReportReason = "hate_speech"
+	ReportReasonSpoilers      ReportReason = "spoilers"
+	ReportReasonInappropriate ReportReason = "inappropriate"
+	ReportReasonCopyright     ReportReason = "copyright"
+	ReportReasonOther         ReportReason = "other"
+)
+
+// ReportStatus - статус жалобы
+type ReportStatus string
+
+const (
+	ReportStatusOpen ReportStatus = "open"
+	// ReportStatusResolved - жалоба признана обоснованной: контент скрыт, автор получил предупреждение
+	ReportStatusResolved ReportStatus = "resolved"
+	// ReportStatusDismissed - жалоба отклонена
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+// IsValid - проверка статуса жалобы
+func (s ReportStatus) IsValid() bool {
+	switch s {
+	case ReportStatusOpen, ReportStatusResolved, ReportStatusDismissed:
+		return true
+	}
+	return false
+}
+
+// ReportAction - действие модератора над жалобой
+type ReportAction string
+
+const (
+	ReportActionClaim   ReportAction = "claim"
+	ReportActionRelease ReportAction = "release"
+	ReportActionResolve ReportAction = "resolve"
+	ReportActionDismiss ReportAction = "dismiss"
+)
+
+// ContentReport - жалоба на пользовательский контент
+type ContentReport struct {
+	ID         string           `json:"id"`
+	TargetType ReportTargetType `json:"target_type"`
+	TargetID   string           `json:"target_id"`
+	ReporterID string           `json:"reporter_id"`
+	Reason     ReportReason     `json:"reason"`
+	Details    *string          `json:"details"`
+	Status     ReportStatus     `json:"status"`
+	// ClaimedBy - модератор, взявший жалобу в работу
+	ClaimedBy      *string    `json:"claimed_by"`
+	ClaimedAt      *time.Time `json:"claimed_at"`
+	ResolvedBy     *string    `json:"resolved_by"`
+	ResolutionNote *string    `json:"resolution_note"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	// OpenReports - число открытых жалоб на тот же объект (включая эту)
+	OpenReports int `json:"open_reports"`
+	// TargetHidden - объект сейчас скрыт из публичных ответов
+	TargetHidden bool `json:"target_hidden"`
+}
+
+// ReportTarget - объект жалобы: автор (nil - у объекта нет автора-пользователя) и признак скрытия
+type ReportTarget struct {
+	Type     ReportTargetType
+	ID       string
+	AuthorID *string
+	Hidden   bool
+}
+
+// ReportFilter - фильтры очереди жалоб (пустое поле не ограничивает выборку)
+type ReportFilter struct {
+	Status     *ReportStatus     `form:"status" binding:"omitempty,oneof=open resolved dismissed"`
+	TargetType *ReportTargetType `form:"target_type" binding:"omitempty,oneof=review comment quote article character_profile"`
+	TargetID   *string           `form:"target_id" binding:"omitempty,uuid"`
+	ClaimedBy  *string           `form:"claimed_by" binding:"omitempty,uuid"`
+}
+
+// CreateReportRequest - DTO жалобы на контент
+type CreateReportRequest struct {
+	TargetType ReportTargetType `json:"target_type" binding:"required,oneof=review comment quote article character_profile" example:"review"`
+	TargetID   string           `json:"target_id" binding:"required,uuid" example:"3f0c9a4e-1b7d-4a53-9f0e-2d6b8c1e5a71"`
+	Reason     ReportReason     `json:"reason" binding:"required,oneof=spam harassment hate_speech spoilers inappropriate copyright other" example:"spam"`
+	Details    *string          `json:"details" binding:"omitempty,max=2000"`
+}
+
+// ResolveReportRequest - DTO решения модератора по жалобе
+type ResolveReportRequest struct {
+	Note *string `json:"note" binding:"omitempty,max=1000"`
+}
+
+// BulkReportRequest - DTO одного действия модератора над несколькими жалобами
+type BulkReportRequest struct {
+	IDs    []string     `json:"ids" binding:"required,min=1,max=100,dive,uuid"`
+	Action ReportAction `json:"action" binding:"required,oneof=claim release resolve dismiss" example:"dismiss"`
+	Note   *string      `json:"note" binding:"omitempty,max=1000"`
+}
+
+// BulkReportFailure - жалоба, к которой не удалось применить действие
+type BulkReportFailure struct {
+	ID      string `json:"id"`
+	Code    string `json:"code" example:"conflict"`
+	Message string `json:"message" example:"report is no longer open"`
+}
+
+// BulkReportResult - итог пакетного действия: каждая жалоба обрабатывается независимо
+type BulkReportResult struct {
+	Succeeded []*ContentReport     `json:"succeeded"`
+	Failed    []*BulkReportFailure `json:"failed"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// RevisionEntity - тип сущности каталога, для которой ведется история правок
+type RevisionEntity string
+
+const (
+	RevisionEntityBook             RevisionEntity = "book"
+	RevisionEntityBookPart         RevisionEntity = "book_part"
+	RevisionEntityCharacterProfile RevisionEntity = "character_profile"
+)
+
+// EditMeta - сведения о правке, не относящиеся к полям ресурса
+type EditMeta struct {
+	// Version - версия ресурса, с которой начата правка (если не передан заголовок If-Match)
+	Version *int `json:"version,omitempty"`
+	// Comment - комментарий к ревизии
+	Comment *string `json:"comment,omitempty" binding:"omitempty,max=500"`
+}
+
+// FieldChange - изменение одного поля между двумя состояниями
+type FieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// Revision - ревизия сущности каталога: состояние редактируемых полей после правки
+type Revision struct {
+	EntityType RevisionEntity         `json:"entity_type" db:"entity_type"`
+	EntityID   string                 `json:"entity_id" db:"entity_id"`
+	Version    int                    `json:"version" db:"version"`
+	EditorID   *string                `json:"editor_id" db:"editor_id"`
+	Comment    *string                `json:"comment" db:"comment"`
+	Changes    map[string]FieldChange `json:"changes" db:"changes"`
+	Snapshot   map[string]any         `json:"snapshot" db:"snapshot"`
+	CreatedAt  time.Time              `json:"created_at" db:"created_at"`
+}
+
+// RevertRevisionRequest - DTO для отката к ревизии
+type RevertRevisionRequest struct {
+	EditMeta
+}
+
+// RevisionResponse - DTO для ответа API ревизии
+type RevisionResponse struct {
+	Version   int                    `json:"version"`
+	EditorID  *string                `json:"editor_id"`
+	Comment   *string                `json:"comment"`
+	Changes   map[string]FieldChange `json:"changes"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// RevisionDiffResponse - DTO для ответа API сравнения двух ревизий
+type RevisionDiffResponse struct {
+	From    int                    `json:"from"`
+	To      int                    `json:"to"`
+	Changes map[string]FieldChange `json:"changes"`
+}
+
+// ToResponse - конвертация Revision в RevisionResponse
+func (r *Revision) ToResponse() *RevisionResponse {
+	return &RevisionResponse{
+		Version:   r.Version,
+		EditorID:  r.EditorID,
+		Comment:   r.Comment,
+		Changes:   r.Changes,
+		CreatedAt: r.CreatedAt,
+	}
+}
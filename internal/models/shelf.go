@@ -0,0 +1,121 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+// ShelfStatus - enum для встроенных полок
+type ShelfStatus string
+
+const (
+	ShelfStatusWantToRead ShelfStatus = "want_to_read"
+	ShelfStatusReading    ShelfStatus = "reading"
+	ShelfStatusRead       ShelfStatus = "read"
+	ShelfStatusAbandoned  ShelfStatus = "abandoned"
+)
+
+// Value - реализация driver.Valuer для PostgreSQL
+func (ss ShelfStatus) Value() (driver.Value, error) {
+	return string(ss), nil
+}
+
+// Scan - реализация sql.Scanner для PostgreSQL
+func (ss *ShelfStatus) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("cannot scan non-string value into ShelfStatus")
+	}
+	*ss = ShelfStatus(str)
+	return nil
+}
+
+// IsValid - проверка, что статус является одной из встроенных полок
+func (ss ShelfStatus) IsValid() bool {
+	switch ss {
+	case ShelfStatusWantToRead, ShelfStatusReading, ShelfStatusRead, ShelfStatusAbandoned:
+		return true
+	}
+	return false
+}
+
+// ShelfBook - книга на встроенной полке пользователя
+type ShelfBook struct {
+	UserID       string      `json:"user_id" db:"user_id"`
+	BookID       string      `json:"book_id" db:"book_id"`
+	Status       ShelfStatus `json:"status" db:"status"`
+	Note         *string     `json:"note" db:"note"`
+	CreatedAt    time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at" db:"updated_at"`
+	BookTitle    string      `json:"book_title" db:"book_title"`
+	BookAuthor   string      `json:"book_author" db:"book_author"`
+	BookCoverURL *string     `json:"book_cover_url" db:"book_cover_url"`
+}
+
+// Shelf - пользовательская именованная полка
+type Shelf struct {
+	ID         string    `json:"id" db:"id"`
+	UserID     string    `json:"user_id" db:"user_id"`
+	Name       string    `json:"name" db:"name"`
+	Position   int       `json:"position" db:"position"`
+	BooksCount int       `json:"books_count" db:"books_count"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// ShelfItem - книга на пользовательской полке
+type ShelfItem struct {
+	ShelfID      string    `json:"shelf_id" db:"shelf_id"`
+	BookID       string    `json:"book_id" db:"book_id"`
+	Position     int       `json:"position" db:"position"`
+	Note         *string   `json:"note" db:"note"`
+	AddedAt      time.Time `json:"added_at" db:"added_at"`
+	BookTitle    string    `json:"book_title" db:"book_title"`
+	BookAuthor   string    `json:"book_author" db:"book_author"`
+	BookCoverURL *string   `json:"book_cover_url" db:"book_cover_url"`
+}
+
+// ShelfStatusCount - количество книг на встроенной полке
+type ShelfStatusCount struct {
+	Status ShelfStatus `json:"status" db:"status"`
+	Count  int         `json:"count" db:"count"`
+}
+
+// SetShelfStatusRequest - DTO для перемещения книги на встроенную полку
+type SetShelfStatusRequest struct {
+	Status ShelfStatus `json:"status" binding:"required,oneof=want_to_read reading read abandoned"`
+	Note   *string     `json:"note" binding:"omitempty,max=2000"`
+}
+
+// CreateShelfRequest - DTO для создания полки
+type CreateShelfRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// UpdateShelfRequest - DTO для обновления полки
+type UpdateShelfRequest struct {
+	Name     *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Position *int    `json:"position" binding:"omitempty,min=0"`
+}
+
+// AddShelfItemRequest - DTO для добавления книги на полку
+type AddShelfItemRequest struct {
+	BookID   string  `json:"book_id" binding:"required"`
+	Note     *string `json:"note" binding:"omitempty,max=2000"`
+	Position *int    `json:"position" binding:"omitempty,min=0"`
+}
+
+// UpdateShelfItemRequest - DTO для обновления книги на полке
+type UpdateShelfItemRequest struct {
+	Note     *string `json:"note" binding:"omitempty,max=2000"`
+	Position *int    `json:"position" binding:"omitempty,min=0"`
+}
+
+// ShelvesOverviewResponse - DTO обзора полок пользователя
+type ShelvesOverviewResponse struct {
+	Statuses []*ShelfStatusCount `json:"statuses"`
+	Shelves  []*Shelf            `json:"shelves"`
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+// ActivityType - enum для типов событий активности
+type ActivityType string
+
+const (
+	ActivityTypeBookFinished     ActivityType = "book_finished"
+	ActivityTypeReviewCreated    ActivityType = "review_created"
+	ActivityTypeQuoteSaved       ActivityType = "quote_saved"
+	ActivityTypeArticlePublished ActivityType = "article_published"
+	ActivityTypeChallengeJoined  ActivityType = "challenge_joined"
+)
+
+// Value - реализация driver.Valuer для PostgreSQL
+func (at ActivityType) Value() (driver.Value, error) {
+	return string(at), nil
+}
+
+// Scan - реализация sql.Scanner для PostgreSQL
+func (at *ActivityType) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("cannot scan non-string value into ActivityType")
+	}
+	*at = ActivityType(str)
+	return nil
+}
+
+// Follow - модель подписки пользователя
+type Follow struct {
+	FollowerID string    `json:"follower_id" db:"follower_id"`
+	FolloweeID string    `json:"followee_id" db:"followee_id"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// ActivityEvent - модель события активности пользователя
+type ActivityEvent struct {
+	ID        string                 `json:"id" db:"id"`
+	ActorID   string                 `json:"actor_id" db:"actor_id"`
+	Type      ActivityType           `json:"type" db:"type"`
+	TargetID  string                 `json:"target_id" db:"target_id"`
+	BookID    *string                `json:"book_id" db:"book_id"`
+	Payload   map[string]interface{} `json:"payload" db:"payload"`
+	CreatedAt time.Time              `json:"created_at" db:"created_at"`
+}
+
+// FeedItem - событие в ленте пользователя вместе с автором
+type FeedItem struct {
+	ActivityEvent
+	ActorUsername  string  `json:"actor_username" db:"actor_username"`
+	ActorAvatarURL *string `json:"actor_avatar_url" db:"actor_avatar_url"`
+}
+
+// FeedItemResponse - DTO для ответа API события ленты
+type FeedItemResponse struct {
+	ID             string                 `json:"id"`
+	Type           ActivityType           `json:"type"`
+	ActorID        string                 `json:"actor_id"`
+	ActorUsername  string                 `json:"actor_username"`
+	ActorAvatarURL *string                `json:"actor_avatar_url"`
+	TargetID       string                 `json:"target_id"`
+	BookID         *string                `json:"book_id"`
+	Payload        map[string]interface{} `json:"payload"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+// FeedPageResponse - DTO страницы ленты с курсором
+type FeedPageResponse struct {
+	Items      []*FeedItemResponse `json:"items"`
+	NextCursor *string             `json:"next_cursor"`
+}
+
+// ToResponse - конвертация FeedItem в FeedItemResponse
+func (fi *FeedItem) ToResponse() *FeedItemResponse {
+	return &FeedItemResponse{
+		ID:             fi.ID,
+		Type:           fi.Type,
+		ActorID:        fi.ActorID,
+		ActorUsername:  fi.ActorUsername,
+		ActorAvatarURL: fi.ActorAvatarURL,
+		TargetID:       fi.TargetID,
+		BookID:         fi.BookID,
+		Payload:        fi.Payload,
+		CreatedAt:      fi.CreatedAt,
+	}
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// TrashKind - тип удаленной сущности в корзине
+type TrashKind string
+
+const (
+	TrashKindBook    TrashKind = "book"
+	TrashKindArticle TrashKind = "article"
+	TrashKindComment TrashKind = "comment"
+	TrashKindUser    TrashKind = "user"
+)
+
+// IsValid - проверка типа сущности корзины
+func (k TrashKind) IsValid() bool {
+	switch k {
+	case TrashKindBook, TrashKindArticle, TrashKindComment, TrashKindUser:
+		return true
+	}
+	return false
+}
+
+// TrashItem - удаленная сущность в корзине
+type TrashItem struct {
+	Type TrashKind `json:"type" db:"type"`
+	ID   string    `json:"id" db:"id"`
+	// Title - название книги или статьи, имя пользователя или начало текста комментария
+	Title     string    `json:"title" db:"title"`
+	DeletedAt time.Time `json:"deleted_at" db:"deleted_at"`
+	// PurgeAt - время окончательного удаления задачей очистки
+	PurgeAt time.Time `json:"purge_at" db:"-"`
+}
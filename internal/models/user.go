@@ -33,20 +33,79 @@ func (ur *UserRole) Scan(value interface{}) error {
 	return nil
 }
 
+// Visibility - enum для настроек видимости профиля и активности
+type Visibility string
+
+const (
+	VisibilityPublic    Visibility = "public"
+	VisibilityFollowers Visibility = "followers"
+	VisibilityPrivate   Visibility = "private"
+)
+
+// Value - реализация driver.Valuer для PostgreSQL
+func (v Visibility) Value() (driver.Value, error) {
+	return string(v), nil
+}
+
+// Scan - реализация sql.Scanner для PostgreSQL
+func (v *Visibility) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return errors.New("cannot scan non-string value into Visibility")
+	}
+	*v = Visibility(str)
+	return nil
+}
+
+// IsValid - проверка допустимости значения видимости
+func (v Visibility) IsValid() bool {
+	switch v {
+	case VisibilityPublic, VisibilityFollowers, VisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// Allows - разрешено ли зрителю видеть данные с такой видимостью
+func (v Visibility) Allows(isOwner, isFollower bool) bool {
+	if isOwner {
+		return true
+	}
+	switch v {
+	case VisibilityPublic:
+		return true
+	case VisibilityFollowers:
+		return isFollower
+	}
+	return false
+}
+
 // User - модель пользователя
 type User struct {
-	ID                 string    `json:"id" db:"id"`
-	Username           string    `json:"username" db:"username"`
-	Email              string    `json:"email" db:"email"`
-	PasswordHash       string    `json:"-" db:"password_hash"` // "-" не включать в JSON
-	AvatarURL          *string   `json:"avatar_url" db:"avatar_url"`
-	Role               UserRole  `json:"role" db:"role"`
-	CreatedAt          time.Time `json:"created_at" db:"created_at"`
-	BooksRead          int       `json:"books_read" db:"books_read"`
-	ReviewsCount       int       `json:"reviews_count" db:"reviews_count"`
-	LikesReceived      int       `json:"likes_received" db:"likes_received"`
-	ProfileVisibility  string    `json:"profile_visibility" db:"profile_visibility"`
-	ActivityVisibility string    `json:"activity_visibility" db:"activity_visibility"`
+	ID                 string     `json:"id" db:"id"`
+	Username           string     `json:"username" db:"username"`
+	Email              string     `json:"email" db:"email"`
+	PasswordHash       string     `json:"-" db:"password_hash"` // "-" не включать в JSON
+	AvatarURL          *string    `json:"avatar_url" db:"avatar_url"`
+	Role               UserRole   `json:"role" db:"role"`
+	CreatedAt          time.Time  `json:"created_at" db:"created_at"`
+	BooksRead          int        `json:"books_read" db:"books_read"`
+	ReviewsCount       int        `json:"reviews_count" db:"reviews_count"`
+	LikesReceived      int        `json:"likes_received" db:"likes_received"`
+	ProfileVisibility  Visibility `json:"profile_visibility" db:"profile_visibility"`
+	ActivityVisibility Visibility `json:"activity_visibility" db:"activity_visibility"`
+	// Strikes - число подтвержденных модераторами нарушений
+	Strikes int `json:"strikes" db:"strikes"`
+	// SuspendedUntil - вход и обновление токена запрещены до этого момента
+	SuspendedUntil *time.Time `json:"suspended_until" db:"suspended_until"`
+}
+
+// IsSuspended - действует ли блокировка аккаунта в момент now
+func (u *User) IsSuspended(now time.Time) bool {
+	return u.SuspendedUntil != nil && now.Before(*u.SuspendedUntil)
 }
 
 type CreateUserRequest struct {
@@ -61,24 +120,49 @@ type LoginRequest struct {
 }
 
 type UpdateUserRequest struct {
-	Username  *string   `json:"username" example:"arif123"`
-	AvatarURL *string   `json:"avatar_url" example:"https://example.com/avatar.png"`
-	Role      *UserRole `json:"role" example:"user"`
+	Username           *string     `json:"username" example:"arif123"`
+	AvatarURL          *string     `json:"avatar_url" example:"https://example.com/avatar.png"`
+	ProfileVisibility  *Visibility `json:"profile_visibility" binding:"omitempty,oneof=public followers private" example:"public"`
+	ActivityVisibility *Visibility `json:"activity_visibility" binding:"omitempty,oneof=public followers private" example:"followers"`
+}
+
+// UpdateUserRoleRequest - DTO для смены роли пользователя администратором
+type UpdateUserRoleRequest struct {
+	Role UserRole `json:"role" binding:"required,oneof=user moderator admin" example:"moderator"`
+}
+
+// SuspendUserRequest - DTO блокировки пользователя администратором
+type SuspendUserRequest struct {
+	Days int `json:"days" binding:"required,min=1,max=3650" example:"7"`
 }
 
 // UserResponse - DTO для ответа API (без пароля)
 type UserResponse struct {
-	ID                 string    `json:"id"`
-	Username           string    `json:"username"`
-	Email              string    `json:"email"`
-	AvatarURL          *string   `json:"avatar_url"`
-	Role               UserRole  `json:"role"`
-	CreatedAt          time.Time `json:"created_at"`
-	BooksRead          int       `json:"books_read"`
-	ReviewsCount       int       `json:"reviews_count"`
-	LikesReceived      int       `json:"likes_received"`
-	ProfileVisibility  string    `json:"profile_visibility"`
-	ActivityVisibility string    `json:"activity_visibility"`
+	ID                 string     `json:"id"`
+	Username           string     `json:"username"`
+	Email              string     `json:"email"`
+	AvatarURL          *string    `json:"avatar_url"`
+	Role               UserRole   `json:"role"`
+	CreatedAt          time.Time  `json:"created_at"`
+	BooksRead          int        `json:"books_read"`
+	ReviewsCount       int        `json:"reviews_count"`
+	LikesReceived      int        `json:"likes_received"`
+	ProfileVisibility  Visibility `json:"profile_visibility"`
+	ActivityVisibility Visibility `json:"activity_visibility"`
+	Strikes            int        `json:"strikes"`
+	SuspendedUntil     *time.Time `json:"suspended_until"`
+}
+
+// PublicUserResponse - DTO публичного профиля (без email)
+type PublicUserResponse struct {
+	ID            string    `json:"id"`
+	Username      string    `json:"username"`
+	AvatarURL     *string   `json:"avatar_url"`
+	Role          UserRole  `json:"role"`
+	CreatedAt     time.Time `json:"created_at"`
+	BooksRead     int       `json:"books_read"`
+	ReviewsCount  int       `json:"reviews_count"`
+	LikesReceived int       `json:"likes_received"`
 }
 
 // ToResponse - конвертация User в UserResponse
@@ -95,5 +179,21 @@ func (u *User) ToResponse() *UserResponse {
 		LikesReceived:      u.LikesReceived,
 		ProfileVisibility:  u.ProfileVisibility,
 		ActivityVisibility: u.ActivityVisibility,
+		Strikes:            u.Strikes,
+		SuspendedUntil:     u.SuspendedUntil,
+	}
+}
+
+// ToPublicResponse - конвертация User в PublicUserResponse
+func (u *User) ToPublicResponse() *PublicUserResponse {
+	return &PublicUserResponse{
+		ID:            u.ID,
+		Username:      u.Username,
+		AvatarURL:     u.AvatarURL,
+		Role:          u.Role,
+		CreatedAt:     u.CreatedAt,
+		BooksRead:     u.BooksRead,
+		ReviewsCount:  u.ReviewsCount,
+		LikesReceived: u.LikesReceived,
 	}
 }
@@ -0,0 +1,146 @@
+package models
+
+import "time"
+
+// ProposalStatus - статус предложения правки книги
+type ProposalStatus string
+
+const (
+	// ProposalStatusPending - идет голосование
+	ProposalStatusPending ProposalStatus = "pending"
+	// ProposalStatusNeedsReview - порог набран, но решение за модератором
+	// (книгу изменили после предложения или автоматическая проверка его отклонила)
+	ProposalStatusNeedsReview ProposalStatus = "needs_review"
+	ProposalStatusAccepted    ProposalStatus = "accepted"
+	ProposalStatusRejected    ProposalStatus = "rejected"
+)
+
+// IsValid - проверка статуса предложения
+func (s ProposalStatus) IsValid() bool {
+	switch s {
+	case ProposalStatusPending, ProposalStatusNeedsReview, ProposalStatusAccepted, ProposalStatusRejected:
+		return true
+	}
+	return false
+}
+
+// IsOpen - по предложению еще не принято решение
+func (s ProposalStatus) IsOpen() bool {
+	return s == ProposalStatusPending || s == ProposalStatusNeedsReview
+}
+
+// BookChanges - исправления метаданных книги в предложении (не указанное поле не меняется).
+// Обложка и признак верификации через предложения не меняются.
+type BookChanges struct {
+	Title         *string    `json:"title,omitempty" binding:"omitempty,min=1,max=255"`
+	OriginalTitle *string    `json:"original_title,omitempty" binding:"omitempty,max=255"`
+	Author        *string    `json:"author,omitempty" binding:"omitempty,min=1,max=255"`
+	Year          *int       `json:"year,omitempty"`
+	Genres        *[]string  `json:"genres,omitempty"`
+	AgeRating     *AgeRating `json:"age_rating,omitempty" binding:"omitempty,oneof=6+ 12+ 16+ 18+"`
+	AuthorCountry *string    `json:"author_country,omitempty" binding:"omitempty,max=100"`
+	Description   *string    `json:"description,omitempty" binding:"omitempty,min=1"`
+	PagesCount    *int       `json:"pages_count,omitempty" binding:"omitempty,min=1"`
+	Tags          *[]string  `json:"tags,omitempty"`
+}
+
+// Apply - применение исправлений к книге
+func (c *BookChanges) Apply(b *Book) {
+	if c.Title != nil {
+		b.Title = *c.Title
+	}
+	if c.OriginalTitle != nil {
+		b.OriginalTitle = c.OriginalTitle
+	}
+	if c.Author != nil {
+		b.Author = *c.Author
+	}
+	if c.Year != nil {
+		b.Year = c.Year
+	}
+	if c.Genres != nil {
+		b.Genres = *c.Genres
+	}
+	if c.AgeRating != nil {
+		b.AgeRating = c.AgeRating
+	}
+	if c.AuthorCountry != nil {
+		b.AuthorCountry = c.AuthorCountry
+	}
+	if c.Description != nil {
+		b.Description = *c.Description
+	}
+	if c.PagesCount != nil {
+		b.PagesCount = *c.PagesCount
+	}
+	if c.Tags != nil {
+		b.Tags = *c.Tags
+	}
+}
+
+// VerificationVerdict - результат автоматической проверки метаданных книги
+type VerificationVerdict struct {
+	Approved bool `json:"approved"`
+	// Confidence - уверенность проверки от 0 до 1
+	Confidence float64 `json:"confidence"`
+	Notes      string  `json:"notes"`
+}
+
+// ProposalTally - итоги голосования; порог считается только по голосам доверенных пользователей
+type ProposalTally struct {
+	Approvals         int `json:"approvals"`
+	Rejections        int `json:"rejections"`
+	TrustedApprovals  int `json:"trusted_approvals"`
+	TrustedRejections int `json:"trusted_rejections"`
+}
+
+// BookProposal - предложение исправить метаданные книги
+type BookProposal struct {
+	ID         string      `json:"id"`
+	BookID     string      `json:"book_id"`
+	ProposerID *string     `json:"proposer_id"`
+	Changes    BookChanges `json:"changes"`
+	Comment    *string     `json:"comment"`
+	// BookVersion - версия книги, к которой относится предложение
+	BookVersion int            `json:"book_version"`
+	Status      ProposalStatus `json:"status"`
+	// Verdict - вердикт автоматической проверки (nil - проверка не выполнялась)
+	Verdict        *VerificationVerdict `json:"ai_verdict"`
+	Votes          ProposalTally        `json:"votes"`
+	ResolvedBy     *string              `json:"resolved_by"`
+	ResolutionNote *string              `json:"resolution_note"`
+	ResolvedAt     *time.Time           `json:"resolved_at"`
+	CreatedAt      time.Time            `json:"created_at"`
+}
+
+// ProposalVoter - данные голосующего для проверки доверия
+type ProposalVoter struct {
+	ID        string
+	Role      UserRole
+	CreatedAt *time.Time
+	Reviews   int
+}
+
+// CreateProposalRequest - DTO предложения исправлений; без changes - подтверждение текущих метаданных
+type CreateProposalRequest struct {
+	Changes BookChanges `json:"changes"`
+	Comment *string     `json:"comment" binding:"omitempty,max=1000"`
+}
+
+// VoteProposalRequest - DTO голоса за предложение
+type VoteProposalRequest struct {
+	Approve *bool `json:"approve" binding:"required"`
+}
+
+// ResolveProposalRequest - DTO решения модератора по предложению
+type ResolveProposalRequest struct {
+	Note *string `json:"note" binding:"omitempty,max=1000"`
+}
+
+// SetVerificationRequest - DTO ручной установки или снятия верификации модератором
+type SetVerificationRequest struct {
+	Verified *bool `json:"verified" binding:"required"`
+	// VerificationType - обязателен при verified=true
+	VerificationType *VerificationType `json:"verification_type" binding:"omitempty,oneof=AI Community"`
+	EditMeta
+}
@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore - корзины в памяти процесса; лимит считается отдельно на каждую реплику
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// bucket - состояние корзины
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewMemoryStore - создание нового MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+// Take - попытка взять токен; новая корзина начинает полной
+func (s *MemoryStore) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+	key = policy.Name + ":" + key
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(policy.Burst), updated: now}
+		s.buckets[key] = b
+	}
+
+	tokens := refill(policy, b.tokens, now.Sub(b.updated))
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+	b.tokens, b.updated = tokens, now
+	return newResult(policy, tokens, allowed), nil
+}
+
+// Prune - удаление простаивающих корзин
+func (s *MemoryStore) Prune(ctx context.Context, idle time.Duration) error {
+	cutoff := time.Now().Add(-idle)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, b := range s.buckets {
+		if b.updated.Before(cutoff) {
+			delete(s.buckets, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// takeQuery - атомарное пополнение и списание токена одним запросом.
+// Конкурентные запросы к одной корзине сериализуются блокировкой строки в ON CONFLICT.
+const takeQuery = `
+	INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+	VALUES ($1, $2::float8 - 1, true, NOW())
+	ON CONFLICT (key) DO UPDATE SET
+		allowed = LEAST($2, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * $3) >= 1,
+		tokens = LEAST($2, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * $3)
+			- CASE WHEN LEAST($2, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * $3) >= 1 THEN 1 ELSE 0 END,
+		updated_at = NOW()
+	RETURNING tokens, allowed`
+
+// PostgresStore - корзины в таблице rate_limit_buckets; лимит общий для всех реплик
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresStore - создание нового PostgresStore
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{pool: pool}
+}
+
+// Take - попытка взять токен; новая корзина начинает полной
+func (s *PostgresStore) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+	var (
+		tokens  float64
+		allowed bool
+	)
+	err := s.pool.QueryRow(ctx, takeQuery, policy.Name+":"+key, float64(policy.Burst), policy.rate()).Scan(&tokens, &allowed)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	return newResult(policy, tokens, allowed), nil
+}
+
+// Prune - удаление простаивающих корзин
+func (s *PostgresStore) Prune(ctx context.Context, idle time.Duration) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM rate_limit_buckets WHERE updated_at < NOW() - $1::interval`, idle)
+	if err != nil {
+		return fmt.Errorf("failed to prune rate limit buckets: %w", err)
+	}
+	return nil
+}
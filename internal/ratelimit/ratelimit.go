@@ -0,0 +1,93 @@
+// Package ratelimit - ограничение частоты запросов алгоритмом token bucket:
+// у каждого клиента корзина емкостью Burst, которая пополняется RequestsPerMinute токенами в минуту,
+// а каждый запрос забирает один токен.
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+)
+
+// Policy - параметры корзины
+type Policy struct {
+	// Name - имя политики; корзины разных политик независимы
+	Name              string
+	RequestsPerMinute int
+	Burst             int
+}
+
+// rate - пополнение в токенах в секунду
+func (p Policy) rate() float64 {
+	return float64(p.RequestsPerMinute) / 60
+}
+
+// FillTime - время, за которое пустая корзина заполняется полностью
+func (p Policy) FillTime() time.Duration {
+	return seconds(float64(p.Burst) / p.rate())
+}
+
+// Result - результат попытки взять токен
+type Result struct {
+	Allowed bool
+	// Limit - емкость корзины
+	Limit int
+	// Remaining - целых токенов осталось после запроса
+	Remaining int
+	// Reset - через сколько корзина снова будет полной
+	Reset time.Duration
+	// RetryAfter - через сколько появится следующий токен (только при отказе)
+	RetryAfter time.Duration
+}
+
+// Store - хранилище корзин
+type Store interface {
+	// Take - попытка взять один токен из корзины key политики policy
+	Take(ctx context.Context, key string, policy Policy) (Result, error)
+
+	// Prune - удаление корзин, не использовавшихся дольше idle (к этому времени они уже полные)
+	Prune(ctx context.Context, idle time.Duration) error
+}
+
+// refill - число токенов через elapsed после состояния tokens
+func refill(policy Policy, tokens float64, elapsed time.Duration) float64 {
+	return math.Min(float64(policy.Burst), tokens+elapsed.Seconds()*policy.rate())
+}
+
+// newResult - Result по числу токенов после попытки
+func newResult(policy Policy, tokens float64, allowed bool) Result {
+	tokens = math.Max(tokens, 0)
+	result := Result{
+		Allowed:   allowed,
+		Limit:     policy.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(policy.Burst) - tokens) / policy.rate()),
+	}
+	if !allowed {
+		result.RetryAfter = seconds((1 - tokens) / policy.rate())
+	}
+	return result
+}
+
+// seconds - длительность из дробного числа секунд
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// RunJanitor - периодическая очистка простаивающих корзин до отмены ctx
+func RunJanitor(ctx context.Context, store Store, interval, idle time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := store.Prune(ctx, idle); err != nil && ctx.Err() == nil {
+			slog.WarnContext(ctx, "failed to prune rate limit buckets", "error", err)
+		}
+	}
+}
@@ -2,11 +2,13 @@ package repositories
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
+	"errors"
 	"strconv"
 
-	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
 	"github.com/tukembaev/bookVisionGo/internal/models"
 	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
 )
@@ -21,9 +23,14 @@ func NewArticleRepository(pool *pgxpool.Pool) interfaces.ArticleRepository {
 	}
 }
 
+// queries - запросы sqlc в транзакции из контекста или через пул
+func (r *ArticleRepository) queries(ctx context.Context) *db.Queries {
+	return db.New(db.ExecutorFrom(ctx, r.pool))
+}
+
 // GetList - получение списка статей
 func (r *ArticleRepository) GetList(ctx context.Context, sortBy, order string, limit string) ([]*models.ArticleListItem, error) {
-	// 1. Белый список полей для сортировки (Защита от SQL-инъекций)
+	// 1. Белый список полей для сортировки (неизвестное поле - сортировка по дате)
 	allowedColumns := map[string]string{
 		"likes":      "likes",
 		"views":      "views",
@@ -36,12 +43,7 @@ func (r *ArticleRepository) GetList(ctx context.Context, sortBy, order string, l
 		column = "created_at" // Сортировка по умолчанию
 	}
 
-	// 2. Валидация направления
-	if order != "asc" {
-		order = "desc" // По умолчанию самые свежие/популярные сверху
-	}
-
-	// 3. Валидация и конвертация limit
+	// 2. Валидация и конвертация limit
 	limitInt := 10 // по умолчанию
 	if limit != "" {
 		if parsedLimit, err := strconv.Atoi(limit); err == nil && parsedLimit > 0 {
@@ -49,50 +51,175 @@ func (r *ArticleRepository) GetList(ctx context.Context, sortBy, order string, l
 		}
 	}
 
-	query := fmt.Sprintf(`SELECT id, title, type, author_id, book_id, excerpt, likes, views, cover_url 
-							FROM articles 
-							ORDER BY %s %s 
-							LIMIT %d`, column, order, limitInt)
-
-	var articles []*models.ArticleListItem
-	err := pgxscan.Select(ctx, r.pool, &articles, query)
+	// 3. По умолчанию самые свежие/популярные сверху
+	rows, err := r.queries(ctx).ListArticles(ctx, db.ListArticlesParams{
+		SortBy:    column,
+		Ascending: order == "asc",
+		RowLimit:  toInt32(limitInt),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to select articles: %w", err)
+		return nil, dbError("failed to select articles", err)
+	}
+
+	articles := make([]*models.ArticleListItem, len(rows))
+	for i, row := range rows {
+		articles[i] = &models.ArticleListItem{
+			ID:        row.ID,
+			Title:     row.Title,
+			Type:      models.ArticleType(row.Type),
+			AuthorID:  row.AuthorID,
+			BookID:    row.BookID,
+			Excerpt:   row.Excerpt,
+			Likes:     intOrZero(row.Likes),
+			Views:     intOrZero(row.Views),
+			CoverURL:  row.CoverURL,
+			UpdatedAt: row.UpdatedAt,
+			Version:   int(row.Version),
+		}
 	}
 	return articles, nil
 }
 
-// GetByID - получение статьи по ID
+// GetByID - получение статьи по ID, в том числе скрытой по жалобам
 func (r *ArticleRepository) GetByID(ctx context.Context, id string) (*models.Article, error) {
-	query := `SELECT id, title, type, author_id, book_id, excerpt, created_at, likes, views, reading_minutes, cover_url, verified, verification_type, no_spoilers, readiness, content 
-				FROM articles 
-			    WHERE id = $1 `
-	var article models.Article
-	err := pgxscan.Get(ctx, r.pool, &article, query, id)
+	row, err := r.queries(ctx).GetArticle(ctx, id)
+	return articleFromRow(row, err)
+}
 
+// GetVisibleByID - получение статьи по ID, если она не скрыта по жалобам
+func (r *ArticleRepository) GetVisibleByID(ctx context.Context, id string) (*models.Article, error) {
+	row, err := r.queries(ctx).GetVisibleArticle(ctx, id)
+	return articleFromRow(row, err)
+}
+
+// articleFromRow - конвертация строки articles в модель (err - ошибка выборки строки)
+func articleFromRow(row db.Article, err error) (*models.Article, error) {
 	if err != nil {
-		if pgxscan.NotFound(err) {
-			return nil, fmt.Errorf("article not found: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, interfaces.ErrArticleNotFound
 		}
-		return nil, fmt.Errorf("failed to get article: %w", err)
+		return nil, dbError("failed to get article", err)
 	}
-	return &article, nil
+
+	article := &models.Article{
+		ID:                  row.ID,
+		Title:               row.Title,
+		Type:                models.ArticleType(row.Type),
+		AuthorID:            row.AuthorID,
+		BookID:              row.BookID,
+		Excerpt:             row.Excerpt,
+		CreatedAt:           timeOrZero(row.CreatedAt),
+		Likes:               intOrZero(row.Likes),
+		Views:               intOrZero(row.Views),
+		ReadingMinutes:      fromInt32Ptr(row.ReadingMinutes),
+		CoverURL:            row.CoverURL,
+		Verified:            boolOrFalse(row.Verified),
+		VerificationType:    row.VerificationType,
+		NoSpoilers:          row.NoSpoilers,
+		ShouldReadReadiness: models.ArticleReadiness(stringOrEmpty(row.Readiness)),
+		UpdatedAt:           row.UpdatedAt,
+		Version:             int(row.Version),
+	}
+	if row.Content != nil {
+		if err := json.Unmarshal(row.Content, &article.Content); err != nil {
+			return nil, dbError("failed to decode article content", err)
+		}
+	}
+	return article, nil
 }
 
 // CreateArticle - создание новой статьи
 func (r *ArticleRepository) CreateArticle(ctx context.Context, article *models.Article) error {
-	// TODO: Реализовать создание статьи
-	return fmt.Errorf("CreateArticle not implemented yet")
+	var content []byte
+	if article.Content != nil {
+		var err error
+		if content, err = json.Marshal(article.Content); err != nil {
+			return dbError("failed to encode article content", err)
+		}
+	}
+
+	row, err := r.queries(ctx).CreateArticle(ctx, db.CreateArticleParams{
+		Title:            article.Title,
+		Type:             string(article.Type),
+		AuthorID:         article.AuthorID,
+		BookID:           article.BookID,
+		Excerpt:          article.Excerpt,
+		ReadingMinutes:   toInt32Ptr(article.ReadingMinutes),
+		CoverURL:         article.CoverURL,
+		Verified:         &article.Verified,
+		VerificationType: article.VerificationType,
+		NoSpoilers:       article.NoSpoilers,
+		Readiness:        readinessOrNil(article.ShouldReadReadiness),
+		Content:          content,
+	})
+	if err != nil {
+		return dbError("failed to create article", err)
+	}
+
+	article.ID = row.ID
+	article.CreatedAt = timeOrZero(row.CreatedAt)
+	article.Version = int(row.Version)
+	article.UpdatedAt = row.UpdatedAt
+	return nil
 }
 
-// Update - обновление статьи
+// Update - обновление статьи с проверкой версии
 func (r *ArticleRepository) Update(ctx context.Context, article *models.Article) error {
-	// TODO: Реализовать обновление статьи
-	return fmt.Errorf("Update not implemented yet")
+	q := r.queries(ctx)
+	row, err := q.UpdateArticle(ctx, db.UpdateArticleParams{
+		ID:               article.ID,
+		Title:            article.Title,
+		Type:             string(article.Type),
+		Excerpt:          article.Excerpt,
+		ReadingMinutes:   toInt32Ptr(article.ReadingMinutes),
+		CoverURL:         article.CoverURL,
+		Verified:         &article.Verified,
+		VerificationType: article.VerificationType,
+		NoSpoilers:       article.NoSpoilers,
+		Readiness:        readinessOrNil(article.ShouldReadReadiness),
+		ExpectedVersion:  toInt32(article.Version),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return versionMismatch(ctx, q.GetArticle, article.ID, interfaces.ErrArticleNotFound, interfaces.ErrArticleVersionConflict)
+	}
+	if err != nil {
+		return dbError("failed to update article", err)
+	}
+
+	article.Version = int(row.Version)
+	article.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+// UpdateCoverURL - замена ссылки на обложку статьи
+func (r *ArticleRepository) UpdateCoverURL(ctx context.Context, id string, coverURL *string) error {
+	rows, err := r.queries(ctx).UpdateArticleCoverURL(ctx, db.UpdateArticleCoverURLParams{ID: id, CoverURL: coverURL})
+	if err != nil {
+		return dbError("failed to update article cover", err)
+	}
+	if rows == 0 {
+		return interfaces.ErrArticleNotFound
+	}
+	return nil
 }
 
-// Delete - удаление статьи
+// Delete - перемещение статьи в корзину
 func (r *ArticleRepository) Delete(ctx context.Context, id string) error {
-	// TODO: Реализовать удаление статьи
-	return fmt.Errorf("Delete not implemented yet")
+	rows, err := r.queries(ctx).DeleteArticle(ctx, id)
+	if err != nil {
+		return dbError("failed to delete article", err)
+	}
+	if rows == 0 {
+		return interfaces.ErrArticleNotFound
+	}
+	return nil
+}
+
+// readinessOrNil - готовность статьи для sqlc (пустое значение - NULL)
+func readinessOrNil(readiness models.ArticleReadiness) *string {
+	if readiness == "" {
+		return nil
+	}
+	value := string(readiness)
+	return &value
 }
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// AuditRepository - реализация репозитория журнала аудита
+type AuditRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAuditRepository - создание нового AuditRepository
+func NewAuditRepository(pool *pgxpool.Pool) interfaces.AuditRepository {
+	return &AuditRepository{
+		pool: pool,
+	}
+}
+
+// queries - запросы sqlc в транзакции из контекста или через пул
+func (r *AuditRepository) queries(ctx context.Context) *db.Queries {
+	return db.New(db.ExecutorFrom(ctx, r.pool))
+}
+
+// Create - добавление записи (в транзакции изменения, которое она описывает)
+func (r *AuditRepository) Create(ctx context.Context, entry *models.AuditEntry) error {
+	err := r.queries(ctx).CreateAuditEntry(ctx, db.CreateAuditEntryParams{
+		ActorID:       entry.ActorID,
+		ActorUsername: entry.ActorUsername,
+		ActorRole:     entry.ActorRole,
+		Action:        string(entry.Action),
+		TargetType:    string(entry.TargetType),
+		TargetID:      entry.TargetID,
+		Before:        entry.Before,
+		After:         entry.After,
+		IP:            entry.IP,
+		RequestID:     entry.RequestID,
+	})
+	if err != nil {
+		return dbError("failed to create audit entry", err)
+	}
+	return nil
+}
+
+// List - записи от новых к старым; beforeID - курсор: только записи с меньшим ID
+func (r *AuditRepository) List(ctx context.Context, filter *models.AuditFilter, beforeID *int64, limit, offset int) ([]*models.AuditEntry, error) {
+	rows, err := r.queries(ctx).ListAuditEntries(ctx, db.ListAuditEntriesParams{
+		ActorID:     filter.ActorID,
+		Action:      filter.Action,
+		TargetType:  filter.TargetType,
+		TargetID:    filter.TargetID,
+		CreatedFrom: filter.From,
+		CreatedTo:   filter.To,
+		BeforeID:    beforeID,
+		RowLimit:    toInt32(limit),
+		RowOffset:   toInt32(offset),
+	})
+	if err != nil {
+		return nil, dbError("failed to select audit log", err)
+	}
+
+	entries := make([]*models.AuditEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = &models.AuditEntry{
+			ID:            row.ID,
+			ActorID:       row.ActorID,
+			ActorUsername: row.ActorUsername,
+			ActorRole:     row.ActorRole,
+			Action:        models.AuditAction(row.Action),
+			TargetType:    models.AuditTarget(row.TargetType),
+			TargetID:      row.TargetID,
+			Before:        row.Before,
+			After:         row.After,
+			IP:            row.IP,
+			RequestID:     row.RequestID,
+			CreatedAt:     row.CreatedAt,
+		}
+	}
+	return entries, nil
+}
@@ -2,9 +2,13 @@ package repositories
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
 	"github.com/tukembaev/bookVisionGo/internal/models"
 	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
 )
@@ -21,99 +25,124 @@ func NewBookRepository(pool *pgxpool.Pool) interfaces.BookRepository {
 	}
 }
 
+// queries - запросы sqlc в транзакции из контекста или через пул
+func (r *BookRepository) queries(ctx context.Context) *db.Queries {
+	return db.New(db.ExecutorFrom(ctx, r.pool))
+}
+
 // Create - создание новой книги
 func (r *BookRepository) Create(ctx context.Context, book *models.Book) error {
-	query := `
-		INSERT INTO books (
-			title, original_title, author, year, genres, age_rating,
-			author_country, description, cover_url, pages_count, tags,
-			verified, verification_type, created_by, created_at,
-			average_rating, rating_count
-		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
-		) RETURNING id`
-
-	var id string
-	err := r.pool.QueryRow(ctx, query,
-		book.Title, book.OriginalTitle, book.Author, book.Year,
-		book.Genres, book.AgeRating, book.AuthorCountry, book.Description,
-		book.CoverURL, book.PagesCount, book.Tags, book.Verified,
-		book.VerificationType, book.CreatedBy, book.CreatedAt,
-		book.AverageRating, book.RatingCount,
-	).Scan(&id)
+	return insertBook(ctx, r.queries(ctx), book)
+}
+
+// CreateWithParts - создание книги вместе с частями в одной транзакции
+func (r *BookRepository) CreateWithParts(ctx context.Context, book *models.Book, parts []*models.BookPart) error {
+	return pgx.BeginFunc(ctx, db.ExecutorFrom(ctx, r.pool), func(tx pgx.Tx) error {
+		q := db.New(tx)
+		if err := insertBook(ctx, q, book); err != nil {
+			return err
+		}
+		for _, part := range parts {
+			part.BookID = book.ID
+			if err := insertPart(ctx, q, part); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
 
+// insertBook - вставка книги через пул или транзакцию
+func insertBook(ctx context.Context, q *db.Queries, book *models.Book) error {
+	if book.CreatedAt.IsZero() {
+		book.CreatedAt = time.Now()
+	}
+
+	row, err := q.CreateBook(ctx, db.CreateBookParams{
+		Title:            book.Title,
+		OriginalTitle:    book.OriginalTitle,
+		Author:           book.Author,
+		Year:             toInt32Ptr(book.Year),
+		Genres:           book.Genres,
+		AgeRating:        book.AgeRating,
+		AuthorCountry:    book.AuthorCountry,
+		Description:      book.Description,
+		CoverURL:         book.CoverURL,
+		PagesCount:       toInt32(book.PagesCount),
+		Tags:             book.Tags,
+		Verified:         &book.Verified,
+		VerificationType: book.VerificationType,
+		CreatedBy:        book.CreatedBy,
+		CreatedAt:        &book.CreatedAt,
+		AverageRating:    &book.AverageRating,
+		RatingCount:      toInt32Ptr(&book.RatingCount),
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create book: %w", err)
+		return dbError("failed to create book", err)
 	}
 
-	book.ID = id
+	book.ID = row.ID
+	book.Version = int(row.Version)
+	book.UpdatedAt = row.UpdatedAt
 	return nil
 }
 
 // GetByID - получение книги по ID
 func (r *BookRepository) GetByID(ctx context.Context, id string) (*models.Book, error) {
-	query := `
-		SELECT id, title, original_title, author, year, genres, age_rating,
-			   author_country, description, cover_url, pages_count, tags,
-			   verified, verification_type, created_by, created_at,
-			   average_rating, rating_count
-		FROM books 
-		WHERE id = $1`
-
-	var book models.Book
-	err := r.pool.QueryRow(ctx, query, id).Scan(
-		&book.ID, &book.Title, &book.OriginalTitle, &book.Author, &book.Year,
-		&book.Genres, &book.AgeRating, &book.AuthorCountry, &book.Description,
-		&book.CoverURL, &book.PagesCount, &book.Tags, &book.Verified,
-		&book.VerificationType, &book.CreatedBy, &book.CreatedAt,
-		&book.AverageRating, &book.RatingCount,
-	)
+	row, err := r.queries(ctx).GetBook(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, interfaces.ErrBookNotFound
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get book by id: %w", err)
+		return nil, dbError("failed to get book by id", err)
 	}
 
-	return &book, nil
+	return bookFromRow(row), nil
 }
 
-// Update - обновление книги
+// Update - обновление книги; рейтинг не записывается, а берется актуальный из базы
 func (r *BookRepository) Update(ctx context.Context, book *models.Book) error {
-	query := `
-        UPDATE books SET 
-            title = $2, original_title = $3, author = $4, year = $5, genres = $6, 
-            age_rating = $7, author_country = $8, description = $9, cover_url = $10, 
-            pages_count = $11, tags = $12, verified = $13, verification_type = $14,
-            average_rating = $15, rating_count = $16
-        WHERE id = $1`
-
-	cmdTag, err := r.pool.Exec(ctx, query,
-		book.ID, book.Title, book.OriginalTitle, book.Author, book.Year,
-		book.Genres, book.AgeRating, book.AuthorCountry, book.Description,
-		book.CoverURL, book.PagesCount, book.Tags, book.Verified,
-		book.VerificationType, book.AverageRating, book.RatingCount,
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to update book: %w", err)
+	q := r.queries(ctx)
+	row, err := q.UpdateBook(ctx, db.UpdateBookParams{
+		ID:               book.ID,
+		Title:            book.Title,
+		OriginalTitle:    book.OriginalTitle,
+		Author:           book.Author,
+		Year:             toInt32Ptr(book.Year),
+		Genres:           book.Genres,
+		AgeRating:        book.AgeRating,
+		AuthorCountry:    book.AuthorCountry,
+		Description:      book.Description,
+		CoverURL:         book.CoverURL,
+		PagesCount:       toInt32(book.PagesCount),
+		Tags:             book.Tags,
+		Verified:         &book.Verified,
+		VerificationType: book.VerificationType,
+		ExpectedVersion:  toInt32(book.Version),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return versionMismatch(ctx, q.GetBook, book.ID, interfaces.ErrBookNotFound, interfaces.ErrBookVersionConflict)
 	}
-
-	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("book with id %s not found", book.ID)
+	if err != nil {
+		return dbError("failed to update book", err)
 	}
 
+	book.Version = int(row.Version)
+	book.UpdatedAt = row.UpdatedAt
+	book.AverageRating = floatOrZero(row.AverageRating)
+	book.RatingCount = intOrZero(row.RatingCount)
 	return nil
 }
 
-// Delete - удаление книги
+// Delete - перемещение книги в корзину
 func (r *BookRepository) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM books WHERE id = $1`
-
-	cmdTag, err := r.pool.Exec(ctx, query, id)
+	rows, err := r.queries(ctx).DeleteBook(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to delete book: %w", err)
+		return dbError("failed to delete book", err)
 	}
 
-	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("book with id %s not found", id)
+	if rows == 0 {
+		return interfaces.ErrBookNotFound
 	}
 
 	return nil
@@ -121,36 +150,17 @@ func (r *BookRepository) Delete(ctx context.Context, id string) error {
 
 // List - получение списка книг с фильтрацией и пагинацией
 func (r *BookRepository) List(ctx context.Context, filters interfaces.BookFilters, limit, offset int) ([]*models.Book, error) {
-	// Реальный SQL запрос к БД
-	query := `
-		SELECT id, title, original_title, author, year, genres, age_rating,
-			   author_country, description, cover_url, pages_count, tags,
-			   verified, verification_type, created_by, created_at,
-			   average_rating, rating_count
-		FROM books 
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2`
-
-	rows, err := r.pool.Query(ctx, query, limit, offset)
+	rows, err := r.queries(ctx).ListBooks(ctx, db.ListBooksParams{
+		Limit:  toInt32(limit),
+		Offset: toInt32(offset),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to query books: %w", err)
-	}
-	defer rows.Close()
-
-	var books []*models.Book
-	for rows.Next() {
-		var book models.Book
-		err := rows.Scan(
-			&book.ID, &book.Title, &book.OriginalTitle, &book.Author, &book.Year,
-			&book.Genres, &book.AgeRating, &book.AuthorCountry, &book.Description,
-			&book.CoverURL, &book.PagesCount, &book.Tags, &book.Verified,
-			&book.VerificationType, &book.CreatedBy, &book.CreatedAt,
-			&book.AverageRating, &book.RatingCount,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan book: %w", err)
-		}
-		books = append(books, &book)
+		return nil, dbError("failed to query books", err)
+	}
+
+	books := make([]*models.Book, len(rows))
+	for i, row := range rows {
+		books[i] = bookFromRow(row)
 	}
 
 	return books, nil
@@ -158,47 +168,24 @@ func (r *BookRepository) List(ctx context.Context, filters interfaces.BookFilter
 
 // Count - подсчет количества книг с фильтрацией
 func (r *BookRepository) Count(ctx context.Context, filters interfaces.BookFilters) (int, error) {
-	// Реальный SQL запрос для подсчета
-	query := `SELECT COUNT(*) FROM books`
-
-	var count int
-	err := r.pool.QueryRow(ctx, query).Scan(&count)
+	count, err := r.queries(ctx).CountBooks(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count books: %w", err)
+		return 0, dbError("failed to count books", err)
 	}
 
-	return count, nil
+	return int(count), nil
 }
 
 // GetParts - получение частей книги
 func (r *BookRepository) GetParts(ctx context.Context, bookID string) ([]*models.BookPart, error) {
-	query := `
-		SELECT id, book_id, title, content, order_num, page_start, page_end, mood_tags, average_rating
-		FROM book_parts 
-		WHERE book_id = $1
-		ORDER BY order_num`
-
-	rows, err := r.pool.Query(ctx, query, bookID)
+	rows, err := r.queries(ctx).ListBookParts(ctx, &bookID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query book parts: %w", err)
-	}
-	defer rows.Close()
-
-	var parts []*models.BookPart
-	for rows.Next() {
-		var part models.BookPart
-		err := rows.Scan(
-			&part.ID, &part.BookID, &part.Title, &part.Content, &part.OrderNum,
-			&part.PageStart, &part.PageEnd, &part.MoodTags, &part.AverageRating,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan book part: %w", err)
-		}
-		parts = append(parts, &part)
+		return nil, dbError("failed to query book parts", err)
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating book parts: %w", err)
+	parts := make([]*models.BookPart, len(rows))
+	for i, row := range rows {
+		parts[i] = bookPartFromRow(row)
 	}
 
 	return parts, nil
@@ -206,24 +193,140 @@ func (r *BookRepository) GetParts(ctx context.Context, bookID string) ([]*models
 
 // GetPartByID - получение части книги по ID
 func (r *BookRepository) GetPartByID(ctx context.Context, partID string) (*models.BookPart, error) {
-	// TODO: Реализовать получение части книги
-	return nil, fmt.Errorf("GetPartByID not implemented yet")
+	row, err := r.queries(ctx).GetBookPart(ctx, partID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, interfaces.ErrBookPartNotFound
+	}
+	if err != nil {
+		return nil, dbError("failed to get book part", err)
+	}
+
+	return bookPartFromRow(row), nil
 }
 
 // CreatePart - создание новой части книги
 func (r *BookRepository) CreatePart(ctx context.Context, part *models.BookPart) error {
-	// TODO: Реализовать создание части книги
-	return fmt.Errorf("CreatePart not implemented yet")
+	return insertPart(ctx, r.queries(ctx), part)
+}
+
+// insertPart - вставка части книги через пул или транзакцию
+func insertPart(ctx context.Context, q *db.Queries, part *models.BookPart) error {
+	if part.ID == "" {
+		part.ID = uuid.NewString()
+	}
+
+	row, err := q.CreateBookPart(ctx, db.CreateBookPartParams{
+		ID:            part.ID,
+		BookID:        &part.BookID,
+		Title:         part.Title,
+		Content:       part.Content,
+		OrderNum:      toInt32(part.OrderNum),
+		PageStart:     toInt32Ptr(part.PageStart),
+		PageEnd:       toInt32Ptr(part.PageEnd),
+		MoodTags:      part.MoodTags,
+		AverageRating: part.AverageRating,
+	})
+	if err != nil {
+		return dbError("failed to create book part", err)
+	}
+
+	part.ID = row.ID
+	part.Version = int(row.Version)
+	part.UpdatedAt = row.UpdatedAt
+	return nil
 }
 
 // UpdatePart - обновление части книги
 func (r *BookRepository) UpdatePart(ctx context.Context, part *models.BookPart) error {
-	// TODO: Реализовать обновление части книги
-	return fmt.Errorf("UpdatePart not implemented yet")
+	q := r.queries(ctx)
+	row, err := q.UpdateBookPart(ctx, db.UpdateBookPartParams{
+		ID:              part.ID,
+		Title:           part.Title,
+		Content:         part.Content,
+		OrderNum:        toInt32(part.OrderNum),
+		PageStart:       toInt32Ptr(part.PageStart),
+		PageEnd:         toInt32Ptr(part.PageEnd),
+		MoodTags:        part.MoodTags,
+		AverageRating:   part.AverageRating,
+		ExpectedVersion: toInt32(part.Version),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return versionMismatch(ctx, q.GetBookPart, part.ID, interfaces.ErrBookPartNotFound, interfaces.ErrBookPartVersionConflict)
+	}
+	if err != nil {
+		return dbError("failed to update book part", err)
+	}
+
+	part.Version = int(row.Version)
+	part.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+// UpdateCoverURL - замена ссылки на обложку книги
+func (r *BookRepository) UpdateCoverURL(ctx context.Context, id string, coverURL *string) error {
+	rows, err := r.queries(ctx).UpdateBookCoverURL(ctx, db.UpdateBookCoverURLParams{ID: id, CoverURL: coverURL})
+	if err != nil {
+		return dbError("failed to update book cover", err)
+	}
+	if rows == 0 {
+		return interfaces.ErrBookNotFound
+	}
+	return nil
 }
 
 // DeletePart - удаление части книги
 func (r *BookRepository) DeletePart(ctx context.Context, partID string) error {
-	// TODO: Реализовать удаление части книги
-	return fmt.Errorf("DeletePart not implemented yet")
+	rows, err := r.queries(ctx).DeleteBookPart(ctx, partID)
+	if err != nil {
+		return dbError("failed to delete book part", err)
+	}
+
+	if rows == 0 {
+		return interfaces.ErrBookPartNotFound
+	}
+
+	return nil
+}
+
+// bookFromRow - модель книги из строки sqlc
+func bookFromRow(row db.Book) *models.Book {
+	return &models.Book{
+		ID:               row.ID,
+		Title:            row.Title,
+		OriginalTitle:    row.OriginalTitle,
+		Author:           row.Author,
+		Year:             fromInt32Ptr(row.Year),
+		Genres:           row.Genres,
+		AgeRating:        row.AgeRating,
+		AuthorCountry:    row.AuthorCountry,
+		Description:      row.Description,
+		CoverURL:         row.CoverURL,
+		PagesCount:       int(row.PagesCount),
+		Verified:         boolOrFalse(row.Verified),
+		VerificationType: row.VerificationType,
+		CreatedBy:        row.CreatedBy,
+		CreatedAt:        timeOrZero(row.CreatedAt),
+		UpdatedAt:        row.UpdatedAt,
+		Version:          int(row.Version),
+		Tags:             row.Tags,
+		AverageRating:    floatOrZero(row.AverageRating),
+		RatingCount:      intOrZero(row.RatingCount),
+	}
+}
+
+// bookPartFromRow - модель части книги из строки sqlc
+func bookPartFromRow(row db.BookPart) *models.BookPart {
+	return &models.BookPart{
+		ID:            row.ID,
+		BookID:        stringOrEmpty(row.BookID),
+		Title:         row.Title,
+		Content:       row.Content,
+		OrderNum:      int(row.OrderNum),
+		PageStart:     fromInt32Ptr(row.PageStart),
+		PageEnd:       fromInt32Ptr(row.PageEnd),
+		MoodTags:      row.MoodTags,
+		AverageRating: row.AverageRating,
+		UpdatedAt:     row.UpdatedAt,
+		Version:       int(row.Version),
+	}
 }
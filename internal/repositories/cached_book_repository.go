@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/cache"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/metrics"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// partOverhead - примерный вес части книги без текста (поля, заголовки, служебные структуры)
+const partOverhead = 256
+
+// BookCacheOptions - параметры кэша книг
+type BookCacheOptions struct {
+	TTL           time.Duration // время жизни записи; ограничивает устаревание при изменениях в обход API и на других репликах
+	MaxBooks      int           // максимум книг в кэше
+	MaxPartsBytes int64         // максимум суммарного текста глав в кэше
+}
+
+// CachedBookRepository - BookRepository с LRU кэшем в памяти процесса для GetByID и GetParts.
+// Изменения через этот репозиторий сбрасывают записи книги после фиксации транзакции
+// (иначе конкурентное чтение успело бы закэшировать старую строку); изменения в обход него
+// (пересчет рейтинга отзывами, другие реплики) видны по истечении TTL.
+// Запросы внутри транзакции идут мимо кэша, чтобы не сохранить незафиксированные данные.
+type CachedBookRepository struct {
+	interfaces.BookRepository
+	books *cache.LRU[string, *models.Book]
+	parts *cache.LRU[string, []*models.BookPart]
+}
+
+// NewCachedBookRepository - обертка repo с кэшем
+func NewCachedBookRepository(repo interfaces.BookRepository, opts BookCacheOptions) *CachedBookRepository {
+	return &CachedBookRepository{
+		BookRepository: repo,
+		books:          cache.NewLRU[string, *models.Book](int64(opts.MaxBooks), opts.TTL, nil),
+		parts:          cache.NewLRU[string, []*models.BookPart](opts.MaxPartsBytes, opts.TTL, partsCost),
+	}
+}
+
+// GetByID - книга из кэша или из базы данных
+func (r *CachedBookRepository) GetByID(ctx context.Context, id string) (*models.Book, error) {
+	if db.InTx(ctx) {
+		return r.BookRepository.GetByID(ctx, id)
+	}
+	if book, ok := r.books.Get(id); ok {
+		metrics.CacheRequests.WithLabelValues("books", "hit").Inc()
+		return cloneBook(book), nil
+	}
+	metrics.CacheRequests.WithLabelValues("books", "miss").Inc()
+
+	book, err := r.BookRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.books.Add(id, cloneBook(book))
+	return book, nil
+}
+
+// GetParts - части книги из кэша или из базы данных
+func (r *CachedBookRepository) GetParts(ctx context.Context, bookID string) ([]*models.BookPart, error) {
+	if db.InTx(ctx) {
+		return r.BookRepository.GetParts(ctx, bookID)
+	}
+	if parts, ok := r.parts.Get(bookID); ok {
+		metrics.CacheRequests.WithLabelValues("book_parts", "hit").Inc()
+		return cloneParts(parts), nil
+	}
+	metrics.CacheRequests.WithLabelValues("book_parts", "miss").Inc()
+
+	parts, err := r.BookRepository.GetParts(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	r.parts.Add(bookID, cloneParts(parts))
+	return parts, nil
+}
+
+// Update - обновление книги со сбросом кэша
+func (r *CachedBookRepository) Update(ctx context.Context, book *models.Book) error {
+	defer r.afterCommit(ctx, func() { r.books.Remove(book.ID) })
+	return r.BookRepository.Update(ctx, book)
+}
+
+// UpdateCoverURL - замена обложки со сбросом кэша
+func (r *CachedBookRepository) UpdateCoverURL(ctx context.Context, id string, coverURL *string) error {
+	defer r.afterCommit(ctx, func() { r.books.Remove(id) })
+	return r.BookRepository.UpdateCoverURL(ctx, id, coverURL)
+}
+
+// Delete - удаление книги со сбросом кэша книги и ее частей
+func (r *CachedBookRepository) Delete(ctx context.Context, id string) error {
+	defer r.afterCommit(ctx, func() { r.Invalidate(id) })
+	return r.BookRepository.Delete(ctx, id)
+}
+
+// CreateWithParts - создание книги с частями со сбросом кэша частей
+func (r *CachedBookRepository) CreateWithParts(ctx context.Context, book *models.Book, parts []*models.BookPart) error {
+	err := r.BookRepository.CreateWithParts(ctx, book, parts)
+	r.afterCommit(ctx, func() { r.parts.Remove(book.ID) })
+	return err
+}
+
+// CreatePart - создание части со сбросом кэша частей книги
+func (r *CachedBookRepository) CreatePart(ctx context.Context, part *models.BookPart) error {
+	defer r.afterCommit(ctx, func() { r.parts.Remove(part.BookID) })
+	return r.BookRepository.CreatePart(ctx, part)
+}
+
+// UpdatePart - обновление части со сбросом кэша частей книги
+func (r *CachedBookRepository) UpdatePart(ctx context.Context, part *models.BookPart) error {
+	bookID := r.partBookID(ctx, part.ID, part.BookID)
+	defer r.afterCommit(ctx, func() { r.invalidateParts(bookID) })
+	return r.BookRepository.UpdatePart(ctx, part)
+}
+
+// DeletePart - удаление части со сбросом кэша частей книги
+func (r *CachedBookRepository) DeletePart(ctx context.Context, partID string) error {
+	bookID := r.partBookID(ctx, partID, "")
+	defer r.afterCommit(ctx, func() { r.invalidateParts(bookID) })
+	return r.BookRepository.DeletePart(ctx, partID)
+}
+
+// Invalidate - сброс кэша книги и ее частей
+func (r *CachedBookRepository) Invalidate(bookID string) {
+	r.books.Remove(bookID)
+	r.parts.Remove(bookID)
+}
+
+// afterCommit - сброс кэша после фиксации транзакции из ctx или сразу вне транзакции.
+// Сброс внутри транзакции не помогает: до COMMIT конкурентный GetByID читает
+// прежнюю зафиксированную строку и снова кладет ее в кэш на весь TTL.
+func (r *CachedBookRepository) afterCommit(ctx context.Context, invalidate func()) {
+	db.AfterCommit(ctx, invalidate)
+}
+
+// partBookID - книга, которой принадлежит часть partID; пустая строка, если часть не найдена
+func (r *CachedBookRepository) partBookID(ctx context.Context, partID, bookID string) string {
+	if bookID != "" {
+		return bookID
+	}
+	part, err := r.BookRepository.GetPartByID(ctx, partID)
+	if err != nil {
+		return ""
+	}
+	return part.BookID
+}
+
+// invalidateParts - сброс кэша частей книги; неизвестная книга сбрасывает весь кэш частей
+func (r *CachedBookRepository) invalidateParts(bookID string) {
+	if bookID == "" {
+		r.parts.Purge()
+		return
+	}
+	r.parts.Remove(bookID)
+}
+
+// cloneBook - копия книги: вызывающий код может менять полученную модель
+func cloneBook(book *models.Book) *models.Book {
+	clone := *book
+	clone.Genres = slices.Clone(book.Genres)
+	clone.Tags = slices.Clone(book.Tags)
+	return &clone
+}
+
+// cloneParts - копия частей книги
+func cloneParts(parts []*models.BookPart) []*models.BookPart {
+	clones := make([]*models.BookPart, len(parts))
+	for i, part := range parts {
+		clone := *part
+		clone.MoodTags = slices.Clone(part.MoodTags)
+		clones[i] = &clone
+	}
+	return clones
+}
+
+// partsCost - вес частей книги в байтах
+func partsCost(parts []*models.BookPart) int64 {
+	var cost int64
+	for _, part := range parts {
+		cost += int64(len(part.Content)+len(part.Title)) + partOverhead
+	}
+	return cost
+}
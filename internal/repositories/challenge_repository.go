@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// ChallengeRepository - реализация репозитория для челленджей
+type ChallengeRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewChallengeRepository - создание нового ChallengeRepository
+func NewChallengeRepository(pool *pgxpool.Pool) interfaces.ChallengeRepository {
+	return &ChallengeRepository{
+		pool: pool,
+	}
+}
+
+// GetByID - получение челленджа по ID
+func (r *ChallengeRepository) GetByID(ctx context.Context, id string) (*models.Challenge, error) {
+	query := `
+		SELECT id, title, description, type, target_count, reward_points, created_at
+		FROM challenges
+		WHERE id = $1`
+
+	var challenge models.Challenge
+	if err := pgxscan.Get(ctx, db.ExecutorFrom(ctx, r.pool), &challenge, query, id); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, interfaces.ErrChallengeNotFound
+		}
+		return nil, dbError("failed to get challenge", err)
+	}
+	return &challenge, nil
+}
+
+// Join - присоединение пользователя к челленджу; created=false если уже участвует
+func (r *ChallengeRepository) Join(ctx context.Context, userID, challengeID string) (*models.UserChallengeProgress, bool, error) {
+	insertQuery := `
+		INSERT INTO user_challenge_progress (user_id, challenge_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, challenge_id) DO NOTHING
+		RETURNING id, user_id, challenge_id, status, progress_count, started_at, completed_at`
+
+	var progress models.UserChallengeProgress
+	err := pgxscan.Get(ctx, db.ExecutorFrom(ctx, r.pool), &progress, insertQuery, userID, challengeID)
+	if err == nil {
+		return &progress, true, nil
+	}
+	if !pgxscan.NotFound(err) {
+		return nil, false, dbError("failed to join challenge", err)
+	}
+
+	// Пользователь уже участвует - возвращаем существующий прогресс
+	selectQuery := `
+		SELECT id, user_id, challenge_id, status, progress_count, started_at, completed_at
+		FROM user_challenge_progress
+		WHERE user_id = $1 AND challenge_id = $2`
+
+	if err := pgxscan.Get(ctx, db.ExecutorFrom(ctx, r.pool), &progress, selectQuery, userID, challengeID); err != nil {
+		return nil, false, dbError("failed to get challenge progress", err)
+	}
+	return &progress, false, nil
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// CharacterRepository - реализация репозитория для персонажей
+type CharacterRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCharacterRepository - создание нового CharacterRepository
+func NewCharacterRepository(pool *pgxpool.Pool) interfaces.CharacterRepository {
+	return &CharacterRepository{
+		pool: pool,
+	}
+}
+
+// GetProfile - получение профиля персонажа по ID, в том числе скрытого по жалобам
+func (r *CharacterRepository) GetProfile(ctx context.Context, id string) (*models.CharacterProfile, error) {
+	return r.getProfile(ctx, id, true)
+}
+
+// GetVisibleProfile - получение профиля персонажа по ID, если он не скрыт по жалобам
+func (r *CharacterRepository) GetVisibleProfile(ctx context.Context, id string) (*models.CharacterProfile, error) {
+	return r.getProfile(ctx, id, false)
+}
+
+// getProfile - получение профиля персонажа; includeHidden - вместе со скрытым по жалобам
+func (r *CharacterRepository) getProfile(ctx context.Context, id string, includeHidden bool) (*models.CharacterProfile, error) {
+	profile := &models.CharacterProfile{}
+	var updatedAt *time.Time
+	err := db.ExecutorFrom(ctx, r.pool).QueryRow(ctx, `
+		SELECT id, book_id, name, aliases, image_url, age, height, weight, social_status,
+			description_no_spoilers, description_spoilers, quotes_no_spoilers, quotes_spoilers,
+			updated_at, version
+		FROM character_profiles
+		WHERE id = $1 AND ($2 OR hidden_at IS NULL)`,
+		id, includeHidden,
+	).Scan(
+		&profile.ID, &profile.BookID, &profile.Name, &profile.Aliases, &profile.ImageURL,
+		&profile.Age, &profile.Height, &profile.Weight, &profile.SocialStatus,
+		&profile.DescriptionNoSpoilers, &profile.DescriptionSpoilers,
+		&profile.QuotesNoSpoilers, &profile.QuotesSpoilers,
+		&updatedAt, &profile.Version,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, interfaces.ErrCharacterNotFound
+	}
+	if err != nil {
+		return nil, dbError("failed to get character profile", err)
+	}
+
+	profile.UpdatedAt = timeOrZero(updatedAt)
+	return profile, nil
+}
+
+// UpdateProfile - обновление профиля персонажа с проверкой версии
+func (r *CharacterRepository) UpdateProfile(ctx context.Context, profile *models.CharacterProfile) error {
+	executor := db.ExecutorFrom(ctx, r.pool)
+	var updatedAt *time.Time
+	err := executor.QueryRow(ctx, `
+		UPDATE character_profiles SET
+			name = $2, aliases = $3, image_url = $4, age = $5, height = $6, weight = $7,
+			social_status = $8, description_no_spoilers = $9, description_spoilers = $10,
+			quotes_no_spoilers = $11, quotes_spoilers = $12, version = version + 1
+		WHERE id = $1 AND version = $13
+		RETURNING version, updated_at`,
+		profile.ID, profile.Name, profile.Aliases, profile.ImageURL, profile.Age, profile.Height,
+		profile.Weight, profile.SocialStatus, profile.DescriptionNoSpoilers, profile.DescriptionSpoilers,
+		profile.QuotesNoSpoilers, profile.QuotesSpoilers, profile.Version,
+	).Scan(&profile.Version, &updatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return versionMismatch(ctx, func(ctx context.Context, id string) (string, error) {
+			var found string
+			err := executor.QueryRow(ctx, `SELECT id FROM character_profiles WHERE id = $1`, id).Scan(&found)
+			return found, err
+		}, profile.ID, interfaces.ErrCharacterNotFound, interfaces.ErrCharacterVersionConflict)
+	}
+	if err != nil {
+		return dbError("failed to update character profile", err)
+	}
+
+	profile.UpdatedAt = timeOrZero(updatedAt)
+	return nil
+}
+
+// CreateIllustration - создание иллюстрации и привязка к профилю в одной транзакции
+func (r *CharacterRepository) CreateIllustration(ctx context.Context, illustration *models.CharacterIllustration) error {
+	return pgx.BeginFunc(ctx, db.ExecutorFrom(ctx, r.pool), func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			INSERT INTO character_illustrations (image_url, author_name)
+			VALUES ($1, $2)
+			RETURNING id`,
+			illustration.ImageURL, illustration.AuthorName,
+		).Scan(&illustration.ID)
+		if err != nil {
+			return dbError("failed to create illustration", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO character_profile_illustrations (character_profile_id, illustration_id)
+			VALUES ($1, $2)`,
+			illustration.CharacterID, illustration.ID,
+		)
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				return interfaces.ErrCharacterNotFound
+			}
+			return dbError("failed to link illustration", err)
+		}
+		return nil
+	})
+}
+
+// isForeignKeyViolation - проверка нарушения внешнего ключа PostgreSQL
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
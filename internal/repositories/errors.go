@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+)
+
+// dbError - ошибка запроса с контекстом операции; нарушения ограничений
+// и отсутствие строки переводятся в доменные ошибки (db.TranslateError)
+func dbError(operation string, err error) error {
+	return fmt.Errorf("%s: %w", operation, db.TranslateError(err))
+}
+
+// versionMismatch - причина, по которой условный UPDATE ... WHERE version = $n не изменил строку:
+// строки нет (notFound) или ее версия уже другая (conflict)
+func versionMismatch[T any](ctx context.Context, get func(context.Context, string) (T, error), id string, notFound, conflict error) error {
+	_, err := get(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return notFound
+	}
+	if err != nil {
+		return dbError("failed to check row version", err)
+	}
+	return conflict
+}
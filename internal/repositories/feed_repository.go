@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// FeedRepository - реализация репозитория для событий активности и ленты
+type FeedRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewFeedRepository - создание нового FeedRepository
+func NewFeedRepository(pool *pgxpool.Pool) interfaces.FeedRepository {
+	return &FeedRepository{
+		pool: pool,
+	}
+}
+
+// Publish - сохранение события и раскладка его по лентам подписчиков
+func (r *FeedRepository) Publish(ctx context.Context, event *models.ActivityEvent, fanOut bool) error {
+	return pgx.BeginFunc(ctx, db.ExecutorFrom(ctx, r.pool), func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			INSERT INTO activity_events (actor_id, type, target_id, book_id, payload)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, created_at`,
+			event.ActorID, event.Type, event.TargetID, event.BookID, event.Payload,
+		).Scan(&event.ID, &event.CreatedAt)
+		if err != nil {
+			return dbError("failed to insert activity event", err)
+		}
+
+		if !fanOut {
+			return nil
+		}
+
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO feed_items (user_id, event_id, created_at)
+			SELECT follower_id, $1, $2 FROM user_follows WHERE followee_id = $3
+			ON CONFLICT (user_id, event_id) DO NOTHING`,
+			event.ID, event.CreatedAt, event.ActorID,
+		); err != nil {
+			return dbError("failed to fan out activity event", err)
+		}
+		return nil
+	})
+}
+
+// ListForUser - получение ленты пользователя, начиная после курсора.
+// Видимость автора проверяется и при чтении, чтобы смена настроек сразу скрывала старые события.
+// Так же при чтении отбрасываются события, чей объект скрыт модератором, удален или относится
+// к удаленной книге: payload хранит копию текста, и без проверки она осталась бы в лентах.
+func (r *FeedRepository) ListForUser(ctx context.Context, userID string, cursor *interfaces.FeedCursor, limit int) ([]*models.FeedItem, error) {
+	query := `
+		SELECT e.id, e.actor_id, e.type, e.target_id, e.book_id, e.payload, f.created_at,
+			   u.username AS actor_username, u.avatar_url AS actor_avatar_url
+		FROM feed_items f
+		JOIN activity_events e ON e.id = f.event_id
+		JOIN users u ON u.id = e.actor_id AND u.deleted_at IS NULL
+		WHERE f.user_id = $1
+		  AND u.profile_visibility <> 'private'
+		  AND u.activity_visibility <> 'private'
+		  AND NOT EXISTS (SELECT 1 FROM books b WHERE b.id = e.book_id AND b.deleted_at IS NOT NULL)
+		  AND CASE e.type
+			  WHEN 'review_created' THEN EXISTS (
+				  SELECT 1 FROM reviews rv WHERE rv.id = e.target_id::uuid AND rv.hidden_at IS NULL)
+			  WHEN 'quote_saved' THEN EXISTS (
+				  SELECT 1 FROM quotes q WHERE q.id = e.target_id::uuid AND q.hidden_at IS NULL)
+			  WHEN 'article_published' THEN EXISTS (
+				  SELECT 1 FROM articles a WHERE a.id = e.target_id::uuid AND a.hidden_at IS NULL AND a.deleted_at IS NULL)
+			  ELSE TRUE
+		  END`
+	args := []interface{}{userID}
+
+	if cursor != nil {
+		query += ` AND (f.created_at, f.event_id) < ($2, $3)`
+		args = append(args, cursor.CreatedAt, cursor.EventID)
+	}
+
+	query += fmt.Sprintf(` ORDER BY f.created_at DESC, f.event_id DESC LIMIT $%d`, len(args)+1)
+	args = append(args, limit)
+
+	var items []*models.FeedItem
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &items, query, args...); err != nil {
+		return nil, dbError("failed to select feed", err)
+	}
+	return items, nil
+}
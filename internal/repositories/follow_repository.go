@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// FollowRepository - реализация репозитория для подписок
+type FollowRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewFollowRepository - создание нового FollowRepository
+func NewFollowRepository(pool *pgxpool.Pool) interfaces.FollowRepository {
+	return &FollowRepository{
+		pool: pool,
+	}
+}
+
+// Follow - подписка followerID на followeeID (повторная подписка не является ошибкой)
+func (r *FollowRepository) Follow(ctx context.Context, followerID, followeeID string) error {
+	query := `
+		INSERT INTO user_follows (follower_id, followee_id)
+		VALUES ($1, $2)
+		ON CONFLICT (follower_id, followee_id) DO NOTHING`
+
+	if _, err := db.ExecutorFrom(ctx, r.pool).Exec(ctx, query, followerID, followeeID); err != nil {
+		return dbError("failed to follow user", err)
+	}
+	return nil
+}
+
+// Unfollow - отписка и очистка ленты от событий автора
+func (r *FollowRepository) Unfollow(ctx context.Context, followerID, followeeID string) error {
+	return pgx.BeginFunc(ctx, db.ExecutorFrom(ctx, r.pool), func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx,
+			`DELETE FROM user_follows WHERE follower_id = $1 AND followee_id = $2`,
+			followerID, followeeID,
+		); err != nil {
+			return dbError("failed to unfollow user", err)
+		}
+
+		if _, err := tx.Exec(ctx, `
+			DELETE FROM feed_items
+			WHERE user_id = $1
+			  AND event_id IN (SELECT id FROM activity_events WHERE actor_id = $2)`,
+			followerID, followeeID,
+		); err != nil {
+			return dbError("failed to clean up feed", err)
+		}
+		return nil
+	})
+}
+
+// IsFollowing - проверка подписки followerID на followeeID
+func (r *FollowRepository) IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM user_follows WHERE follower_id = $1 AND followee_id = $2)`
+
+	var exists bool
+	if err := db.ExecutorFrom(ctx, r.pool).QueryRow(ctx, query, followerID, followeeID).Scan(&exists); err != nil {
+		return false, dbError("failed to check follow", err)
+	}
+	return exists, nil
+}
+
+// ListFollowers - получение подписчиков пользователя
+func (r *FollowRepository) ListFollowers(ctx context.Context, userID string, limit, offset int) ([]*models.User, error) {
+	query := `
+		SELECT u.id, u.username, u.email, u.password_hash, u.avatar_url, u.role, u.created_at,
+			   u.books_read, u.reviews_count, u.likes_received, u.profile_visibility, u.activity_visibility
+		FROM user_follows f
+		JOIN users u ON u.id = f.follower_id AND u.deleted_at IS NULL
+		WHERE f.followee_id = $1
+		ORDER BY f.created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var users []*models.User
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &users, query, userID, limit, offset); err != nil {
+		return nil, dbError("failed to list followers", err)
+	}
+	return users, nil
+}
+
+// ListFollowing - получение подписок пользователя
+func (r *FollowRepository) ListFollowing(ctx context.Context, userID string, limit, offset int) ([]*models.User, error) {
+	query := `
+		SELECT u.id, u.username, u.email, u.password_hash, u.avatar_url, u.role, u.created_at,
+			   u.books_read, u.reviews_count, u.likes_received, u.profile_visibility, u.activity_visibility
+		FROM user_follows f
+		JOIN users u ON u.id = f.followee_id AND u.deleted_at IS NULL
+		WHERE f.follower_id = $1
+		ORDER BY f.created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var users []*models.User
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &users, query, userID, limit, offset); err != nil {
+		return nil, dbError("failed to list following", err)
+	}
+	return users, nil
+}
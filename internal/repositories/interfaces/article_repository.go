@@ -3,14 +3,28 @@ package interfaces
 import (
 	"context"
 
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
 	"github.com/tukembaev/bookVisionGo/internal/models"
 )
 
+var (
+	// ErrArticleNotFound - статья не найдена
+	ErrArticleNotFound = apperrors.NotFound("article not found")
+	// ErrArticleVersionConflict - статья изменена после чтения (версия устарела)
+	ErrArticleVersionConflict = apperrors.Conflict("article was modified by another request")
+)
+
 type ArticleRepository interface {
 	GetList(ctx context.Context, sortBy, order string, limit string) ([]*models.ArticleListItem, error)
+	// GetByID - статья по ID, в том числе скрытая по жалобам (правка и модерация)
 	GetByID(ctx context.Context, id string) (*models.Article, error)
+	// GetVisibleByID - статья по ID для публичного чтения: скрытая по жалобам - ErrArticleNotFound
+	GetVisibleByID(ctx context.Context, id string) (*models.Article, error)
 
 	CreateArticle(ctx context.Context, article *models.Article) error
+	// Update - обновление статьи, если ее версия в базе равна article.Version
 	Update(ctx context.Context, article *models.Article) error
+	// UpdateCoverURL - замена ссылки на обложку статьи
+	UpdateCoverURL(ctx context.Context, id string, coverURL *string) error
 	Delete(ctx context.Context, id string) error
 }
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// AuditRepository - интерфейс для работы с журналом аудита (только добавление и чтение)
+type AuditRepository interface {
+	// Create - добавление записи (в транзакции изменения, которое она описывает)
+	Create(ctx context.Context, entry *models.AuditEntry) error
+
+	// List - записи от новых к старым; beforeID - курсор: только записи с меньшим ID
+	List(ctx context.Context, filter *models.AuditFilter, beforeID *int64, limit, offset int) ([]*models.AuditEntry, error)
+}
@@ -2,21 +2,42 @@ package interfaces
 
 import (
 	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
 	"github.com/tukembaev/bookVisionGo/internal/models"
 )
 
+var (
+	// ErrBookNotFound - книга не найдена
+	ErrBookNotFound = apperrors.NotFound("book not found")
+	// ErrBookPartNotFound - часть книги не найдена
+	ErrBookPartNotFound = apperrors.NotFound("book part not found")
+	// ErrBookVersionConflict - книга изменена после чтения (версия устарела)
+	ErrBookVersionConflict = apperrors.Conflict("book was modified by another request")
+	// ErrBookPartVersionConflict - часть книги изменена после чтения (версия устарела)
+	ErrBookPartVersionConflict = apperrors.Conflict("book part was modified by another request")
+)
+
 // BookRepository - интерфейс для работы с книгами
 type BookRepository interface {
 	// Create - создание новой книги
 	Create(ctx context.Context, book *models.Book) error
 	
+	// CreateWithParts - создание книги вместе с частями в одной транзакции
+	CreateWithParts(ctx context.Context, book *models.Book, parts []*models.BookPart) error
+	
 	// GetByID - получение книги по ID
 	GetByID(ctx context.Context, id string) (*models.Book, error)
 	
-	// Update - обновление данных книги
+	// Update - обновление данных книги, если ее версия в базе равна book.Version;
+	// рейтинг не записывается (его пересчитывает ReviewRepository.RefreshBookRating). При успехе book.Version,
+	// book.UpdatedAt и рейтинг получают актуальные значения
 	Update(ctx context.Context, book *models.Book) error
 	
-	// Delete - удаление книги
+	// UpdateCoverURL - замена ссылки на обложку книги
+	UpdateCoverURL(ctx context.Context, id string, coverURL *string) error
+	
+	// Delete - перемещение книги в корзину
 	Delete(ctx context.Context, id string) error
 	
 	// List - получение списка книг с фильтрацией и пагинацией
@@ -34,7 +55,7 @@ type BookRepository interface {
 	// CreatePart - создание новой части книги
 	CreatePart(ctx context.Context, part *models.BookPart) error
 	
-	// UpdatePart - обновление части книги
+	// UpdatePart - обновление части книги, если ее версия в базе равна part.Version
 	UpdatePart(ctx context.Context, part *models.BookPart) error
 	
 	// DeletePart - удаление части книги
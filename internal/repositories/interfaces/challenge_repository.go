@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// ErrChallengeNotFound - челлендж не найден
+var ErrChallengeNotFound = apperrors.NotFound("challenge not found")
+
+// ChallengeRepository - интерфейс для работы с челленджами
+type ChallengeRepository interface {
+	// GetByID - получение челленджа по ID
+	GetByID(ctx context.Context, id string) (*models.Challenge, error)
+
+	// Join - присоединение пользователя к челленджу; created=false если уже участвует
+	Join(ctx context.Context, userID, challengeID string) (progress *models.UserChallengeProgress, created bool, err error)
+}
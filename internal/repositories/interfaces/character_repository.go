@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+var (
+	// ErrCharacterNotFound - профиль персонажа не найден
+	ErrCharacterNotFound = apperrors.NotFound("character not found")
+	// ErrCharacterVersionConflict - профиль персонажа изменен после чтения (версия устарела)
+	ErrCharacterVersionConflict = apperrors.Conflict("character was modified by another request")
+)
+
+// CharacterRepository - интерфейс для работы с профилями персонажей и иллюстрациями
+type CharacterRepository interface {
+	// GetProfile - получение профиля персонажа по ID, в том числе скрытого по жалобам
+	// (правка, откат и модерация)
+	GetProfile(ctx context.Context, id string) (*models.CharacterProfile, error)
+
+	// GetVisibleProfile - получение профиля персонажа по ID для публичного чтения:
+	// скрытый по жалобам профиль - ErrCharacterNotFound
+	GetVisibleProfile(ctx context.Context, id string) (*models.CharacterProfile, error)
+
+	// UpdateProfile - обновление профиля персонажа, если его версия не изменилась с момента чтения
+	// (profile.Version - прочитанная версия); при успехе Version и UpdatedAt обновляются,
+	// иначе ErrCharacterVersionConflict
+	UpdateProfile(ctx context.Context, profile *models.CharacterProfile) error
+
+	// CreateIllustration - создание иллюстрации и привязка ее к профилю персонажа
+	CreateIllustration(ctx context.Context, illustration *models.CharacterIllustration) error
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// FeedRepository - интерфейс для работы с событиями активности и лентой
+type FeedRepository interface {
+	// Publish - сохранение события и раскладка его по лентам подписчиков
+	Publish(ctx context.Context, event *models.ActivityEvent, fanOut bool) error
+
+	// ListForUser - получение ленты пользователя, начиная после курсора
+	ListForUser(ctx context.Context, userID string, cursor *FeedCursor, limit int) ([]*models.FeedItem, error)
+}
+
+// FeedCursor - позиция в ленте для курсорной пагинации
+type FeedCursor struct {
+	CreatedAt time.Time
+	EventID   string
+}
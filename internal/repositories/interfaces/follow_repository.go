@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// FollowRepository - интерфейс для работы с подписками пользователей
+type FollowRepository interface {
+	// Follow - подписка followerID на followeeID (повторная подписка не является ошибкой)
+	Follow(ctx context.Context, followerID, followeeID string) error
+
+	// Unfollow - отписка и очистка ленты от событий автора
+	Unfollow(ctx context.Context, followerID, followeeID string) error
+
+	// IsFollowing - проверка подписки followerID на followeeID
+	IsFollowing(ctx context.Context, followerID, followeeID string) (bool, error)
+
+	// ListFollowers - получение подписчиков пользователя
+	ListFollowers(ctx context.Context, userID string, limit, offset int) ([]*models.User, error)
+
+	// ListFollowing - получение подписок пользователя
+	ListFollowing(ctx context.Context, userID string, limit, offset int) ([]*models.User, error)
+}
@@ -0,0 +1,45 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+var (
+	// ErrProposalNotFound - предложение не найдено (или книга удалена)
+	ErrProposalNotFound = apperrors.NotFound("proposal not found")
+	// ErrVoterNotFound - голосующий не найден или удален
+	ErrVoterNotFound = apperrors.Unauthorized("account no longer exists")
+)
+
+// ProposalRepository - интерфейс для работы с предложениями правок книг и голосами за них
+type ProposalRepository interface {
+	// Create - сохранение предложения; заполняет ID, Status и CreatedAt
+	Create(ctx context.Context, proposal *models.BookProposal) error
+
+	// GetByID - предложение с итогами голосования
+	GetByID(ctx context.Context, id string) (*models.BookProposal, error)
+
+	// Lock - блокировка предложения до конца транзакции; возвращает текущий статус
+	Lock(ctx context.Context, id string) (models.ProposalStatus, error)
+
+	// ListByBook - предложения книги от новых к старым (пустой status - все)
+	ListByBook(ctx context.Context, bookID string, status models.ProposalStatus, limit, offset int) ([]*models.BookProposal, error)
+
+	// ListByStatus - предложения в статусе status от давних к новым (очередь модераторов)
+	ListByStatus(ctx context.Context, status models.ProposalStatus, limit, offset int) ([]*models.BookProposal, error)
+
+	// Vote - голос пользователя (повторный голос заменяет прежний)
+	Vote(ctx context.Context, proposalID, userID string, approve, trusted bool) error
+
+	// SetStatus - смена статуса открытого предложения
+	SetStatus(ctx context.Context, id string, status models.ProposalStatus) error
+
+	// Resolve - закрытие предложения; resolvedBy - модератор (nil - решение голосованием)
+	Resolve(ctx context.Context, id string, status models.ProposalStatus, resolvedBy, note *string) error
+
+	// GetVoter - роль, возраст аккаунта и число рецензий пользователя
+	GetVoter(ctx context.Context, userID string) (*models.ProposalVoter, error)
+}
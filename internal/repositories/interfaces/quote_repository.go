@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// QuoteRepository - интерфейс для работы с цитатами
+type QuoteRepository interface {
+	// Create - сохранение новой цитаты
+	Create(ctx context.Context, quote *models.Quote) error
+
+	// ListByUser - получение цитат пользователя; includeHidden - вместе со скрытыми
+	// по жалобам (выгрузка собственных данных)
+	ListByUser(ctx context.Context, userID string, includeHidden bool, limit, offset int) ([]*models.Quote, error)
+}
@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// ReadingRepository - интерфейс для работы с прогрессом чтения, сессиями и избранным
+type ReadingRepository interface {
+	// GetProgress - получение прогресса чтения книги пользователем (nil, если чтение не начато)
+	GetProgress(ctx context.Context, userID, bookID string) (*models.UserBookProgress, error)
+
+	// UpsertProgress - создание или обновление прогресса чтения
+	UpsertProgress(ctx context.Context, progress *models.UserBookProgress) error
+
+	// ListProgressByUser - получение прогресса чтения пользователя
+	ListProgressByUser(ctx context.Context, userID string, limit, offset int) ([]*models.UserBookProgress, error)
+
+	// ListSessionsByUser - получение сессий чтения пользователя
+	ListSessionsByUser(ctx context.Context, userID string, limit, offset int) ([]*models.ReadingSession, error)
+
+	// ListFavoritesByUser - получение избранных книг пользователя
+	ListFavoritesByUser(ctx context.Context, userID string, limit, offset int) ([]*models.UserFavorite, error)
+
+	// AddFavorite - добавление книги в избранное (повторное добавление возвращает существующую запись)
+	AddFavorite(ctx context.Context, userID, bookID string) (*models.UserFavorite, error)
+
+	// RemoveFavorite - удаление книги из избранного
+	RemoveFavorite(ctx context.Context, userID, bookID string) error
+}
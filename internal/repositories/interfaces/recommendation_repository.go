@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// RecommendationRepository - интерфейс для работы с рекомендациями книг
+type RecommendationRepository interface {
+	// LoadBookFeatures - жанры, теги и mood_tags частей для всех книг
+	LoadBookFeatures(ctx context.Context) ([]*models.BookFeatures, error)
+
+	// LoadUserSignals - положительные сигналы пользователей по книгам
+	LoadUserSignals(ctx context.Context) ([]*models.UserBookSignal, error)
+
+	// ReplaceSimilarities - атомарная замена таблицы похожести книг
+	ReplaceSimilarities(ctx context.Context, similarities []*models.BookSimilarity) error
+
+	// ListSimilar - похожие книги для книги
+	ListSimilar(ctx context.Context, bookID string, limit int) ([]*models.RecommendedBook, error)
+
+	// ListForUser - рекомендации для пользователя на основе его положительных сигналов
+	ListForUser(ctx context.Context, userID string, limit int) ([]*models.RecommendedBook, error)
+
+	// ListPopularForUser - популярные книги, которые пользователь еще не отмечал
+	ListPopularForUser(ctx context.Context, userID string, limit int) ([]*models.RecommendedBook, error)
+}
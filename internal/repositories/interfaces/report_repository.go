@@ -0,0 +1,54 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+var (
+	// ErrReportNotFound - жалоба не найдена
+	ErrReportNotFound = apperrors.NotFound("report not found")
+	// ErrReportTargetNotFound - объект жалобы не найден (или удален)
+	ErrReportTargetNotFound = apperrors.NotFound("reported content not found")
+	// ErrAlreadyReported - у пользователя уже есть открытая жалоба на этот объект
+	ErrAlreadyReported = apperrors.Conflict("you have already reported this content")
+)
+
+// ReportRepository - интерфейс для работы с жалобами на пользовательский контент
+type ReportRepository interface {
+	// Create - сохранение жалобы; заполняет ID, Status и CreatedAt
+	Create(ctx context.Context, report *models.ContentReport) error
+
+	// GetByID - жалоба с числом открытых жалоб на тот же объект
+	GetByID(ctx context.Context, id string) (*models.ContentReport, error)
+
+	// Lock - блокировка жалобы до конца транзакции
+	Lock(ctx context.Context, id string) (*models.ContentReport, error)
+
+	// LockOpenByTarget - блокировка открытых жалоб на объект; возвращает ID жалобы -> кто взял ее в работу
+	LockOpenByTarget(ctx context.Context, targetType models.ReportTargetType, targetID string) (map[string]*string, error)
+
+	// List - жалобы по фильтру от давних к новым
+	List(ctx context.Context, filter *models.ReportFilter, limit, offset int) ([]*models.ContentReport, error)
+
+	// CountOpenReporters - число разных пользователей с открытыми жалобами на объект
+	CountOpenReporters(ctx context.Context, targetType models.ReportTargetType, targetID string) (int, error)
+
+	// HasResolved - была ли жалоба на объект признана обоснованной
+	HasResolved(ctx context.Context, targetType models.ReportTargetType, targetID string) (bool, error)
+
+	// SetClaim - взятие жалобы в работу модератором (nil - жалоба отпущена)
+	SetClaim(ctx context.Context, id string, moderatorID *string) error
+
+	// ResolveOpenByTarget - закрытие всех открытых жалоб на объект с решением status
+	ResolveOpenByTarget(ctx context.Context, targetType models.ReportTargetType, targetID string, status models.ReportStatus, resolvedBy string, note *string) error
+
+	// LockTarget - автор и состояние объекта жалобы с блокировкой строки объекта до конца транзакции:
+	// жалобы и решения по одному объекту выполняются по очереди
+	LockTarget(ctx context.Context, targetType models.ReportTargetType, targetID string) (*models.ReportTarget, error)
+
+	// SetTargetHidden - скрытие объекта из публичных ответов или его возврат
+	SetTargetHidden(ctx context.Context, targetType models.ReportTargetType, targetID string, hidden bool) error
+}
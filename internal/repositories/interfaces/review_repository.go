@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// ReviewRepository - интерфейс для работы с отзывами
+type ReviewRepository interface {
+	// Create - создание нового отзыва
+	Create(ctx context.Context, review *models.Review) error
+
+	// RefreshBookRating - пересчет среднего рейтинга книги по отзывам
+	RefreshBookRating(ctx context.Context, bookID string) error
+
+	// ListByUser - получение отзывов пользователя; includeHidden - вместе со скрытыми
+	// по жалобам (выгрузка собственных данных)
+	ListByUser(ctx context.Context, userID string, includeHidden bool, limit, offset int) ([]*models.Review, error)
+}
+
+// ErrReviewExists - пользователь уже оставил отзыв на книгу
+var ErrReviewExists = apperrors.Conflict("review for this book already exists")
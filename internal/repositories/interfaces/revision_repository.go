@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// ErrRevisionNotFound - ревизия не найдена
+var ErrRevisionNotFound = apperrors.NotFound("revision not found")
+
+// RevisionRepository - интерфейс для работы с историей правок каталога
+type RevisionRepository interface {
+	// Create - запись ревизии
+	Create(ctx context.Context, revision *models.Revision) error
+
+	// CreateBaseline - запись исходного состояния сущности (без автора и изменений), если его еще нет
+	CreateBaseline(ctx context.Context, revision *models.Revision) error
+
+	// Exists - есть ли у сущности хотя бы одна ревизия
+	Exists(ctx context.Context, entity models.RevisionEntity, entityID string) (bool, error)
+
+	// List - ревизии сущности от новых к старым, без снимков состояния
+	List(ctx context.Context, entity models.RevisionEntity, entityID string, limit, offset int) ([]*models.Revision, error)
+
+	// Get - ревизия сущности по номеру версии
+	Get(ctx context.Context, entity models.RevisionEntity, entityID string, version int) (*models.Revision, error)
+}
@@ -0,0 +1,67 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// ShelfRepository - интерфейс для работы с полками пользователя
+type ShelfRepository interface {
+	// GetStatus - получение встроенной полки книги у пользователя (nil, если книга не на полке)
+	GetStatus(ctx context.Context, userID, bookID string) (*models.ShelfBook, error)
+
+	// SetStatus - перемещение книги на встроенную полку
+	SetStatus(ctx context.Context, entry *models.ShelfBook) error
+
+	// RemoveStatus - удаление книги со встроенной полки
+	RemoveStatus(ctx context.Context, userID, bookID string) error
+
+	// ListByStatus - получение книг на встроенной полке
+	ListByStatus(ctx context.Context, userID string, status models.ShelfStatus, limit, offset int) ([]*models.ShelfBook, error)
+
+	// CountByStatus - количество книг на каждой встроенной полке
+	CountByStatus(ctx context.Context, userID string) ([]*models.ShelfStatusCount, error)
+
+	// ListShelves - получение пользовательских полок
+	ListShelves(ctx context.Context, userID string) ([]*models.Shelf, error)
+
+	// GetShelf - получение пользовательской полки по ID
+	GetShelf(ctx context.Context, id string) (*models.Shelf, error)
+
+	// CreateShelf - создание пользовательской полки в конце списка
+	CreateShelf(ctx context.Context, shelf *models.Shelf) error
+
+	// UpdateShelf - обновление названия и позиции полки
+	UpdateShelf(ctx context.Context, shelf *models.Shelf) error
+
+	// DeleteShelf - удаление полки вместе с ее книгами
+	DeleteShelf(ctx context.Context, id string) error
+
+	// ListItems - получение книг на пользовательской полке
+	ListItems(ctx context.Context, shelfID string, limit, offset int) ([]*models.ShelfItem, error)
+
+	// GetItem - получение книги на пользовательской полке
+	GetItem(ctx context.Context, shelfID, bookID string) (*models.ShelfItem, error)
+
+	// AddItem - добавление книги в конец пользовательской полки
+	AddItem(ctx context.Context, item *models.ShelfItem) error
+
+	// UpdateItem - обновление заметки и позиции книги на полке
+	UpdateItem(ctx context.Context, item *models.ShelfItem) error
+
+	// RemoveItem - удаление книги с пользовательской полки
+	RemoveItem(ctx context.Context, shelfID, bookID string) error
+}
+
+var (
+	// ErrShelfNotFound - полка не найдена
+	ErrShelfNotFound = apperrors.NotFound("shelf not found")
+	// ErrShelfExists - полка с таким названием уже существует
+	ErrShelfExists = apperrors.Conflict("shelf with this name already exists")
+	// ErrShelfItemNotFound - книги нет на полке
+	ErrShelfItemNotFound = apperrors.NotFound("book is not on this shelf")
+	// ErrShelfItemExists - книга уже на полке
+	ErrShelfItemExists = apperrors.Conflict("book is already on this shelf")
+)
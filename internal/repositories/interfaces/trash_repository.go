@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// ErrTrashItemNotFound - удаленная сущность не найдена в корзине
+var ErrTrashItemNotFound = apperrors.NotFound("trash item not found")
+
+// TrashRepository - интерфейс для работы с мягко удаленными сущностями
+type TrashRepository interface {
+	// List - удаленные сущности от недавно удаленных к давним (пустой kind - все типы)
+	List(ctx context.Context, kind models.TrashKind, limit, offset int) ([]*models.TrashItem, error)
+
+	// Lock - строка удаленной сущности в JSON с блокировкой до конца транзакции (снимок для журнала)
+	Lock(ctx context.Context, kind models.TrashKind, id string) (json.RawMessage, error)
+
+	// Restore - снятие отметки об удалении; возвращает восстановленную строку в JSON
+	Restore(ctx context.Context, kind models.TrashKind, id string) (json.RawMessage, error)
+
+	// Purge - окончательное удаление сущности из корзины
+	Purge(ctx context.Context, kind models.TrashKind, id string) error
+
+	// PurgeDeletedBefore - окончательное удаление всех сущностей, удаленных раньше before; возвращает число удаленных строк
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+}
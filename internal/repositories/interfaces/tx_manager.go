@@ -0,0 +1,14 @@
+package interfaces
+
+import "context"
+
+// TxManager - выполнение нескольких вызовов репозиториев в одной транзакции.
+// Репозитории, вызванные с контекстом из fn, работают внутри этой транзакции;
+// вложенный вызов создает точку сохранения.
+type TxManager interface {
+	// WithinTx - выполнение fn в транзакции READ COMMITTED
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// WithinSerializableTx - выполнение fn в транзакции SERIALIZABLE с повтором при конфликте
+	WithinSerializableTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
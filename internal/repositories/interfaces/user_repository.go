@@ -2,10 +2,19 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
 	"github.com/tukembaev/bookVisionGo/internal/models"
 )
 
+var (
+	// ErrUserNotFound - пользователь не найден
+	ErrUserNotFound = apperrors.NotFound("user not found")
+	// ErrInvalidCredentials - неверное имя пользователя или пароль
+	ErrInvalidCredentials = apperrors.Unauthorized("invalid credentials")
+)
+
 // UserRepository - интерфейс для работы с пользователями
 type UserRepository interface {
 	// Create - создание нового пользователя
@@ -20,9 +29,18 @@ type UserRepository interface {
 	// Update - обновление данных пользователя
 	Update(ctx context.Context, user *models.User) error
 
-	// Delete - удаление пользователя
+	// UpdateAvatarURL - замена ссылки на аватар пользователя
+	UpdateAvatarURL(ctx context.Context, id string, avatarURL *string) error
+
+	// Delete - перемещение пользователя в корзину
 	Delete(ctx context.Context, id string) error
 
+	// AddStrike - предупреждение пользователю; возвращает новое число предупреждений
+	AddStrike(ctx context.Context, id string) (int, error)
+
+	// SetSuspension - блокировка пользователя до until (nil - снятие блокировки)
+	SetSuspension(ctx context.Context, id string, until *time.Time) error
+
 	// List - получение списка пользователей с пагинацией
 	List(ctx context.Context, limit, offset int) ([]*models.User, error)
 
@@ -0,0 +1,223 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// ProposalRepository - реализация репозитория предложений правок книг
+type ProposalRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewProposalRepository - создание нового ProposalRepository
+func NewProposalRepository(pool *pgxpool.Pool) interfaces.ProposalRepository {
+	return &ProposalRepository{
+		pool: pool,
+	}
+}
+
+// queries - запросы sqlc в транзакции из контекста или через пул
+func (r *ProposalRepository) queries(ctx context.Context) *db.Queries {
+	return db.New(db.ExecutorFrom(ctx, r.pool))
+}
+
+// Create - сохранение предложения; заполняет ID, Status и CreatedAt
+func (r *ProposalRepository) Create(ctx context.Context, proposal *models.BookProposal) error {
+	changes, err := json.Marshal(proposal.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode proposal changes: %w", err)
+	}
+
+	params := db.CreateBookProposalParams{
+		BookID:      proposal.BookID,
+		ProposerID:  proposal.ProposerID,
+		Changes:     changes,
+		Comment:     proposal.Comment,
+		BookVersion: toInt32(proposal.BookVersion),
+	}
+	if v := proposal.Verdict; v != nil {
+		params.AIApproved = &v.Approved
+		params.AIConfidence = &v.Confidence
+		params.AINotes = &v.Notes
+	}
+
+	row, err := r.queries(ctx).CreateBookProposal(ctx, params)
+	if err != nil {
+		return dbError("failed to create proposal", err)
+	}
+	proposal.ID = row.ID
+	proposal.Status = models.ProposalStatus(row.Status)
+	proposal.CreatedAt = row.CreatedAt
+	return nil
+}
+
+// GetByID - предложение с итогами голосования
+func (r *ProposalRepository) GetByID(ctx context.Context, id string) (*models.BookProposal, error) {
+	row, err := r.queries(ctx).GetBookProposal(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, interfaces.ErrProposalNotFound
+	}
+	if err != nil {
+		return nil, dbError("failed to get proposal", err)
+	}
+	return proposalFromRow(row)
+}
+
+// Lock - блокировка предложения до конца транзакции; возвращает текущий статус
+func (r *ProposalRepository) Lock(ctx context.Context, id string) (models.ProposalStatus, error) {
+	status, err := r.queries(ctx).LockBookProposal(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", interfaces.ErrProposalNotFound
+	}
+	if err != nil {
+		return "", dbError("failed to lock proposal", err)
+	}
+	return models.ProposalStatus(status), nil
+}
+
+// ListByBook - предложения книги от новых к старым (пустой status - все)
+func (r *ProposalRepository) ListByBook(ctx context.Context, bookID string, status models.ProposalStatus, limit, offset int) ([]*models.BookProposal, error) {
+	params := db.ListBookProposalsParams{
+		BookID:    bookID,
+		RowLimit:  toInt32(limit),
+		RowOffset: toInt32(offset),
+	}
+	if status != "" {
+		s := string(status)
+		params.Status = &s
+	}
+
+	rows, err := r.queries(ctx).ListBookProposals(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to select proposals", err)
+	}
+
+	proposals := make([]*models.BookProposal, len(rows))
+	for i, row := range rows {
+		if proposals[i], err = proposalFromRow(db.GetBookProposalRow(row)); err != nil {
+			return nil, err
+		}
+	}
+	return proposals, nil
+}
+
+// ListByStatus - предложения в статусе status от давних к новым (очередь модераторов)
+func (r *ProposalRepository) ListByStatus(ctx context.Context, status models.ProposalStatus, limit, offset int) ([]*models.BookProposal, error) {
+	rows, err := r.queries(ctx).ListBookProposalsByStatus(ctx, db.ListBookProposalsByStatusParams{
+		Status:    string(status),
+		RowLimit:  toInt32(limit),
+		RowOffset: toInt32(offset),
+	})
+	if err != nil {
+		return nil, dbError("failed to select proposals", err)
+	}
+
+	proposals := make([]*models.BookProposal, len(rows))
+	for i, row := range rows {
+		if proposals[i], err = proposalFromRow(db.GetBookProposalRow(row)); err != nil {
+			return nil, err
+		}
+	}
+	return proposals, nil
+}
+
+// Vote - голос пользователя (повторный голос заменяет прежний)
+func (r *ProposalRepository) Vote(ctx context.Context, proposalID, userID string, approve, trusted bool) error {
+	err := r.queries(ctx).UpsertBookProposalVote(ctx, db.UpsertBookProposalVoteParams{
+		ProposalID: proposalID,
+		UserID:     userID,
+		Approve:    approve,
+		Trusted:    trusted,
+	})
+	if err != nil {
+		return dbError("failed to save proposal vote", err)
+	}
+	return nil
+}
+
+// SetStatus - смена статуса открытого предложения
+func (r *ProposalRepository) SetStatus(ctx context.Context, id string, status models.ProposalStatus) error {
+	err := r.queries(ctx).SetBookProposalStatus(ctx, db.SetBookProposalStatusParams{
+		ID:     id,
+		Status: string(status),
+	})
+	if err != nil {
+		return dbError("failed to update proposal status", err)
+	}
+	return nil
+}
+
+// Resolve - закрытие предложения; resolvedBy - модератор (nil - решение голосованием)
+func (r *ProposalRepository) Resolve(ctx context.Context, id string, status models.ProposalStatus, resolvedBy, note *string) error {
+	err := r.queries(ctx).ResolveBookProposal(ctx, db.ResolveBookProposalParams{
+		ID:             id,
+		Status:         string(status),
+		ResolvedBy:     resolvedBy,
+		ResolutionNote: note,
+	})
+	if err != nil {
+		return dbError("failed to resolve proposal", err)
+	}
+	return nil
+}
+
+// GetVoter - роль, возраст аккаунта и число рецензий пользователя
+func (r *ProposalRepository) GetVoter(ctx context.Context, userID string) (*models.ProposalVoter, error) {
+	row, err := r.queries(ctx).GetProposalVoter(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, interfaces.ErrVoterNotFound
+	}
+	if err != nil {
+		return nil, dbError("failed to get voter", err)
+	}
+	return &models.ProposalVoter{
+		ID:        row.ID,
+		Role:      row.Role,
+		CreatedAt: row.CreatedAt,
+		Reviews:   int(row.Reviews),
+	}, nil
+}
+
+// proposalFromRow - модель предложения из строки sqlc
+func proposalFromRow(row db.GetBookProposalRow) (*models.BookProposal, error) {
+	proposal := &models.BookProposal{
+		ID:          row.ID,
+		BookID:      row.BookID,
+		ProposerID:  row.ProposerID,
+		Comment:     row.Comment,
+		BookVersion: int(row.BookVersion),
+		Status:      models.ProposalStatus(row.Status),
+		Votes: models.ProposalTally{
+			Approvals:         int(row.Approvals),
+			Rejections:        int(row.Rejections),
+			TrustedApprovals:  int(row.TrustedApprovals),
+			TrustedRejections: int(row.TrustedRejections),
+		},
+		ResolvedBy:     row.ResolvedBy,
+		ResolutionNote: row.ResolutionNote,
+		ResolvedAt:     row.ResolvedAt,
+		CreatedAt:      row.CreatedAt,
+	}
+	if err := unmarshalJSONB(row.Changes, &proposal.Changes); err != nil {
+		return nil, err
+	}
+	if row.AIApproved != nil {
+		proposal.Verdict = &models.VerificationVerdict{Approved: *row.AIApproved}
+		if row.AIConfidence != nil {
+			proposal.Verdict.Confidence = *row.AIConfidence
+		}
+		if row.AINotes != nil {
+			proposal.Verdict.Notes = *row.AINotes
+		}
+	}
+	return proposal, nil
+}
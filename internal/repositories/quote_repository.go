@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// QuoteRepository - реализация репозитория для цитат
+type QuoteRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewQuoteRepository - создание нового QuoteRepository
+func NewQuoteRepository(pool *pgxpool.Pool) interfaces.QuoteRepository {
+	return &QuoteRepository{
+		pool: pool,
+	}
+}
+
+// Create - сохранение новой цитаты
+func (r *QuoteRepository) Create(ctx context.Context, quote *models.Quote) error {
+	query := `
+		INSERT INTO quotes (user_id, book_id, part_id, text)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+
+	err := db.ExecutorFrom(ctx, r.pool).QueryRow(ctx, query, quote.UserID, quote.BookID, quote.PartID, quote.Text).
+		Scan(&quote.ID, &quote.CreatedAt)
+	if err != nil {
+		return dbError("failed to create quote", err)
+	}
+
+	return nil
+}
+
+// ListByUser - получение цитат пользователя; includeHidden - вместе со скрытыми по жалобам
+func (r *QuoteRepository) ListByUser(ctx context.Context, userID string, includeHidden bool, limit, offset int) ([]*models.Quote, error) {
+	query := `
+		SELECT q.id, q.user_id, q.book_id, q.part_id, q.text, q.created_at
+		FROM quotes q
+		JOIN books b ON b.id = q.book_id AND b.deleted_at IS NULL
+		WHERE q.user_id = $1 AND ($2 OR q.hidden_at IS NULL)
+		ORDER BY q.created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	var quotes []*models.Quote
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &quotes, query, userID, includeHidden, limit, offset); err != nil {
+		return nil, dbError("failed to select user quotes", err)
+	}
+	return quotes, nil
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// ReadingRepository - реализация репозитория для прогресса чтения, сессий и избранного
+type ReadingRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewReadingRepository - создание нового ReadingRepository
+func NewReadingRepository(pool *pgxpool.Pool) interfaces.ReadingRepository {
+	return &ReadingRepository{
+		pool: pool,
+	}
+}
+
+// GetProgress - получение прогресса чтения книги пользователем (nil, если чтение не начато)
+func (r *ReadingRepository) GetProgress(ctx context.Context, userID, bookID string) (*models.UserBookProgress, error) {
+	query := `
+		SELECT id, user_id, book_id, completed_part_ids, current_part_id,
+			   COALESCE(is_completed, false) AS is_completed, completed_at
+		FROM user_book_progress
+		WHERE user_id = $1 AND book_id = $2`
+
+	var progress models.UserBookProgress
+	if err := pgxscan.Get(ctx, db.ExecutorFrom(ctx, r.pool), &progress, query, userID, bookID); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, nil
+		}
+		return nil, dbError("failed to get progress", err)
+	}
+	return &progress, nil
+}
+
+// UpsertProgress - создание или обновление прогресса чтения
+func (r *ReadingRepository) UpsertProgress(ctx context.Context, progress *models.UserBookProgress) error {
+	if progress.CompletedPartIDs == nil {
+		progress.CompletedPartIDs = []string{}
+	}
+
+	query := `
+		INSERT INTO user_book_progress (
+			user_id, book_id, completed_part_ids, current_part_id, is_completed, completed_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, book_id) DO UPDATE SET
+			completed_part_ids = EXCLUDED.completed_part_ids,
+			current_part_id = EXCLUDED.current_part_id,
+			is_completed = EXCLUDED.is_completed,
+			completed_at = EXCLUDED.completed_at
+		RETURNING id`
+
+	err := db.ExecutorFrom(ctx, r.pool).QueryRow(ctx, query,
+		progress.UserID, progress.BookID, progress.CompletedPartIDs,
+		progress.CurrentPartID, progress.IsCompleted, progress.CompletedAt,
+	).Scan(&progress.ID)
+	if err != nil {
+		return dbError("failed to upsert progress", err)
+	}
+
+	return nil
+}
+
+// ListProgressByUser - получение прогресса чтения пользователя
+func (r *ReadingRepository) ListProgressByUser(ctx context.Context, userID string, limit, offset int) ([]*models.UserBookProgress, error) {
+	query := `
+		SELECT p.id, p.user_id, p.book_id, p.completed_part_ids, p.current_part_id,
+			   COALESCE(p.is_completed, false) AS is_completed, p.completed_at
+		FROM user_book_progress p
+		JOIN books b ON b.id = p.book_id AND b.deleted_at IS NULL
+		WHERE p.user_id = $1
+		ORDER BY p.completed_at DESC NULLS FIRST
+		LIMIT $2 OFFSET $3`
+
+	var progress []*models.UserBookProgress
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &progress, query, userID, limit, offset); err != nil {
+		return nil, dbError("failed to select user progress", err)
+	}
+	return progress, nil
+}
+
+// ListSessionsByUser - получение сессий чтения пользователя
+func (r *ReadingRepository) ListSessionsByUser(ctx context.Context, userID string, limit, offset int) ([]*models.ReadingSession, error) {
+	query := `
+		SELECT s.id, s.user_id, s.book_id, s.part_id, s.started_at, s.ended_at,
+			   COALESCE(s.pages_read, 0) AS pages_read, s.duration_minutes
+		FROM user_reading_sessions s
+		JOIN books b ON b.id = s.book_id AND b.deleted_at IS NULL
+		WHERE s.user_id = $1
+		ORDER BY s.started_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var sessions []*models.ReadingSession
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &sessions, query, userID, limit, offset); err != nil {
+		return nil, dbError("failed to select user reading sessions", err)
+	}
+	return sessions, nil
+}
+
+// ListFavoritesByUser - получение избранных книг пользователя
+func (r *ReadingRepository) ListFavoritesByUser(ctx context.Context, userID string, limit, offset int) ([]*models.UserFavorite, error) {
+	query := `
+		SELECT f.id, f.user_id, f.book_id, f.created_at
+		FROM user_favorites f
+		JOIN books b ON b.id = f.book_id AND b.deleted_at IS NULL
+		WHERE f.user_id = $1
+		ORDER BY f.created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	var favorites []*models.UserFavorite
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &favorites, query, userID, limit, offset); err != nil {
+		return nil, dbError("failed to select user favorites", err)
+	}
+	return favorites, nil
+}
+
+// AddFavorite - добавление книги в избранное (повторное добавление возвращает существующую запись)
+func (r *ReadingRepository) AddFavorite(ctx context.Context, userID, bookID string) (*models.UserFavorite, error) {
+	query := `
+		INSERT INTO user_favorites (user_id, book_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, book_id) DO UPDATE SET user_id = EXCLUDED.user_id
+		RETURNING id, user_id, book_id, created_at`
+
+	var favorite models.UserFavorite
+	if err := pgxscan.Get(ctx, db.ExecutorFrom(ctx, r.pool), &favorite, query, userID, bookID); err != nil {
+		return nil, dbError("failed to add favorite", err)
+	}
+	return &favorite, nil
+}
+
+// RemoveFavorite - удаление книги из избранного
+func (r *ReadingRepository) RemoveFavorite(ctx context.Context, userID, bookID string) error {
+	query := `DELETE FROM user_favorites WHERE user_id = $1 AND book_id = $2`
+
+	if _, err := db.ExecutorFrom(ctx, r.pool).Exec(ctx, query, userID, bookID); err != nil {
+		return dbError("failed to remove favorite", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// userSignalsQuery - положительные сигналы пользователей: отзыв с оценкой от 8,
+// избранное и полка "read". Берется максимальный вес по книге.
+const userSignalsQuery = `
+	SELECT user_id, book_id, MAX(weight) AS weight
+	FROM (
+		SELECT user_id, book_id, 1.0 AS weight FROM reviews WHERE rating >= 8
+		UNION ALL
+		SELECT user_id, book_id, 1.0 AS weight FROM user_favorites
+		UNION ALL
+		SELECT user_id, book_id, 0.5 AS weight FROM user_shelf_books WHERE status = 'read'
+	) s
+	WHERE user_id IS NOT NULL AND book_id IS NOT NULL`
+
+// notInteractedFilter - исключение книг (алиас b), которые пользователь $1 уже оценил или отметил
+const notInteractedFilter = `
+	NOT EXISTS (SELECT 1 FROM reviews rv WHERE rv.user_id = $1 AND rv.book_id = b.id)
+	AND NOT EXISTS (SELECT 1 FROM user_favorites f WHERE f.user_id = $1 AND f.book_id = b.id)
+	AND NOT EXISTS (SELECT 1 FROM user_shelf_books sb WHERE sb.user_id = $1 AND sb.book_id = b.id)`
+
+// RecommendationRepository - реализация репозитория для рекомендаций книг
+type RecommendationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRecommendationRepository - создание нового RecommendationRepository
+func NewRecommendationRepository(pool *pgxpool.Pool) interfaces.RecommendationRepository {
+	return &RecommendationRepository{
+		pool: pool,
+	}
+}
+
+// LoadBookFeatures - жанры, теги и mood_tags частей для всех книг
+func (r *RecommendationRepository) LoadBookFeatures(ctx context.Context) ([]*models.BookFeatures, error) {
+	query := `
+		SELECT b.id AS book_id,
+			   COALESCE(b.genres, '{}') AS genres,
+			   COALESCE(b.tags, '{}') AS tags,
+			   ARRAY(
+				   SELECT DISTINCT m
+				   FROM book_parts p, unnest(p.mood_tags) AS m
+				   WHERE p.book_id = b.id
+			   ) AS mood_tags
+		FROM books b
+		WHERE b.deleted_at IS NULL`
+
+	var features []*models.BookFeatures
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &features, query); err != nil {
+		return nil, dbError("failed to load book features", err)
+	}
+	return features, nil
+}
+
+// LoadUserSignals - положительные сигналы пользователей по книгам
+func (r *RecommendationRepository) LoadUserSignals(ctx context.Context) ([]*models.UserBookSignal, error) {
+	query := userSignalsQuery + ` GROUP BY user_id, book_id`
+
+	var signals []*models.UserBookSignal
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &signals, query); err != nil {
+		return nil, dbError("failed to load user signals", err)
+	}
+	return signals, nil
+}
+
+// ReplaceSimilarities - атомарная замена таблицы похожести книг
+func (r *RecommendationRepository) ReplaceSimilarities(ctx context.Context, similarities []*models.BookSimilarity) error {
+	return pgx.BeginFunc(ctx, db.ExecutorFrom(ctx, r.pool), func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM book_similarities`); err != nil {
+			return dbError("failed to clear book similarities", err)
+		}
+
+		_, err := tx.CopyFrom(ctx,
+			pgx.Identifier{"book_similarities"},
+			[]string{"book_id", "similar_book_id", "score", "reasons", "computed_at"},
+			pgx.CopyFromSlice(len(similarities), func(i int) ([]any, error) {
+				s := similarities[i]
+				return []any{s.BookID, s.SimilarBookID, s.Score, s.Reasons, s.ComputedAt}, nil
+			}),
+		)
+		if err != nil {
+			return dbError("failed to copy book similarities", err)
+		}
+		return nil
+	})
+}
+
+// ListSimilar - похожие книги для книги
+func (r *RecommendationRepository) ListSimilar(ctx context.Context, bookID string, limit int) ([]*models.RecommendedBook, error) {
+	query := `
+		SELECT b.id AS book_id, b.title, b.author, b.cover_url,
+			   COALESCE(b.average_rating, 0) AS average_rating,
+			   s.score, s.reasons
+		FROM book_similarities s
+		JOIN books b ON b.id = s.similar_book_id AND b.deleted_at IS NULL
+		WHERE s.book_id = $1
+		ORDER BY s.score DESC
+		LIMIT $2`
+
+	var books []*models.RecommendedBook
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &books, query, bookID, limit); err != nil {
+		return nil, dbError("failed to select similar books", err)
+	}
+	return books, nil
+}
+
+// ListForUser - рекомендации для пользователя на основе его положительных сигналов.
+// Книги, с которыми пользователь уже взаимодействовал, исключаются.
+func (r *RecommendationRepository) ListForUser(ctx context.Context, userID string, limit int) ([]*models.RecommendedBook, error) {
+	query := `
+		WITH seeds AS (` + userSignalsQuery + ` AND user_id = $1 GROUP BY user_id, book_id),
+		candidates AS (
+			SELECT s.similar_book_id AS book_id, SUM(s.score * seeds.weight) AS score
+			FROM seeds
+			JOIN book_similarities s ON s.book_id = seeds.book_id
+			GROUP BY s.similar_book_id
+		)
+		SELECT b.id AS book_id, b.title, b.author, b.cover_url,
+			   COALESCE(b.average_rating, 0) AS average_rating,
+			   c.score,
+			   ARRAY(
+				   SELECT DISTINCT reason
+				   FROM seeds
+				   JOIN book_similarities s ON s.book_id = seeds.book_id AND s.similar_book_id = c.book_id,
+						unnest(s.reasons) AS reason
+			   ) AS reasons
+		FROM candidates c
+		JOIN books b ON b.id = c.book_id AND b.deleted_at IS NULL
+		WHERE ` + notInteractedFilter + `
+		ORDER BY c.score DESC
+		LIMIT $2`
+
+	var books []*models.RecommendedBook
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &books, query, userID, limit); err != nil {
+		return nil, dbError("failed to select user recommendations", err)
+	}
+	return books, nil
+}
+
+// ListPopularForUser - популярные книги, которые пользователь еще не отмечал
+func (r *RecommendationRepository) ListPopularForUser(ctx context.Context, userID string, limit int) ([]*models.RecommendedBook, error) {
+	query := `
+		SELECT b.id AS book_id, b.title, b.author, b.cover_url,
+			   COALESCE(b.average_rating, 0) AS average_rating,
+			   0::DOUBLE PRECISION AS score,
+			   '{}'::TEXT[] AS reasons
+		FROM books b
+		WHERE b.deleted_at IS NULL AND ` + notInteractedFilter + `
+		ORDER BY b.average_rating DESC NULLS LAST, b.rating_count DESC NULLS LAST
+		LIMIT $2`
+
+	var books []*models.RecommendedBook
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &books, query, userID, limit); err != nil {
+		return nil, dbError("failed to select popular books", err)
+	}
+	return books, nil
+}
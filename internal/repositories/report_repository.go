@@ -0,0 +1,264 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// reportTable - таблица объекта жалобы, выражение для автора и условие видимости строки
+type reportTable struct {
+	table  string
+	author string
+	filter string
+}
+
+// reportTables - белый список таблиц, на строки которых можно пожаловаться (имена подставляются в SQL).
+// У профиля персонажа нет автора-пользователя: предупреждение за него не выдается.
+var reportTables = map[models.ReportTargetType]reportTable{
+	models.ReportTargetReview:           {table: "reviews", author: "user_id::text"},
+	models.ReportTargetComment:          {table: "comments", author: "user_id::text", filter: " AND deleted_at IS NULL"},
+	models.ReportTargetQuote:            {table: "quotes", author: "user_id::text"},
+	models.ReportTargetArticle:          {table: "articles", author: "author_id::text", filter: " AND deleted_at IS NULL"},
+	models.ReportTargetCharacterProfile: {table: "character_profiles", author: "NULL::text"},
+}
+
+// ReportRepository - реализация репозитория жалоб на пользовательский контент
+type ReportRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewReportRepository - создание нового ReportRepository
+func NewReportRepository(pool *pgxpool.Pool) interfaces.ReportRepository {
+	return &ReportRepository{
+		pool: pool,
+	}
+}
+
+// queries - запросы sqlc в транзакции из контекста или через пул
+func (r *ReportRepository) queries(ctx context.Context) *db.Queries {
+	return db.New(db.ExecutorFrom(ctx, r.pool))
+}
+
+// Create - сохранение жалобы; заполняет ID, Status и CreatedAt
+func (r *ReportRepository) Create(ctx context.Context, report *models.ContentReport) error {
+	row, err := r.queries(ctx).CreateContentReport(ctx, db.CreateContentReportParams{
+		TargetType: string(report.TargetType),
+		TargetID:   report.TargetID,
+		ReporterID: report.ReporterID,
+		Reason:     string(report.Reason),
+		Details:    report.Details,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return interfaces.ErrAlreadyReported
+		}
+		return dbError("failed to create report", err)
+	}
+	report.ID = row.ID
+	report.Status = models.ReportStatus(row.Status)
+	report.CreatedAt = row.CreatedAt
+	return nil
+}
+
+// GetByID - жалоба с числом открытых жалоб на тот же объект
+func (r *ReportRepository) GetByID(ctx context.Context, id string) (*models.ContentReport, error) {
+	row, err := r.queries(ctx).GetContentReport(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, interfaces.ErrReportNotFound
+	}
+	if err != nil {
+		return nil, dbError("failed to get report", err)
+	}
+	return reportFromRow(row), nil
+}
+
+// Lock - блокировка жалобы до конца транзакции
+func (r *ReportRepository) Lock(ctx context.Context, id string) (*models.ContentReport, error) {
+	row, err := r.queries(ctx).LockContentReport(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, interfaces.ErrReportNotFound
+	}
+	if err != nil {
+		return nil, dbError("failed to lock report", err)
+	}
+	return reportFromTableRow(row), nil
+}
+
+// LockOpenByTarget - блокировка открытых жалоб на объект; возвращает ID жалобы -> кто взял ее в работу
+func (r *ReportRepository) LockOpenByTarget(ctx context.Context, targetType models.ReportTargetType, targetID string) (map[string]*string, error) {
+	rows, err := r.queries(ctx).LockOpenTargetReports(ctx, db.LockOpenTargetReportsParams{
+		TargetType: string(targetType),
+		TargetID:   targetID,
+	})
+	if err != nil {
+		return nil, dbError("failed to lock reports", err)
+	}
+
+	claims := make(map[string]*string, len(rows))
+	for _, row := range rows {
+		claims[row.ID] = row.ClaimedBy
+	}
+	return claims, nil
+}
+
+// List - жалобы по фильтру от давних к новым
+func (r *ReportRepository) List(ctx context.Context, filter *models.ReportFilter, limit, offset int) ([]*models.ContentReport, error) {
+	params := db.ListContentReportsParams{
+		TargetID:  filter.TargetID,
+		ClaimedBy: filter.ClaimedBy,
+		RowLimit:  toInt32(limit),
+		RowOffset: toInt32(offset),
+	}
+	if filter.Status != nil {
+		status := string(*filter.Status)
+		params.Status = &status
+	}
+	if filter.TargetType != nil {
+		targetType := string(*filter.TargetType)
+		params.TargetType = &targetType
+	}
+
+	rows, err := r.queries(ctx).ListContentReports(ctx, params)
+	if err != nil {
+		return nil, dbError("failed to select reports", err)
+	}
+
+	reports := make([]*models.ContentReport, len(rows))
+	for i, row := range rows {
+		reports[i] = reportFromRow(db.GetContentReportRow(row))
+	}
+	return reports, nil
+}
+
+// CountOpenReporters - число разных пользователей с открытыми жалобами на объект
+func (r *ReportRepository) CountOpenReporters(ctx context.Context, targetType models.ReportTargetType, targetID string) (int, error) {
+	count, err := r.queries(ctx).CountOpenTargetReporters(ctx, db.CountOpenTargetReportersParams{
+		TargetType: string(targetType),
+		TargetID:   targetID,
+	})
+	if err != nil {
+		return 0, dbError("failed to count reporters", err)
+	}
+	return int(count), nil
+}
+
+// HasResolved - была ли жалоба на объект признана обоснованной
+func (r *ReportRepository) HasResolved(ctx context.Context, targetType models.ReportTargetType, targetID string) (bool, error) {
+	resolved, err := r.queries(ctx).HasResolvedTargetReports(ctx, db.HasResolvedTargetReportsParams{
+		TargetType: string(targetType),
+		TargetID:   targetID,
+	})
+	if err != nil {
+		return false, dbError("failed to check resolved reports", err)
+	}
+	return resolved, nil
+}
+
+// SetClaim - взятие жалобы в работу модератором (nil - жалоба отпущена)
+func (r *ReportRepository) SetClaim(ctx context.Context, id string, moderatorID *string) error {
+	var err error
+	if moderatorID != nil {
+		err = r.queries(ctx).ClaimContentReport(ctx, db.ClaimContentReportParams{ID: id, ClaimedBy: moderatorID})
+	} else {
+		err = r.queries(ctx).ReleaseContentReport(ctx, id)
+	}
+	if err != nil {
+		return dbError("failed to update report claim", err)
+	}
+	return nil
+}
+
+// ResolveOpenByTarget - закрытие всех открытых жалоб на объект с решением status
+func (r *ReportRepository) ResolveOpenByTarget(ctx context.Context, targetType models.ReportTargetType, targetID string, status models.ReportStatus, resolvedBy string, note *string) error {
+	_, err := r.queries(ctx).ResolveOpenTargetReports(ctx, db.ResolveOpenTargetReportsParams{
+		TargetType:     string(targetType),
+		TargetID:       targetID,
+		Status:         string(status),
+		ResolvedBy:     &resolvedBy,
+		ResolutionNote: note,
+	})
+	if err != nil {
+		return dbError("failed to resolve reports", err)
+	}
+	return nil
+}
+
+// LockTarget - автор и состояние объекта жалобы с блокировкой строки (вызывать в транзакции)
+func (r *ReportRepository) LockTarget(ctx context.Context, targetType models.ReportTargetType, targetID string) (*models.ReportTarget, error) {
+	t := reportTables[targetType]
+	query := fmt.Sprintf(`SELECT %s, hidden_at IS NOT NULL FROM %s WHERE id = $1%s FOR UPDATE`, t.author, t.table, t.filter)
+
+	target := &models.ReportTarget{Type: targetType, ID: targetID}
+	err := db.ExecutorFrom(ctx, r.pool).QueryRow(ctx, query, targetID).Scan(&target.AuthorID, &target.Hidden)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, interfaces.ErrReportTargetNotFound
+	}
+	if err != nil {
+		return nil, dbError("failed to get reported content", err)
+	}
+	return target, nil
+}
+
+// SetTargetHidden - скрытие объекта из публичных ответов или его возврат
+func (r *ReportRepository) SetTargetHidden(ctx context.Context, targetType models.ReportTargetType, targetID string, hidden bool) error {
+	t := reportTables[targetType]
+	query := fmt.Sprintf(`UPDATE %s SET hidden_at = CASE WHEN $2 THEN COALESCE(hidden_at, NOW()) END WHERE id = $1%s`,
+		t.table, t.filter)
+
+	tag, err := db.ExecutorFrom(ctx, r.pool).Exec(ctx, query, targetID, hidden)
+	if err != nil {
+		return dbError("failed to update reported content", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return interfaces.ErrReportTargetNotFound
+	}
+	return nil
+}
+
+// reportFromRow - модель жалобы из строки sqlc с итогами по объекту
+func reportFromRow(row db.GetContentReportRow) *models.ContentReport {
+	report := reportFromTableRow(db.ContentReport{
+		ID:             row.ID,
+		TargetType:     row.TargetType,
+		TargetID:       row.TargetID,
+		ReporterID:     row.ReporterID,
+		Reason:         row.Reason,
+		Details:        row.Details,
+		Status:         row.Status,
+		ClaimedBy:      row.ClaimedBy,
+		ClaimedAt:      row.ClaimedAt,
+		ResolvedBy:     row.ResolvedBy,
+		ResolutionNote: row.ResolutionNote,
+		ResolvedAt:     row.ResolvedAt,
+		CreatedAt:      row.CreatedAt,
+	})
+	report.OpenReports = int(row.OpenReports)
+	report.TargetHidden = row.TargetHidden
+	return report
+}
+
+// reportFromTableRow - модель жалобы из строки таблицы content_reports
+func reportFromTableRow(row db.ContentReport) *models.ContentReport {
+	return &models.ContentReport{
+		ID:             row.ID,
+		TargetType:     models.ReportTargetType(row.TargetType),
+		TargetID:       row.TargetID,
+		ReporterID:     row.ReporterID,
+		Reason:         models.ReportReason(row.Reason),
+		Details:        row.Details,
+		Status:         models.ReportStatus(row.Status),
+		ClaimedBy:      row.ClaimedBy,
+		ClaimedAt:      row.ClaimedAt,
+		ResolvedBy:     row.ResolvedBy,
+		ResolutionNote: row.ResolutionNote,
+		ResolvedAt:     row.ResolvedAt,
+		CreatedAt:      row.CreatedAt,
+	}
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// ReviewRepository - реализация репозитория для отзывов
+type ReviewRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewReviewRepository - создание нового ReviewRepository
+func NewReviewRepository(pool *pgxpool.Pool) interfaces.ReviewRepository {
+	return &ReviewRepository{
+		pool: pool,
+	}
+}
+
+// Create - создание нового отзыва
+func (r *ReviewRepository) Create(ctx context.Context, review *models.Review) error {
+	query := `
+		INSERT INTO reviews (
+			user_id, book_id, rating, text, liked_characters,
+			disliked_characters, best_parts
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at`
+
+	err := db.ExecutorFrom(ctx, r.pool).QueryRow(ctx, query,
+		review.UserID, review.BookID, review.Rating, review.Text,
+		review.LikedCharacters, review.DislikedCharacters, review.BestParts,
+	).Scan(&review.ID, &review.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return interfaces.ErrReviewExists
+		}
+		return dbError("failed to create review", err)
+	}
+
+	return nil
+}
+
+// RefreshBookRating - пересчет среднего рейтинга книги по отзывам
+func (r *ReviewRepository) RefreshBookRating(ctx context.Context, bookID string) error {
+	query := `
+		UPDATE books SET
+			average_rating = COALESCE((SELECT ROUND(AVG(rating), 1) FROM reviews WHERE book_id = $1), 0),
+			rating_count = (SELECT COUNT(*) FROM reviews WHERE book_id = $1)
+		WHERE id = $1`
+
+	if _, err := db.ExecutorFrom(ctx, r.pool).Exec(ctx, query, bookID); err != nil {
+		return dbError("failed to refresh book rating", err)
+	}
+	return nil
+}
+
+// ListByUser - получение отзывов пользователя; includeHidden - вместе со скрытыми по жалобам
+func (r *ReviewRepository) ListByUser(ctx context.Context, userID string, includeHidden bool, limit, offset int) ([]*models.Review, error) {
+	query := `
+		SELECT r.id, r.user_id, r.book_id, r.rating, r.text, r.liked_characters,
+			   r.disliked_characters, r.best_parts, r.created_at
+		FROM reviews r
+		JOIN books b ON b.id = r.book_id AND b.deleted_at IS NULL
+		WHERE r.user_id = $1 AND ($2 OR r.hidden_at IS NULL)
+		ORDER BY r.created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	var reviews []*models.Review
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &reviews, query, userID, includeHidden, limit, offset); err != nil {
+		return nil, dbError("failed to select user reviews", err)
+	}
+	return reviews, nil
+}
@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// RevisionRepository - реализация репозитория истории правок
+type RevisionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewRevisionRepository - создание нового RevisionRepository
+func NewRevisionRepository(pool *pgxpool.Pool) interfaces.RevisionRepository {
+	return &RevisionRepository{
+		pool: pool,
+	}
+}
+
+// queries - запросы sqlc в транзакции из контекста или через пул
+func (r *RevisionRepository) queries(ctx context.Context) *db.Queries {
+	return db.New(db.ExecutorFrom(ctx, r.pool))
+}
+
+// Create - запись ревизии
+func (r *RevisionRepository) Create(ctx context.Context, revision *models.Revision) error {
+	changes, err := marshalJSONB(revision.Changes)
+	if err != nil {
+		return err
+	}
+	snapshot, err := marshalJSONB(revision.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	err = r.queries(ctx).CreateRevision(ctx, db.CreateRevisionParams{
+		EntityType: string(revision.EntityType),
+		EntityID:   revision.EntityID,
+		Version:    toInt32(revision.Version),
+		EditorID:   revision.EditorID,
+		Comment:    revision.Comment,
+		Changes:    changes,
+		Snapshot:   snapshot,
+	})
+	if err != nil {
+		return dbError("failed to create revision", err)
+	}
+	return nil
+}
+
+// CreateBaseline - запись исходного состояния сущности
+func (r *RevisionRepository) CreateBaseline(ctx context.Context, revision *models.Revision) error {
+	snapshot, err := marshalJSONB(revision.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	err = r.queries(ctx).CreateBaselineRevision(ctx, db.CreateBaselineRevisionParams{
+		EntityType: string(revision.EntityType),
+		EntityID:   revision.EntityID,
+		Version:    toInt32(revision.Version),
+		Snapshot:   snapshot,
+	})
+	if err != nil {
+		return dbError("failed to create baseline revision", err)
+	}
+	return nil
+}
+
+// Exists - есть ли у сущности хотя бы одна ревизия
+func (r *RevisionRepository) Exists(ctx context.Context, entity models.RevisionEntity, entityID string) (bool, error) {
+	exists, err := r.queries(ctx).HasRevisions(ctx, db.HasRevisionsParams{
+		EntityType: string(entity),
+		EntityID:   entityID,
+	})
+	if err != nil {
+		return false, dbError("failed to check revisions", err)
+	}
+	return exists, nil
+}
+
+// List - ревизии сущности от новых к старым
+func (r *RevisionRepository) List(ctx context.Context, entity models.RevisionEntity, entityID string, limit, offset int) ([]*models.Revision, error) {
+	rows, err := r.queries(ctx).ListRevisions(ctx, db.ListRevisionsParams{
+		EntityType: string(entity),
+		EntityID:   entityID,
+		Limit:      toInt32(limit),
+		Offset:     toInt32(offset),
+	})
+	if err != nil {
+		return nil, dbError("failed to list revisions", err)
+	}
+
+	revisions := make([]*models.Revision, 0, len(rows))
+	for _, row := range rows {
+		revision := &models.Revision{
+			EntityType: models.RevisionEntity(row.EntityType),
+			EntityID:   row.EntityID,
+			Version:    int(row.Version),
+			EditorID:   row.EditorID,
+			Comment:    row.Comment,
+			CreatedAt:  row.CreatedAt,
+		}
+		if err := unmarshalJSONB(row.Changes, &revision.Changes); err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// Get - ревизия сущности по номеру версии
+func (r *RevisionRepository) Get(ctx context.Context, entity models.RevisionEntity, entityID string, version int) (*models.Revision, error) {
+	row, err := r.queries(ctx).GetRevision(ctx, db.GetRevisionParams{
+		EntityType: string(entity),
+		EntityID:   entityID,
+		Version:    toInt32(version),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, interfaces.ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, dbError("failed to get revision", err)
+	}
+
+	revision := &models.Revision{
+		EntityType: models.RevisionEntity(row.EntityType),
+		EntityID:   row.EntityID,
+		Version:    int(row.Version),
+		EditorID:   row.EditorID,
+		Comment:    row.Comment,
+		CreatedAt:  row.CreatedAt,
+	}
+	if err := unmarshalJSONB(row.Changes, &revision.Changes); err != nil {
+		return nil, err
+	}
+	if err := unmarshalJSONB(row.Snapshot, &revision.Snapshot); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// marshalJSONB - значение для колонки JSONB; nil map записывается как NULL
+func marshalJSONB[T any](v map[string]T) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode jsonb: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalJSONB - разбор колонки JSONB; NULL оставляет значение пустым
+func unmarshalJSONB(data []byte, v any) error {
+	if data == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode jsonb: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// ShelfRepository - реализация репозитория для полок пользователя
+type ShelfRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewShelfRepository - создание нового ShelfRepository
+func NewShelfRepository(pool *pgxpool.Pool) interfaces.ShelfRepository {
+	return &ShelfRepository{
+		pool: pool,
+	}
+}
+
+// GetStatus - получение встроенной полки книги у пользователя (nil, если книга не на полке)
+func (r *ShelfRepository) GetStatus(ctx context.Context, userID, bookID string) (*models.ShelfBook, error) {
+	query := `
+		SELECT s.user_id, s.book_id, s.status, s.note, s.created_at, s.updated_at,
+			   b.title AS book_title, b.author AS book_author, b.cover_url AS book_cover_url
+		FROM user_shelf_books s
+		JOIN books b ON b.id = s.book_id AND b.deleted_at IS NULL
+		WHERE s.user_id = $1 AND s.book_id = $2`
+
+	var entry models.ShelfBook
+	if err := pgxscan.Get(ctx, db.ExecutorFrom(ctx, r.pool), &entry, query, userID, bookID); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, nil
+		}
+		return nil, dbError("failed to get shelf status", err)
+	}
+	return &entry, nil
+}
+
+// SetStatus - перемещение книги на встроенную полку
+func (r *ShelfRepository) SetStatus(ctx context.Context, entry *models.ShelfBook) error {
+	query := `
+		INSERT INTO user_shelf_books (user_id, book_id, status, note)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, book_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			note = EXCLUDED.note,
+			updated_at = NOW()
+		RETURNING created_at, updated_at`
+
+	err := db.ExecutorFrom(ctx, r.pool).QueryRow(ctx, query,
+		entry.UserID, entry.BookID, entry.Status, entry.Note,
+	).Scan(&entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		return dbError("failed to set shelf status", err)
+	}
+	return nil
+}
+
+// RemoveStatus - удаление книги со встроенной полки
+func (r *ShelfRepository) RemoveStatus(ctx context.Context, userID, bookID string) error {
+	query := `DELETE FROM user_shelf_books WHERE user_id = $1 AND book_id = $2`
+
+	tag, err := db.ExecutorFrom(ctx, r.pool).Exec(ctx, query, userID, bookID)
+	if err != nil {
+		return dbError("failed to remove shelf status", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return interfaces.ErrShelfItemNotFound
+	}
+	return nil
+}
+
+// ListByStatus - получение книг на встроенной полке
+func (r *ShelfRepository) ListByStatus(ctx context.Context, userID string, status models.ShelfStatus, limit, offset int) ([]*models.ShelfBook, error) {
+	query := `
+		SELECT s.user_id, s.book_id, s.status, s.note, s.created_at, s.updated_at,
+			   b.title AS book_title, b.author AS book_author, b.cover_url AS book_cover_url
+		FROM user_shelf_books s
+		JOIN books b ON b.id = s.book_id AND b.deleted_at IS NULL
+		WHERE s.user_id = $1 AND s.status = $2
+		ORDER BY s.updated_at DESC
+		LIMIT $3 OFFSET $4`
+
+	var entries []*models.ShelfBook
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &entries, query, userID, status, limit, offset); err != nil {
+		return nil, dbError("failed to select shelf books", err)
+	}
+	return entries, nil
+}
+
+// CountByStatus - количество книг на каждой встроенной полке
+func (r *ShelfRepository) CountByStatus(ctx context.Context, userID string) ([]*models.ShelfStatusCount, error) {
+	query := `
+		SELECT st.status, COUNT(s.book_id) AS count
+		FROM unnest(enum_range(NULL::shelf_status)) AS st(status)
+		LEFT JOIN user_shelf_books s ON s.status = st.status AND s.user_id = $1
+		GROUP BY st.status
+		ORDER BY st.status`
+
+	var counts []*models.ShelfStatusCount
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &counts, query, userID); err != nil {
+		return nil, dbError("failed to count shelf books", err)
+	}
+	return counts, nil
+}
+
+// ListShelves - получение пользовательских полок
+func (r *ShelfRepository) ListShelves(ctx context.Context, userID string) ([]*models.Shelf, error) {
+	query := `
+		SELECT sh.id, sh.user_id, sh.name, sh.position, sh.created_at,
+			   (SELECT COUNT(*) FROM user_shelf_items i WHERE i.shelf_id = sh.id) AS books_count
+		FROM user_shelves sh
+		WHERE sh.user_id = $1
+		ORDER BY sh.position, sh.created_at`
+
+	var shelves []*models.Shelf
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &shelves, query, userID); err != nil {
+		return nil, dbError("failed to select shelves", err)
+	}
+	return shelves, nil
+}
+
+// GetShelf - получение пользовательской полки по ID
+func (r *ShelfRepository) GetShelf(ctx context.Context, id string) (*models.Shelf, error) {
+	query := `
+		SELECT sh.id, sh.user_id, sh.name, sh.position, sh.created_at,
+			   (SELECT COUNT(*) FROM user_shelf_items i WHERE i.shelf_id = sh.id) AS books_count
+		FROM user_shelves sh
+		WHERE sh.id = $1`
+
+	var shelf models.Shelf
+	if err := pgxscan.Get(ctx, db.ExecutorFrom(ctx, r.pool), &shelf, query, id); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, interfaces.ErrShelfNotFound
+		}
+		return nil, dbError("failed to get shelf", err)
+	}
+	return &shelf, nil
+}
+
+// CreateShelf - создание пользовательской полки в конце списка
+func (r *ShelfRepository) CreateShelf(ctx context.Context, shelf *models.Shelf) error {
+	query := `
+		INSERT INTO user_shelves (user_id, name, position)
+		VALUES ($1, $2, (SELECT COALESCE(MAX(position) + 1, 0) FROM user_shelves WHERE user_id = $1))
+		RETURNING id, position, created_at`
+
+	err := db.ExecutorFrom(ctx, r.pool).QueryRow(ctx, query, shelf.UserID, shelf.Name).
+		Scan(&shelf.ID, &shelf.Position, &shelf.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return interfaces.ErrShelfExists
+		}
+		return dbError("failed to create shelf", err)
+	}
+	return nil
+}
+
+// UpdateShelf - обновление названия и позиции полки
+func (r *ShelfRepository) UpdateShelf(ctx context.Context, shelf *models.Shelf) error {
+	query := `UPDATE user_shelves SET name = $2, position = $3 WHERE id = $1`
+
+	tag, err := db.ExecutorFrom(ctx, r.pool).Exec(ctx, query, shelf.ID, shelf.Name, shelf.Position)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return interfaces.ErrShelfExists
+		}
+		return dbError("failed to update shelf", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return interfaces.ErrShelfNotFound
+	}
+	return nil
+}
+
+// DeleteShelf - удаление полки вместе с ее книгами
+func (r *ShelfRepository) DeleteShelf(ctx context.Context, id string) error {
+	tag, err := db.ExecutorFrom(ctx, r.pool).Exec(ctx, `DELETE FROM user_shelves WHERE id = $1`, id)
+	if err != nil {
+		return dbError("failed to delete shelf", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return interfaces.ErrShelfNotFound
+	}
+	return nil
+}
+
+// ListItems - получение книг на пользовательской полке
+func (r *ShelfRepository) ListItems(ctx context.Context, shelfID string, limit, offset int) ([]*models.ShelfItem, error) {
+	query := `
+		SELECT i.shelf_id, i.book_id, i.position, i.note, i.added_at,
+			   b.title AS book_title, b.author AS book_author, b.cover_url AS book_cover_url
+		FROM user_shelf_items i
+		JOIN books b ON b.id = i.book_id AND b.deleted_at IS NULL
+		WHERE i.shelf_id = $1
+		ORDER BY i.position, i.added_at
+		LIMIT $2 OFFSET $3`
+
+	var items []*models.ShelfItem
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &items, query, shelfID, limit, offset); err != nil {
+		return nil, dbError("failed to select shelf items", err)
+	}
+	return items, nil
+}
+
+// GetItem - получение книги на пользовательской полке
+func (r *ShelfRepository) GetItem(ctx context.Context, shelfID, bookID string) (*models.ShelfItem, error) {
+	query := `
+		SELECT i.shelf_id, i.book_id, i.position, i.note, i.added_at,
+			   b.title AS book_title, b.author AS book_author, b.cover_url AS book_cover_url
+		FROM user_shelf_items i
+		JOIN books b ON b.id = i.book_id AND b.deleted_at IS NULL
+		WHERE i.shelf_id = $1 AND i.book_id = $2`
+
+	var item models.ShelfItem
+	if err := pgxscan.Get(ctx, db.ExecutorFrom(ctx, r.pool), &item, query, shelfID, bookID); err != nil {
+		if pgxscan.NotFound(err) {
+			return nil, interfaces.ErrShelfItemNotFound
+		}
+		return nil, dbError("failed to get shelf item", err)
+	}
+	return &item, nil
+}
+
+// AddItem - добавление книги в конец пользовательской полки
+func (r *ShelfRepository) AddItem(ctx context.Context, item *models.ShelfItem) error {
+	query := `
+		INSERT INTO user_shelf_items (shelf_id, book_id, note, position)
+		VALUES ($1, $2, $3, (SELECT COALESCE(MAX(position) + 1, 0) FROM user_shelf_items WHERE shelf_id = $1))
+		RETURNING position, added_at`
+
+	err := db.ExecutorFrom(ctx, r.pool).QueryRow(ctx, query, item.ShelfID, item.BookID, item.Note).
+		Scan(&item.Position, &item.AddedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return interfaces.ErrShelfItemExists
+		}
+		return dbError("failed to add shelf item", err)
+	}
+	return nil
+}
+
+// UpdateItem - обновление заметки и позиции книги на полке
+func (r *ShelfRepository) UpdateItem(ctx context.Context, item *models.ShelfItem) error {
+	query := `
+		UPDATE user_shelf_items SET note = $3, position = $4
+		WHERE shelf_id = $1 AND book_id = $2`
+
+	tag, err := db.ExecutorFrom(ctx, r.pool).Exec(ctx, query, item.ShelfID, item.BookID, item.Note, item.Position)
+	if err != nil {
+		return dbError("failed to update shelf item", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return interfaces.ErrShelfItemNotFound
+	}
+	return nil
+}
+
+// RemoveItem - удаление книги с пользовательской полки
+func (r *ShelfRepository) RemoveItem(ctx context.Context, shelfID, bookID string) error {
+	query := `DELETE FROM user_shelf_items WHERE shelf_id = $1 AND book_id = $2`
+
+	tag, err := db.ExecutorFrom(ctx, r.pool).Exec(ctx, query, shelfID, bookID)
+	if err != nil {
+		return dbError("failed to remove shelf item", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return interfaces.ErrShelfItemNotFound
+	}
+	return nil
+}
+
+// isUniqueViolation - проверка нарушения уникального ограничения PostgreSQL
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
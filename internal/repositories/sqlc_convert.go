@@ -0,0 +1,66 @@
+package repositories
+
+import "time"
+
+// Преобразования между типами, сгенерированными sqlc (int32, nullable-указатели),
+// и типами моделей.
+
+// toInt32 - int в int32 для параметров sqlc
+func toInt32(v int) int32 {
+	return int32(v)
+}
+
+// toInt32Ptr - *int в *int32 для параметров sqlc
+func toInt32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v)
+	return &i
+}
+
+// fromInt32Ptr - *int32 из sqlc в *int модели
+func fromInt32Ptr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+// intOrZero - значение nullable-колонки или 0
+func intOrZero(v *int32) int {
+	if v == nil {
+		return 0
+	}
+	return int(*v)
+}
+
+// floatOrZero - значение nullable-колонки или 0
+func floatOrZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// boolOrFalse - значение nullable-колонки или false
+func boolOrFalse(v *bool) bool {
+	return v != nil && *v
+}
+
+// stringOrEmpty - значение nullable-колонки или пустая строка
+func stringOrEmpty(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+// timeOrZero - значение nullable-колонки или нулевое время
+func timeOrZero(v *time.Time) time.Time {
+	if v == nil {
+		return time.Time{}
+	}
+	return *v
+}
@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// trashTable - таблица сущности корзины и выражение для ее названия
+type trashTable struct {
+	table string
+	title string
+}
+
+// trashTables - белый список таблиц с мягким удалением (имена подставляются в SQL)
+var trashTables = map[models.TrashKind]trashTable{
+	models.TrashKindBook:    {table: "books", title: "title"},
+	models.TrashKindArticle: {table: "articles", title: "title"},
+	models.TrashKindComment: {table: "comments", title: "LEFT(text, 80)"},
+	models.TrashKindUser:    {table: "users", title: "username"},
+}
+
+// trashSnapshot - строка сущности в JSON для журнала (без хеша пароля пользователя)
+const trashSnapshot = `to_jsonb(t) - 'password_hash'`
+
+// trashPurgeOrder - порядок очистки: сначала зависимые сущности, чтобы каскад не удалял их раньше подсчета
+var trashPurgeOrder = []models.TrashKind{
+	models.TrashKindComment,
+	models.TrashKindArticle,
+	models.TrashKindBook,
+	models.TrashKindUser,
+}
+
+// TrashRepository - реализация репозитория корзины
+type TrashRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTrashRepository - создание нового TrashRepository
+func NewTrashRepository(pool *pgxpool.Pool) interfaces.TrashRepository {
+	return &TrashRepository{
+		pool: pool,
+	}
+}
+
+// List - удаленные сущности от недавно удаленных к давним (пустой kind - все типы)
+func (r *TrashRepository) List(ctx context.Context, kind models.TrashKind, limit, offset int) ([]*models.TrashItem, error) {
+	kinds := []models.TrashKind{kind}
+	if kind == "" {
+		kinds = []models.TrashKind{models.TrashKindBook, models.TrashKindArticle, models.TrashKindComment, models.TrashKindUser}
+	}
+
+	parts := make([]string, len(kinds))
+	for i, k := range kinds {
+		t := trashTables[k]
+		parts[i] = fmt.Sprintf(`SELECT '%s' AS type, id::text AS id, %s AS title, deleted_at FROM %s WHERE deleted_at IS NOT NULL`,
+			k, t.title, t.table)
+	}
+	query := strings.Join(parts, "\n\t\tUNION ALL\n\t\t") + `
+		ORDER BY deleted_at DESC
+		LIMIT $1 OFFSET $2`
+
+	var items []*models.TrashItem
+	if err := pgxscan.Select(ctx, db.ExecutorFrom(ctx, r.pool), &items, query, limit, offset); err != nil {
+		return nil, dbError("failed to select trash", err)
+	}
+	return items, nil
+}
+
+// Lock - строка удаленной сущности в JSON с блокировкой до конца транзакции (вызывать в транзакции)
+func (r *TrashRepository) Lock(ctx context.Context, kind models.TrashKind, id string) (json.RawMessage, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s t WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE`,
+		trashSnapshot, trashTables[kind].table)
+
+	var row json.RawMessage
+	if err := db.ExecutorFrom(ctx, r.pool).QueryRow(ctx, query, id).Scan(&row); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, interfaces.ErrTrashItemNotFound
+		}
+		return nil, dbError("failed to select trash item", err)
+	}
+	return row, nil
+}
+
+// Restore - снятие отметки об удалении; возвращает восстановленную строку в JSON
+func (r *TrashRepository) Restore(ctx context.Context, kind models.TrashKind, id string) (json.RawMessage, error) {
+	query := fmt.Sprintf(`UPDATE %s t SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL RETURNING %s`,
+		trashTables[kind].table, trashSnapshot)
+
+	var row json.RawMessage
+	if err := db.ExecutorFrom(ctx, r.pool).QueryRow(ctx, query, id).Scan(&row); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, interfaces.ErrTrashItemNotFound
+		}
+		return nil, dbError("failed to restore from trash", err)
+	}
+	return row, nil
+}
+
+// Purge - окончательное удаление сущности из корзины (вызывать в транзакции: для книги удаляется и история правок)
+func (r *TrashRepository) Purge(ctx context.Context, kind models.TrashKind, id string) error {
+	if kind == models.TrashKindBook {
+		if err := r.purgeBookRevisions(ctx, "books.id = $1 AND books.deleted_at IS NOT NULL", id); err != nil {
+			return err
+		}
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 AND deleted_at IS NOT NULL`, trashTables[kind].table)
+
+	tag, err := db.ExecutorFrom(ctx, r.pool).Exec(ctx, query, id)
+	if err != nil {
+		return dbError("failed to purge from trash", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return interfaces.ErrTrashItemNotFound
+	}
+	return nil
+}
+
+// PurgeDeletedBefore - окончательное удаление всех сущностей, удаленных раньше before (вызывать в транзакции)
+func (r *TrashRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	if err := r.purgeBookRevisions(ctx, "books.deleted_at < $1", before); err != nil {
+		return 0, err
+	}
+
+	var purged int64
+	for _, kind := range trashPurgeOrder {
+		query := fmt.Sprintf(`DELETE FROM %s WHERE deleted_at < $1`, trashTables[kind].table)
+
+		tag, err := db.ExecutorFrom(ctx, r.pool).Exec(ctx, query, before)
+		if err != nil {
+			return 0, dbError("failed to purge trash", err)
+		}
+		purged += tag.RowsAffected()
+	}
+	return purged, nil
+}
+
+// purgeBookRevisions - удаление истории правок книг, их частей и профилей персонажей.
+// У revisions нет внешних ключей на сущности, поэтому каскад их не затрагивает.
+func (r *TrashRepository) purgeBookRevisions(ctx context.Context, booksFilter string, arg any) error {
+	query := `
+		DELETE FROM revisions
+		WHERE (entity_type = 'book' AND entity_id IN (
+				SELECT books.id::text FROM books WHERE ` + booksFilter + `))
+		   OR (entity_type = 'book_part' AND entity_id IN (
+				SELECT p.id FROM book_parts p JOIN books ON books.id = p.book_id WHERE ` + booksFilter + `))
+		   OR (entity_type = 'character_profile' AND entity_id IN (
+				SELECT cp.id::text FROM character_profiles cp JOIN books ON books.id = cp.book_id WHERE ` + booksFilter + `))`
+
+	if _, err := db.ExecutorFrom(ctx, r.pool).Exec(ctx, query, arg); err != nil {
+		return dbError("failed to purge book revisions", err)
+	}
+	return nil
+}
@@ -2,8 +2,8 @@ package repositories
 
 import (
 	"context"
-	"fmt"
-	"log"
+	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,30 +11,30 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/tukembaev/bookVisionGo/internal/db"
 	"github.com/tukembaev/bookVisionGo/internal/models"
 	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
 )
 
 // userRepository - реализация UserRepository
 type userRepository struct {
-	db *pgxpool.Pool
+	pool *pgxpool.Pool
 }
 
 // NewUserRepository - создание нового UserRepository
-func NewUserRepository(db *pgxpool.Pool) interfaces.UserRepository {
+func NewUserRepository(pool *pgxpool.Pool) interfaces.UserRepository {
 	return &userRepository{
-		db: db,
+		pool: pool,
 	}
 }
 
+// queries - запросы sqlc в транзакции из контекста или через пул
+func (r *userRepository) queries(ctx context.Context) *db.Queries {
+	return db.New(db.ExecutorFrom(ctx, r.pool))
+}
+
 // Create - создание нового пользователя
 func (r *userRepository) Create(ctx context.Context, user *models.User) error {
-	query := `
-		INSERT INTO users (id, username, email, password_hash, avatar_url, role, created_at, 
-		                  books_read, reviews_count, likes_received, profile_visibility, activity_visibility)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-	`
-
 	// Генерация UUID если не задан
 	if user.ID == "" {
 		user.ID = uuid.New().String()
@@ -43,7 +43,7 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	// Хеширование пароля
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.PasswordHash), bcrypt.DefaultCost)
 	if err != nil {
-		return fmt.Errorf("failed to hash password: %w", err)
+		return dbError("failed to hash password", err)
 	}
 
 	// Установка значений по умолчанию
@@ -53,184 +53,148 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 	if user.CreatedAt.IsZero() {
 		user.CreatedAt = time.Now()
 	}
-
-	_, err = r.db.Exec(ctx, query,
-		user.ID,
-		user.Username,
-		user.Email,
-		string(hashedPassword),
-		user.AvatarURL,
-		user.Role,
-		user.CreatedAt,
-		user.BooksRead,
-		user.ReviewsCount,
-		user.LikesReceived,
-		user.ProfileVisibility,
-		user.ActivityVisibility,
-	)
-
+	if user.ProfileVisibility == "" {
+		user.ProfileVisibility = models.VisibilityPublic
+	}
+	if user.ActivityVisibility == "" {
+		user.ActivityVisibility = models.VisibilityPublic
+	}
+
+	err = r.queries(ctx).CreateUser(ctx, db.CreateUserParams{
+		ID:                 user.ID,
+		Username:           user.Username,
+		Email:              user.Email,
+		PasswordHash:       string(hashedPassword),
+		AvatarURL:          user.AvatarURL,
+		Role:               user.Role,
+		CreatedAt:          &user.CreatedAt,
+		BooksRead:          toInt32Ptr(&user.BooksRead),
+		ReviewsCount:       toInt32Ptr(&user.ReviewsCount),
+		LikesReceived:      toInt32Ptr(&user.LikesReceived),
+		ProfileVisibility:  user.ProfileVisibility,
+		ActivityVisibility: user.ActivityVisibility,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
+		return dbError("failed to create user", err)
 	}
 
-	log.Printf("User created successfully: %s", user.Username)
+	slog.DebugContext(ctx, "user created", "user_id", user.ID)
 	return nil
 }
 
 // GetByID - получение пользователя по ID
 func (r *userRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
-	query := `
-		SELECT id, username, email, password_hash, avatar_url, role, created_at,
-			   books_read, reviews_count, likes_received, profile_visibility, activity_visibility
-		FROM users 
-		WHERE id = $1
-	`
-
-	var user models.User
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PasswordHash,
-		&user.AvatarURL,
-		&user.Role,
-		&user.CreatedAt,
-		&user.BooksRead,
-		&user.ReviewsCount,
-		&user.LikesReceived,
-		&user.ProfileVisibility,
-		&user.ActivityVisibility,
-	)
-
+	row, err := r.queries(ctx).GetUser(ctx, id)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, interfaces.ErrUserNotFound
 		}
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, dbError("failed to get user", err)
 	}
 
-	return &user, nil
+	return userFromRow(row), nil
 }
 
 // GetByUsername - получение пользователя по username
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
-	query := `
-		SELECT id, username, email, password_hash, avatar_url, role, created_at,
-			   books_read, reviews_count, likes_received, profile_visibility, activity_visibility
-		FROM users 
-		WHERE username = $1
-	`
-
-	var user models.User
-	err := r.db.QueryRow(ctx, query, username).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PasswordHash,
-		&user.AvatarURL,
-		&user.Role,
-		&user.CreatedAt,
-		&user.BooksRead,
-		&user.ReviewsCount,
-		&user.LikesReceived,
-		&user.ProfileVisibility,
-		&user.ActivityVisibility,
-	)
-
+	row, err := r.queries(ctx).GetUserByUsername(ctx, username)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, interfaces.ErrUserNotFound
 		}
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, dbError("failed to get user", err)
 	}
 
-	return &user, nil
+	return userFromRow(row), nil
 }
 
 // Update - обновление данных пользователя
 func (r *userRepository) Update(ctx context.Context, user *models.User) error {
-	query := `
-		UPDATE users 
-		SET username = $2, avatar_url = $3, role = $4, 
-		    books_read = $5, reviews_count = $6, likes_received = $7,
-		    profile_visibility = $8, activity_visibility = $9
-		WHERE id = $1
-	`
-
-	_, err := r.db.Exec(ctx, query,
-		user.ID,
-		user.Username,
-		user.AvatarURL,
-		user.Role,
-		user.BooksRead,
-		user.ReviewsCount,
-		user.LikesReceived,
-		user.ProfileVisibility,
-		user.ActivityVisibility,
-	)
+	err := r.queries(ctx).UpdateUser(ctx, db.UpdateUserParams{
+		ID:                 user.ID,
+		Username:           user.Username,
+		AvatarURL:          user.AvatarURL,
+		Role:               user.Role,
+		BooksRead:          toInt32Ptr(&user.BooksRead),
+		ReviewsCount:       toInt32Ptr(&user.ReviewsCount),
+		LikesReceived:      toInt32Ptr(&user.LikesReceived),
+		ProfileVisibility:  user.ProfileVisibility,
+		ActivityVisibility: user.ActivityVisibility,
+	})
+	if err != nil {
+		return dbError("failed to update user", err)
+	}
+
+	slog.DebugContext(ctx, "user updated", "user_id", user.ID)
+	return nil
+}
 
+// UpdateAvatarURL - замена ссылки на аватар пользователя
+func (r *userRepository) UpdateAvatarURL(ctx context.Context, id string, avatarURL *string) error {
+	rows, err := r.queries(ctx).UpdateUserAvatarURL(ctx, db.UpdateUserAvatarURLParams{ID: id, AvatarURL: avatarURL})
 	if err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+		return dbError("failed to update avatar", err)
 	}
 
-	log.Printf("User updated successfully: %s", user.Username)
+	if rows == 0 {
+		return interfaces.ErrUserNotFound
+	}
 	return nil
 }
 
-// Delete - удаление пользователя
+// Delete - перемещение пользователя в корзину
 func (r *userRepository) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM users WHERE id = $1`
+	rows, err := r.queries(ctx).DeleteUser(ctx, id)
+	if err != nil {
+		return dbError("failed to delete user", err)
+	}
 
-	result, err := r.db.Exec(ctx, query, id)
+	if rows == 0 {
+		return interfaces.ErrUserNotFound
+	}
+
+	slog.DebugContext(ctx, "user deleted", "user_id", id)
+	return nil
+}
+
+// AddStrike - предупреждение пользователю; возвращает новое число предупреждений
+func (r *userRepository) AddStrike(ctx context.Context, id string) (int, error) {
+	strikes, err := r.queries(ctx).AddUserStrike(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, interfaces.ErrUserNotFound
+	}
 	if err != nil {
-		return fmt.Errorf("failed to delete user: %w", err)
+		return 0, dbError("failed to add user strike", err)
 	}
+	return int(strikes), nil
+}
 
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("user not found")
+// SetSuspension - блокировка пользователя до until (nil - снятие блокировки)
+func (r *userRepository) SetSuspension(ctx context.Context, id string, until *time.Time) error {
+	rows, err := r.queries(ctx).SetUserSuspension(ctx, db.SetUserSuspensionParams{ID: id, SuspendedUntil: until})
+	if err != nil {
+		return dbError("failed to update user suspension", err)
 	}
 
-	log.Printf("User deleted successfully: %s", id)
+	if rows == 0 {
+		return interfaces.ErrUserNotFound
+	}
 	return nil
 }
 
 // List - получение списка пользователей с пагинацией
 func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
-	query := `
-		SELECT id, username, email, password_hash, avatar_url, role, created_at,
-			   books_read, reviews_count, likes_received, profile_visibility, activity_visibility
-		FROM users 
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2
-	`
-
-	rows, err := r.db.Query(ctx, query, limit, offset)
+	rows, err := r.queries(ctx).ListUsers(ctx, db.ListUsersParams{
+		Limit:  toInt32(limit),
+		Offset: toInt32(offset),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list users: %w", err)
+		return nil, dbError("failed to list users", err)
 	}
-	defer rows.Close()
-
-	var users []*models.User
-	for rows.Next() {
-		var user models.User
-		err := rows.Scan(
-			&user.ID,
-			&user.Username,
-			&user.Email,
-			&user.PasswordHash,
-			&user.AvatarURL,
-			&user.Role,
-			&user.CreatedAt,
-			&user.BooksRead,
-			&user.ReviewsCount,
-			&user.LikesReceived,
-			&user.ProfileVisibility,
-			&user.ActivityVisibility,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan user: %w", err)
-		}
-		users = append(users, &user)
+
+	users := make([]*models.User, len(rows))
+	for i, row := range rows {
+		users[i] = userFromRow(row)
 	}
 
 	return users, nil
@@ -238,28 +202,48 @@ func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*models
 
 // Count - подсчет общего количества пользователей
 func (r *userRepository) Count(ctx context.Context) (int, error) {
-	query := `SELECT COUNT(*) FROM users`
-
-	var count int
-	err := r.db.QueryRow(ctx, query).Scan(&count)
+	count, err := r.queries(ctx).CountUsers(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count users: %w", err)
+		return 0, dbError("failed to count users", err)
 	}
 
-	return count, nil
+	return int(count), nil
 }
 
 // VerifyPassword - проверка пароля пользователя
 func (r *userRepository) VerifyPassword(ctx context.Context, username, password string) (*models.User, error) {
 	user, err := r.GetByUsername(ctx, username)
+	if errors.Is(err, interfaces.ErrUserNotFound) {
+		return nil, interfaces.ErrInvalidCredentials
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
 	if err != nil {
-		return nil, fmt.Errorf("invalid password")
+		return nil, interfaces.ErrInvalidCredentials
 	}
 
 	return user, nil
 }
+
+// userFromRow - модель пользователя из строки sqlc
+func userFromRow(row db.User) *models.User {
+	return &models.User{
+		ID:                 row.ID,
+		Username:           row.Username,
+		Email:              row.Email,
+		PasswordHash:       row.PasswordHash,
+		AvatarURL:          row.AvatarURL,
+		Role:               row.Role,
+		CreatedAt:          timeOrZero(row.CreatedAt),
+		BooksRead:          intOrZero(row.BooksRead),
+		ReviewsCount:       intOrZero(row.ReviewsCount),
+		LikesReceived:      intOrZero(row.LikesReceived),
+		ProfileVisibility:  row.ProfileVisibility,
+		ActivityVisibility: row.ActivityVisibility,
+		Strikes:            int(row.Strikes),
+		SuspendedUntil:     row.SuspendedUntil,
+	}
+}
@@ -0,0 +1,287 @@
+package seed
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"go.yaml.in/yaml/v3"
+)
+
+// FixtureVersion - поддерживаемая версия формата файлов фикстур
+const FixtureVersion = 1
+
+// fixturesFS - наборы фикстур, встроенные в бинарник (по директории на набор)
+//
+//go:embed fixtures
+var fixturesFS embed.FS
+
+// idNamespace - пространство имен UUIDv5 для детерминированных ID фикстур
+var idNamespace = uuid.MustParse("5b7d3a3e-8f0c-4a51-9a3c-0c6f4b7e2d10")
+
+// fixtureID - детерминированный ID сущности по ее ключу
+func fixtureID(kind, key string) string {
+	return uuid.NewSHA1(idNamespace, []byte(kind+"/"+key)).String()
+}
+
+// Manifest - описание набора фикстур (dataset.yaml)
+type Manifest struct {
+	Version     int      `yaml:"version"`
+	Description string   `yaml:"description"`
+	Requires    []string `yaml:"requires"`
+}
+
+// Dataset - набор фикстур
+type Dataset struct {
+	Name     string
+	Manifest Manifest
+	Fixtures Fixtures
+}
+
+// Fixtures - содержимое файлов фикстур; каждый файл может содержать любые разделы
+type Fixtures struct {
+	Version    int                `yaml:"version"`
+	Users      []UserFixture      `yaml:"users,omitempty"`
+	Books      []BookFixture      `yaml:"books,omitempty"`
+	Characters []CharacterFixture `yaml:"characters,omitempty"`
+	Reviews    []ReviewFixture    `yaml:"reviews,omitempty"`
+	Challenges []ChallengeFixture `yaml:"challenges,omitempty"`
+	Playlists  []PlaylistFixture  `yaml:"playlists,omitempty"`
+	Articles   []ArticleFixture   `yaml:"articles,omitempty"`
+}
+
+// UserFixture - пользователь
+type UserFixture struct {
+	Key                string            `yaml:"key"`
+	ID                 string            `yaml:"id,omitempty"`
+	Username           string            `yaml:"username"`
+	Email              string            `yaml:"email"`
+	Password           string            `yaml:"password"`
+	Role               models.UserRole   `yaml:"role,omitempty"`
+	AvatarURL          *string           `yaml:"avatar_url,omitempty"`
+	ProfileVisibility  models.Visibility `yaml:"profile_visibility,omitempty"`
+	ActivityVisibility models.Visibility `yaml:"activity_visibility,omitempty"`
+}
+
+// BookFixture - книга вместе с частями
+type BookFixture struct {
+	Key              string                   `yaml:"key"`
+	ID               string                   `yaml:"id,omitempty"`
+	Title            string                   `yaml:"title"`
+	OriginalTitle    *string                  `yaml:"original_title,omitempty"`
+	Author           string                   `yaml:"author"`
+	Year             *int                     `yaml:"year,omitempty"`
+	Genres           []string                 `yaml:"genres,flow"`
+	AgeRating        *models.AgeRating        `yaml:"age_rating,omitempty"`
+	AuthorCountry    *string                  `yaml:"author_country,omitempty"`
+	Description      string                   `yaml:"description"`
+	CoverURL         *string                  `yaml:"cover_url,omitempty"`
+	PagesCount       int                      `yaml:"pages_count"`
+	Tags             []string                 `yaml:"tags,flow"`
+	Verified         bool                     `yaml:"verified"`
+	VerificationType *models.VerificationType `yaml:"verification_type,omitempty"`
+	CreatedAt        time.Time                `yaml:"created_at"`
+	AverageRating    float64                  `yaml:"average_rating"`
+	RatingCount      int                      `yaml:"rating_count"`
+	Parts            []PartFixture            `yaml:"parts"`
+}
+
+// PartFixture - часть книги; порядковый номер берется из позиции в списке
+type PartFixture struct {
+	ID            string   `yaml:"id,omitempty"`
+	Title         string   `yaml:"title"`
+	Content       string   `yaml:"content,omitempty"`
+	PageStart     *int     `yaml:"page_start,omitempty"`
+	PageEnd       *int     `yaml:"page_end,omitempty"`
+	MoodTags      []string `yaml:"mood_tags,flow"`
+	AverageRating *float64 `yaml:"average_rating,omitempty"`
+}
+
+// CharacterFixture - персонаж книги
+type CharacterFixture struct {
+	Key             string `yaml:"key"`
+	ID              string `yaml:"id,omitempty"`
+	Book            string `yaml:"book"`
+	Name            string `yaml:"name"`
+	Description     string `yaml:"description"`
+	Source          string `yaml:"source,omitempty"`
+	Verified        bool   `yaml:"verified"`
+	PopularityScore int    `yaml:"popularity_score"`
+}
+
+// ReviewFixture - отзыв пользователя на книгу (один на пару пользователь/книга)
+type ReviewFixture struct {
+	User               string    `yaml:"user"`
+	Book               string    `yaml:"book"`
+	Rating             int       `yaml:"rating"`
+	Text               string    `yaml:"text"`
+	LikedCharacters    []string  `yaml:"liked_characters,flow"`
+	DislikedCharacters []string  `yaml:"disliked_characters,flow"`
+	BestParts          []string  `yaml:"best_parts,flow"`
+	CreatedAt          time.Time `yaml:"created_at"`
+}
+
+// ChallengeFixture - челлендж
+type ChallengeFixture struct {
+	Key          string    `yaml:"key"`
+	ID           string    `yaml:"id,omitempty"`
+	Title        string    `yaml:"title"`
+	Description  string    `yaml:"description"`
+	Type         string    `yaml:"type"`
+	TargetCount  int       `yaml:"target_count"`
+	RewardPoints int       `yaml:"reward_points"`
+	RewardType   string    `yaml:"reward_type"`
+	CreatedAt    time.Time `yaml:"created_at"`
+}
+
+// PlaylistFixture - музыкальный плейлист
+type PlaylistFixture struct {
+	Key       string    `yaml:"key"`
+	ID        string    `yaml:"id,omitempty"`
+	Title     string    `yaml:"title"`
+	MoodTag   string    `yaml:"mood_tag"`
+	Tracks    []string  `yaml:"tracks"`
+	CreatedBy string    `yaml:"created_by,omitempty"`
+	CreatedAt time.Time `yaml:"created_at"`
+}
+
+// ArticleFixture - статья; book и author - ключи фикстур
+type ArticleFixture struct {
+	Key              string                   `yaml:"key"`
+	ID               string                   `yaml:"id,omitempty"`
+	Title            string                   `yaml:"title"`
+	Type             string                   `yaml:"type"`
+	Author           string                   `yaml:"author,omitempty"`
+	Book             string                   `yaml:"book,omitempty"`
+	Excerpt          string                   `yaml:"excerpt"`
+	CreatedAt        time.Time                `yaml:"created_at"`
+	Likes            int                      `yaml:"likes"`
+	Views            int                      `yaml:"views"`
+	ReadingMinutes   *int                     `yaml:"reading_minutes,omitempty"`
+	CoverURL         *string                  `yaml:"cover_url,omitempty"`
+	Verified         bool                     `yaml:"verified"`
+	VerificationType *models.VerificationType `yaml:"verification_type,omitempty"`
+	NoSpoilers       bool                     `yaml:"no_spoilers"`
+	Readiness        string                   `yaml:"readiness,omitempty"`
+	Content          []map[string]interface{} `yaml:"content"`
+}
+
+// LoadDatasets - загрузка всех встроенных наборов фикстур
+func LoadDatasets() (map[string]*Dataset, error) {
+	entries, err := fs.ReadDir(fixturesFS, "fixtures")
+	if err != nil {
+		return nil, err
+	}
+
+	datasets := make(map[string]*Dataset)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dataset, err := loadDataset(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		datasets[dataset.Name] = dataset
+	}
+	return datasets, nil
+}
+
+// loadDataset - чтение манифеста и файлов фикстур набора
+func loadDataset(name string) (*Dataset, error) {
+	dir := path.Join("fixtures", name)
+	dataset := &Dataset{Name: name}
+
+	if err := readYAML(path.Join(dir, "dataset.yaml"), &dataset.Manifest); err != nil {
+		return nil, err
+	}
+	if dataset.Manifest.Version != FixtureVersion {
+		return nil, fmt.Errorf("dataset %s: unsupported version %d", name, dataset.Manifest.Version)
+	}
+
+	files, err := fs.Glob(fixturesFS, path.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if path.Base(file) == "dataset.yaml" {
+			continue
+		}
+
+		var fixtures Fixtures
+		if err := readYAML(file, &fixtures); err != nil {
+			return nil, err
+		}
+		if fixtures.Version != FixtureVersion {
+			return nil, fmt.Errorf("%s: unsupported version %d", file, fixtures.Version)
+		}
+
+		f := &dataset.Fixtures
+		f.Users = append(f.Users, fixtures.Users...)
+		f.Books = append(f.Books, fixtures.Books...)
+		f.Characters = append(f.Characters, fixtures.Characters...)
+		f.Reviews = append(f.Reviews, fixtures.Reviews...)
+		f.Challenges = append(f.Challenges, fixtures.Challenges...)
+		f.Playlists = append(f.Playlists, fixtures.Playlists...)
+		f.Articles = append(f.Articles, fixtures.Articles...)
+	}
+	return dataset, nil
+}
+
+// resolveDatasets - выбранные наборы вместе с зависимостями (зависимости идут первыми)
+func resolveDatasets(all map[string]*Dataset, names []string) ([]*Dataset, error) {
+	var ordered []*Dataset
+	visiting := make(map[string]bool)
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		dataset, ok := all[name]
+		if !ok {
+			return fmt.Errorf("unknown dataset %q", name)
+		}
+		if slices.Contains(ordered, dataset) {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("dataset %q has a circular dependency", name)
+		}
+		visiting[name] = true
+		for _, dep := range dataset.Manifest.Requires {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		ordered = append(ordered, dataset)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// readYAML - чтение YAML файла из встроенных фикстур (неизвестные поля - ошибка)
+func readYAML(name string, out interface{}) error {
+	file, err := fixturesFS.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(out); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
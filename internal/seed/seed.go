@@ -0,0 +1,436 @@
+// Package seed заполняет базу данных из версионированных наборов фикстур и генерирует синтетические данные.
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/tukembaev/bookVisionGo/internal/db"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Options - параметры заполнения базы
+type Options struct {
+	// Datasets - имена наборов фикстур (зависимости подключаются автоматически)
+	Datasets []string
+	// Truncate - очистить таблицы заполняемых сущностей перед загрузкой
+	Truncate bool
+	// Synthetic - параметры генератора синтетических данных (nil - не генерировать)
+	Synthetic *SyntheticOptions
+}
+
+// Report - количество загруженных записей по сущностям
+type Report struct {
+	Datasets   []string
+	Users      int
+	Books      int
+	Parts      int
+	Characters int
+	Reviews    int
+	Challenges int
+	Playlists  int
+	Articles   int
+}
+
+// String - краткая сводка для вывода в консоль
+func (r *Report) String() string {
+	return fmt.Sprintf("datasets=%s users=%d books=%d parts=%d characters=%d reviews=%d challenges=%d playlists=%d articles=%d",
+		strings.Join(r.Datasets, ","), r.Users, r.Books, r.Parts, r.Characters, r.Reviews, r.Challenges, r.Playlists, r.Articles)
+}
+
+// Run - загрузка выбранных наборов в одной транзакции. ID детерминированы,
+// поэтому повторный запуск обновляет существующие записи, а не дублирует их.
+func Run(ctx context.Context, pool *pgxpool.Pool, opts Options) (*Report, error) {
+	all, err := LoadDatasets()
+	if err != nil {
+		return nil, err
+	}
+	datasets, err := resolveDatasets(all, opts.Datasets)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures Fixtures
+	report := &Report{}
+	for _, dataset := range datasets {
+		report.Datasets = append(report.Datasets, dataset.Name)
+		f := dataset.Fixtures
+		fixtures.Users = append(fixtures.Users, f.Users...)
+		fixtures.Books = append(fixtures.Books, f.Books...)
+		fixtures.Characters = append(fixtures.Characters, f.Characters...)
+		fixtures.Reviews = append(fixtures.Reviews, f.Reviews...)
+		fixtures.Challenges = append(fixtures.Challenges, f.Challenges...)
+		fixtures.Playlists = append(fixtures.Playlists, f.Playlists...)
+		fixtures.Articles = append(fixtures.Articles, f.Articles...)
+	}
+
+	refs, err := newRegistry(&fixtures)
+	if err != nil {
+		return nil, err
+	}
+
+	err = pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		if opts.Truncate {
+			if err := truncate(ctx, tx, &fixtures, opts.Synthetic != nil); err != nil {
+				return err
+			}
+		}
+
+		s := &seeder{tx: tx, queries: db.New(tx), refs: refs, report: report}
+		steps := []func(context.Context, *Fixtures) error{
+			s.users, s.books, s.characters, s.reviews, s.challenges, s.playlists, s.articles,
+		}
+		for _, step := range steps {
+			if err := step(ctx, &fixtures); err != nil {
+				return err
+			}
+		}
+
+		if opts.Synthetic != nil {
+			return generate(ctx, tx, *opts.Synthetic, report)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// truncate - очистка таблиц сущностей, присутствующих в выбранных наборах
+func truncate(ctx context.Context, tx pgx.Tx, f *Fixtures, synthetic bool) error {
+	var tables []string
+	add := func(ok bool, names ...string) {
+		if ok {
+			tables = append(tables, names...)
+		}
+	}
+	add(len(f.Users) > 0 || synthetic, "users")
+	add(len(f.Books) > 0 || synthetic, "books", "book_parts")
+	add(len(f.Characters) > 0, "characters")
+	add(len(f.Reviews) > 0 || synthetic, "reviews")
+	add(len(f.Challenges) > 0, "challenges")
+	add(len(f.Playlists) > 0, "playlists")
+	add(len(f.Articles) > 0, "articles")
+	if len(tables) == 0 {
+		return nil
+	}
+
+	// CASCADE очищает и зависимые таблицы (прогресс, полки, лента и т.д.)
+	if _, err := tx.Exec(ctx, "TRUNCATE "+strings.Join(tables, ", ")+" CASCADE"); err != nil {
+		return fmt.Errorf("failed to truncate tables: %w", err)
+	}
+	return nil
+}
+
+// registry - ID сущностей по ключам фикстур
+type registry struct {
+	users map[string]string
+	books map[string]string
+}
+
+// newRegistry - сопоставление ключей с ID (явный id из фикстуры или детерминированный UUIDv5)
+func newRegistry(f *Fixtures) (*registry, error) {
+	r := &registry{users: make(map[string]string), books: make(map[string]string)}
+	for _, user := range f.Users {
+		if err := register(r.users, "user", user.Key, user.ID); err != nil {
+			return nil, err
+		}
+	}
+	for _, book := range f.Books {
+		if err := register(r.books, "book", book.Key, book.ID); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// register - добавление ключа в реестр с проверкой дубликатов
+func register(ids map[string]string, kind, key, id string) error {
+	if key == "" {
+		return fmt.Errorf("%s fixture without key", kind)
+	}
+	if _, exists := ids[key]; exists {
+		return fmt.Errorf("duplicate %s key %q", kind, key)
+	}
+	ids[key] = entityID(kind, key, id)
+	return nil
+}
+
+// entityID - явный ID фикстуры или детерминированный по ключу
+func entityID(kind, key, id string) string {
+	if id != "" {
+		return id
+	}
+	return fixtureID(kind, key)
+}
+
+// user - ID пользователя по ключу
+func (r *registry) user(key string) (string, error) {
+	id, ok := r.users[key]
+	if !ok {
+		return "", fmt.Errorf("unknown user key %q", key)
+	}
+	return id, nil
+}
+
+// book - ID книги по ключу
+func (r *registry) book(key string) (string, error) {
+	id, ok := r.books[key]
+	if !ok {
+		return "", fmt.Errorf("unknown book key %q", key)
+	}
+	return id, nil
+}
+
+// optionalRef - ID по необязательному ключу (пустой ключ - NULL)
+func optionalRef(key string, resolve func(string) (string, error)) (*string, error) {
+	if key == "" {
+		return nil, nil
+	}
+	id, err := resolve(key)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// seeder - загрузка фикстур в рамках транзакции; книги, части, пользователи
+// и статьи пишутся через сгенерированные sqlc запросы
+type seeder struct {
+	tx      pgx.Tx
+	queries *db.Queries
+	refs    *registry
+	report  *Report
+}
+
+func (s *seeder) users(ctx context.Context, f *Fixtures) error {
+	for _, user := range f.Users {
+		hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return fmt.Errorf("failed to hash password of user %s: %w", user.Key, err)
+		}
+		err = s.queries.UpsertUser(ctx, db.UpsertUserParams{
+			ID:                 s.refs.users[user.Key],
+			Username:           user.Username,
+			Email:              user.Email,
+			PasswordHash:       string(hash),
+			AvatarURL:          user.AvatarURL,
+			Role:               withDefault(user.Role, models.UserRoleUser),
+			ProfileVisibility:  withDefault(user.ProfileVisibility, models.VisibilityPublic),
+			ActivityVisibility: withDefault(user.ActivityVisibility, models.VisibilityPublic),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to seed user %s: %w", user.Key, err)
+		}
+		s.report.Users++
+	}
+	return nil
+}
+
+func (s *seeder) books(ctx context.Context, f *Fixtures) error {
+	for _, book := range f.Books {
+		bookID := s.refs.books[book.Key]
+		err := s.queries.UpsertBook(ctx, db.UpsertBookParams{
+			ID:               bookID,
+			Title:            book.Title,
+			OriginalTitle:    book.OriginalTitle,
+			Author:           book.Author,
+			Year:             int32Ptr(book.Year),
+			Genres:           book.Genres,
+			AgeRating:        book.AgeRating,
+			AuthorCountry:    book.AuthorCountry,
+			Description:      book.Description,
+			CoverURL:         book.CoverURL,
+			PagesCount:       int32(book.PagesCount),
+			Tags:             book.Tags,
+			Verified:         &book.Verified,
+			VerificationType: book.VerificationType,
+			CreatedAt:        &book.CreatedAt,
+			AverageRating:    &book.AverageRating,
+			RatingCount:      int32Ptr(&book.RatingCount),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to seed book %s: %w", book.Key, err)
+		}
+		s.report.Books++
+
+		for i, part := range book.Parts {
+			orderNum := i + 1
+			err := s.queries.UpsertBookPart(ctx, db.UpsertBookPartParams{
+				ID:            entityID("part", fmt.Sprintf("%s/%d", book.Key, orderNum), part.ID),
+				BookID:        &bookID,
+				Title:         part.Title,
+				Content:       part.Content,
+				OrderNum:      int32(orderNum),
+				PageStart:     int32Ptr(part.PageStart),
+				PageEnd:       int32Ptr(part.PageEnd),
+				MoodTags:      part.MoodTags,
+				AverageRating: part.AverageRating,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to seed part %d of book %s: %w", orderNum, book.Key, err)
+			}
+			s.report.Parts++
+		}
+	}
+	return nil
+}
+
+func (s *seeder) characters(ctx context.Context, f *Fixtures) error {
+	query := `
+		INSERT INTO characters (id, book_id, name, description, source, verified, popularity_score)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO UPDATE SET
+			book_id = EXCLUDED.book_id, name = EXCLUDED.name, description = EXCLUDED.description,
+			source = EXCLUDED.source, verified = EXCLUDED.verified, popularity_score = EXCLUDED.popularity_score`
+
+	for _, character := range f.Characters {
+		bookID, err := s.refs.book(character.Book)
+		if err != nil {
+			return fmt.Errorf("character %s: %w", character.Key, err)
+		}
+		_, err = s.tx.Exec(ctx, query, entityID("character", character.Key, character.ID), bookID, character.Name,
+			character.Description, withDefault(character.Source, "community"), character.Verified, character.PopularityScore)
+		if err != nil {
+			return fmt.Errorf("failed to seed character %s: %w", character.Key, err)
+		}
+		s.report.Characters++
+	}
+	return nil
+}
+
+func (s *seeder) reviews(ctx context.Context, f *Fixtures) error {
+	query := `
+		INSERT INTO reviews (id, user_id, book_id, rating, text, liked_characters, disliked_characters, best_parts, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, book_id) DO UPDATE SET
+			rating = EXCLUDED.rating, text = EXCLUDED.text, liked_characters = EXCLUDED.liked_characters,
+			disliked_characters = EXCLUDED.disliked_characters, best_parts = EXCLUDED.best_parts,
+			created_at = EXCLUDED.created_at`
+
+	for _, review := range f.Reviews {
+		userID, err := s.refs.user(review.User)
+		if err != nil {
+			return fmt.Errorf("review %s/%s: %w", review.User, review.Book, err)
+		}
+		bookID, err := s.refs.book(review.Book)
+		if err != nil {
+			return fmt.Errorf("review %s/%s: %w", review.User, review.Book, err)
+		}
+		_, err = s.tx.Exec(ctx, query, fixtureID("review", review.User+"/"+review.Book), userID, bookID, review.Rating,
+			review.Text, review.LikedCharacters, review.DislikedCharacters, review.BestParts, review.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to seed review %s/%s: %w", review.User, review.Book, err)
+		}
+		s.report.Reviews++
+	}
+	return nil
+}
+
+func (s *seeder) challenges(ctx context.Context, f *Fixtures) error {
+	query := `
+		INSERT INTO challenges (id, title, description, type, target_count, reward_points, reward_type, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE SET
+			title = EXCLUDED.title, description = EXCLUDED.description, type = EXCLUDED.type,
+			target_count = EXCLUDED.target_count, reward_points = EXCLUDED.reward_points,
+			reward_type = EXCLUDED.reward_type, created_at = EXCLUDED.created_at`
+
+	for _, challenge := range f.Challenges {
+		_, err := s.tx.Exec(ctx, query, entityID("challenge", challenge.Key, challenge.ID), challenge.Title,
+			challenge.Description, challenge.Type, challenge.TargetCount, challenge.RewardPoints,
+			challenge.RewardType, challenge.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to seed challenge %s: %w", challenge.Key, err)
+		}
+		s.report.Challenges++
+	}
+	return nil
+}
+
+func (s *seeder) playlists(ctx context.Context, f *Fixtures) error {
+	query := `
+		INSERT INTO playlists (id, title, mood_tag, tracks, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET
+			title = EXCLUDED.title, mood_tag = EXCLUDED.mood_tag, tracks = EXCLUDED.tracks,
+			created_by = EXCLUDED.created_by, created_at = EXCLUDED.created_at`
+
+	for _, playlist := range f.Playlists {
+		_, err := s.tx.Exec(ctx, query, entityID("playlist", playlist.Key, playlist.ID), playlist.Title,
+			playlist.MoodTag, playlist.Tracks, withDefault(playlist.CreatedBy, "system"), playlist.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to seed playlist %s: %w", playlist.Key, err)
+		}
+		s.report.Playlists++
+	}
+	return nil
+}
+
+func (s *seeder) articles(ctx context.Context, f *Fixtures) error {
+	for _, article := range f.Articles {
+		authorID, err := optionalRef(article.Author, s.refs.user)
+		if err != nil {
+			return fmt.Errorf("article %s: %w", article.Key, err)
+		}
+		bookID, err := optionalRef(article.Book, s.refs.book)
+		if err != nil {
+			return fmt.Errorf("article %s: %w", article.Key, err)
+		}
+		var readiness *string
+		if article.Readiness != "" {
+			readiness = &article.Readiness
+		}
+		content, err := json.Marshal(article.Content)
+		if err != nil {
+			return fmt.Errorf("article %s: failed to encode content: %w", article.Key, err)
+		}
+
+		err = s.queries.UpsertArticle(ctx, db.UpsertArticleParams{
+			ID:               entityID("article", article.Key, article.ID),
+			Title:            article.Title,
+			Type:             article.Type,
+			AuthorID:         authorID,
+			BookID:           bookID,
+			Excerpt:          article.Excerpt,
+			CreatedAt:        &article.CreatedAt,
+			Likes:            int32Ptr(&article.Likes),
+			Views:            int32Ptr(&article.Views),
+			ReadingMinutes:   int32Ptr(article.ReadingMinutes),
+			CoverURL:         article.CoverURL,
+			Verified:         &article.Verified,
+			VerificationType: article.VerificationType,
+			NoSpoilers:       article.NoSpoilers,
+			Readiness:        readiness,
+			Content:          content,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to seed article %s: %w", article.Key, err)
+		}
+		s.report.Articles++
+	}
+	return nil
+}
+
+// withDefault - значение или значение по умолчанию, если оно пустое
+func withDefault[T ~string](value, fallback T) T {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// int32Ptr - *int в *int32 для параметров sqlc
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v)
+	return &i
+}
@@ -0,0 +1,199 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// SyntheticPassword - пароль всех синтетических пользователей
+const SyntheticPassword = "loadtest"
+
+// syntheticBatchSize - количество запросов в одном pgx.Batch
+const syntheticBatchSize = 500
+
+// SyntheticOptions - параметры генератора данных для нагрузочного тестирования
+type SyntheticOptions struct {
+	Books          int
+	Users          int
+	PartsPerBook   int
+	ReviewsPerUser int
+	// Seed - зерно генератора; при одинаковых параметрах данные совпадают
+	Seed int64
+}
+
+var (
+	syntheticGenres = []string{"Роман", "Драма", "Фантастика", "Детектив", "Фэнтези", "Философия", "История", "Приключения"}
+	syntheticTags   = []string{"классика", "любовь", "война", "семья", "мистика", "общество", "путешествие", "взросление"}
+	syntheticMoods  = []string{"напряжение", "ностальгия", "радость", "грусть", "тревога", "надежда"}
+	syntheticWords  = []string{"тень", "город", "ветер", "письмо", "дорога", "память", "сад", "остров", "зима", "огонь", "море", "башня"}
+)
+
+// generate - синтетические книги с частями, пользователи и их отзывы.
+// ID строятся по ключам "synthetic/...", поэтому повторный запуск обновляет те же записи.
+func generate(ctx context.Context, tx pgx.Tx, opts SyntheticOptions, report *Report) error {
+	rnd := rand.New(rand.NewSource(opts.Seed))
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	batch := &batcher{tx: tx}
+
+	bookIDs := make([]string, opts.Books)
+	for i := range opts.Books {
+		key := fmt.Sprintf("synthetic/%06d", i+1)
+		bookIDs[i] = fixtureID("book", key)
+
+		title := fmt.Sprintf("%s %s №%d", capitalize(pick(rnd, syntheticWords)), pick(rnd, syntheticWords), i+1)
+		year := 1800 + rnd.Intn(225)
+		pages := 80 + rnd.Intn(800)
+		batch.queue(`
+			INSERT INTO books (id, title, author, year, genres, description, pages_count, tags, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (id) DO UPDATE SET
+				title = EXCLUDED.title, author = EXCLUDED.author, year = EXCLUDED.year, genres = EXCLUDED.genres,
+				description = EXCLUDED.description, pages_count = EXCLUDED.pages_count, tags = EXCLUDED.tags,
+				created_at = EXCLUDED.created_at`,
+			bookIDs[i], title, fmt.Sprintf("Автор %d", 1+rnd.Intn(max(opts.Books/3, 1))), year,
+			pickN(rnd, syntheticGenres, 1+rnd.Intn(3)), "Синтетическая книга для нагрузочного тестирования.",
+			pages, pickN(rnd, syntheticTags, 1+rnd.Intn(3)), base.Add(time.Duration(i)*time.Hour))
+		report.Books++
+
+		pagesPerPart := max(pages/max(opts.PartsPerBook, 1), 1)
+		for n := 1; n <= opts.PartsPerBook; n++ {
+			pageStart := (n-1)*pagesPerPart + 1
+			pageEnd := n * pagesPerPart
+			batch.queue(`
+				INSERT INTO book_parts (id, book_id, title, content, order_num, page_start, page_end, mood_tags)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+				ON CONFLICT (id) DO UPDATE SET
+					title = EXCLUDED.title, content = EXCLUDED.content, page_start = EXCLUDED.page_start,
+					page_end = EXCLUDED.page_end, mood_tags = EXCLUDED.mood_tags`,
+				fixtureID("part", fmt.Sprintf("%s/%d", key, n)), bookIDs[i], fmt.Sprintf("Глава %d", n),
+				paragraph(rnd, 40+rnd.Intn(80)), n, pageStart, pageEnd, pickN(rnd, syntheticMoods, 1+rnd.Intn(2)))
+			report.Parts++
+		}
+		if err := batch.flushIfFull(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Один хеш на всех пользователей: bcrypt на каждого сделал бы генерацию очень медленной
+	hash, err := bcrypt.GenerateFromPassword([]byte(SyntheticPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash synthetic password: %w", err)
+	}
+
+	for i := range opts.Users {
+		username := fmt.Sprintf("loadtest_user_%06d", i+1)
+		userID := fixtureID("user", "synthetic/"+username)
+		batch.queue(`
+			INSERT INTO users (id, username, email, password_hash, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (id) DO UPDATE SET
+				username = EXCLUDED.username, email = EXCLUDED.email, password_hash = EXCLUDED.password_hash`,
+			userID, username, username+"@loadtest.local", string(hash), base.Add(time.Duration(i)*time.Minute))
+		report.Users++
+
+		reviews := min(opts.ReviewsPerUser, len(bookIDs))
+		for _, b := range rnd.Perm(len(bookIDs))[:reviews] {
+			batch.queue(`
+				INSERT INTO reviews (id, user_id, book_id, rating, text, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				ON CONFLICT (user_id, book_id) DO UPDATE SET
+					rating = EXCLUDED.rating, text = EXCLUDED.text, created_at = EXCLUDED.created_at`,
+				fixtureID("review", fmt.Sprintf("synthetic/%s/%d", username, b)), userID, bookIDs[b],
+				1+rnd.Intn(10), paragraph(rnd, 10+rnd.Intn(30)), base.Add(time.Duration(rnd.Intn(365*24))*time.Hour))
+			report.Reviews++
+		}
+		if err := batch.flushIfFull(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.flush(ctx); err != nil {
+		return err
+	}
+
+	// Рейтинг синтетических книг считается по сгенерированным отзывам
+	if len(bookIDs) > 0 {
+		_, err := tx.Exec(ctx, `
+			UPDATE books b SET
+				average_rating = COALESCE(r.avg_rating, 0),
+				rating_count = COALESCE(r.cnt, 0)
+			FROM (SELECT unnest($1::uuid[]) AS id) ids
+			LEFT JOIN (
+				SELECT book_id, ROUND(AVG(rating), 1) AS avg_rating, COUNT(*) AS cnt
+				FROM reviews WHERE book_id = ANY($1::uuid[]) GROUP BY book_id
+			) r ON r.book_id = ids.id
+			WHERE b.id = ids.id`, bookIDs)
+		if err != nil {
+			return fmt.Errorf("failed to refresh synthetic book ratings: %w", err)
+		}
+	}
+	return nil
+}
+
+// batcher - накопление запросов и отправка пачками
+type batcher struct {
+	tx    pgx.Tx
+	batch pgx.Batch
+}
+
+func (b *batcher) queue(query string, args ...interface{}) {
+	b.batch.Queue(query, args...)
+}
+
+func (b *batcher) flushIfFull(ctx context.Context) error {
+	if b.batch.Len() < syntheticBatchSize {
+		return nil
+	}
+	return b.flush(ctx)
+}
+
+func (b *batcher) flush(ctx context.Context) error {
+	if b.batch.Len() == 0 {
+		return nil
+	}
+	err := b.tx.SendBatch(ctx, &b.batch).Close()
+	b.batch = pgx.Batch{}
+	if err != nil {
+		return fmt.Errorf("failed to insert synthetic data: %w", err)
+	}
+	return nil
+}
+
+// pick - случайный элемент
+func pick(rnd *rand.Rand, values []string) string {
+	return values[rnd.Intn(len(values))]
+}
+
+// pickN - n различных случайных элементов
+func pickN(rnd *rand.Rand, values []string, n int) []string {
+	result := make([]string, 0, n)
+	for _, i := range rnd.Perm(len(values))[:min(n, len(values))] {
+		result = append(result, values[i])
+	}
+	return result
+}
+
+// paragraph - текст из случайных слов
+func paragraph(rnd *rand.Rand, words int) string {
+	parts := make([]string, words)
+	for i := range parts {
+		parts[i] = pick(rnd, syntheticWords)
+	}
+	return capitalize(strings.Join(parts, " ")) + "."
+}
+
+// capitalize - первая буква в верхнем регистре
+func capitalize(s string) string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return s
+	}
+	return strings.ToUpper(string(runes[0])) + string(runes[1:])
+}
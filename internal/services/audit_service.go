@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/exporter"
+	"github.com/tukembaev/bookVisionGo/internal/logging"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+const (
+	defaultAuditLimit = 20
+	maxAuditLimit     = 100
+	// maxAuditExportRows - предел строк в одной CSV выгрузке; остальное - через фильтры по времени
+	maxAuditExportRows = 100000
+)
+
+// auditActorKey - ключ исполнителя запроса в context.Context
+type auditActorKey struct{}
+
+// WithAuditActor - контекст с исполнителем запроса; его записывает Record
+func WithAuditActor(ctx context.Context, actor *models.AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditActorFrom - исполнитель запроса из контекста (nil для фоновых задач и CLI)
+func AuditActorFrom(ctx context.Context) *models.AuditActor {
+	actor, _ := ctx.Value(auditActorKey{}).(*models.AuditActor)
+	return actor
+}
+
+// AuditService - сервис журнала действий модераторов и администраторов
+type AuditService struct {
+	auditRepo interfaces.AuditRepository
+}
+
+// NewAuditService - создание нового AuditService
+func NewAuditService(auditRepo interfaces.AuditRepository) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+	}
+}
+
+// Record - запись действия в журнал. Вызывается в транзакции изменения:
+// если запись не удалась, изменение откатывается вместе с ней.
+// before и after - состояние сущности до и после (nil, если его нет).
+func (s *AuditService) Record(ctx context.Context, action models.AuditAction, target models.AuditTarget, targetID string, before, after any) error {
+	entry := &models.AuditEntry{
+		Action:     action,
+		TargetType: target,
+		TargetID:   targetID,
+	}
+	if actor := AuditActorFrom(ctx); actor != nil {
+		role := string(actor.Role)
+		entry.ActorID = &actor.UserID
+		entry.ActorUsername = &actor.Username
+		entry.ActorRole = &role
+		if actor.IP != "" {
+			entry.IP = &actor.IP
+		}
+	}
+	if requestID := logging.RequestID(ctx); requestID != "" {
+		entry.RequestID = &requestID
+	}
+
+	var err error
+	if entry.Before, err = auditSnapshot(before); err != nil {
+		return err
+	}
+	if entry.After, err = auditSnapshot(after); err != nil {
+		return err
+	}
+
+	return s.auditRepo.Create(ctx, entry)
+}
+
+// List - записи журнала от новых к старым
+func (s *AuditService) List(ctx context.Context, filter *models.AuditFilter, limit, offset int) ([]*models.AuditEntry, error) {
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.auditRepo.List(ctx, filter, nil, limit, offset)
+}
+
+// Export - выгрузка журнала в CSV (не больше maxAuditExportRows последних записей)
+func (s *AuditService) Export(ctx context.Context, filter *models.AuditFilter) (*exporter.File, error) {
+	var (
+		entries  []*models.AuditEntry
+		beforeID *int64
+	)
+	for len(entries) < maxAuditExportRows {
+		batch, err := s.auditRepo.List(ctx, filter, beforeID, min(exportBatchSize, maxAuditExportRows-len(entries)), 0)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, batch...)
+		if len(batch) < exportBatchSize {
+			break
+		}
+		// курсор по ID: новые записи во время выгрузки не сдвигают страницы
+		beforeID = &batch[len(batch)-1].ID
+	}
+
+	return exporter.AuditLogCSV(entries, time.Now().UTC())
+}
+
+// auditSnapshot - состояние сущности для колонки JSONB (nil - NULL)
+func auditSnapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit snapshot: %w", err)
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	return data, nil
+}
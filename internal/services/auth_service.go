@@ -2,17 +2,41 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/cache"
+	"github.com/tukembaev/bookVisionGo/internal/metrics"
 	"github.com/tukembaev/bookVisionGo/internal/models"
 	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
 	"github.com/tukembaev/bookVisionGo/internal/utils"
 )
 
+var (
+	// ErrInvalidVisibility - недопустимое значение видимости
+	ErrInvalidVisibility = apperrors.Validation("visibility must be one of: public, followers, private")
+	// ErrUsernameTaken - имя пользователя уже занято
+	ErrUsernameTaken = apperrors.Conflict("username is already taken")
+	// ErrAccountSuspended - аккаунт заблокирован администратором или за нарушения
+	ErrAccountSuspended = apperrors.Forbidden("account is suspended")
+	// ErrAccountDeleted - владелец токена удален
+	ErrAccountDeleted = apperrors.Unauthorized("account no longer exists")
+)
+
+// Кэш аккаунтов для проверки токенов: роль, блокировка и удаление применяются
+// к уже выданным токенам не позже чем через accountCacheTTL
+const (
+	accountCacheTTL  = 30 * time.Second
+	accountCacheSize = 10000
+)
+
 // AuthService - сервис аутентификации
 type AuthService struct {
 	userRepo interfaces.UserRepository
 	jwtUtils *utils.JWTUtils
+	accounts *cache.LRU[string, *models.User]
 }
 
 // NewAuthService - создание нового AuthService
@@ -20,15 +44,19 @@ func NewAuthService(userRepo interfaces.UserRepository, jwtUtils *utils.JWTUtils
 	return &AuthService{
 		userRepo: userRepo,
 		jwtUtils: jwtUtils,
+		accounts: cache.NewLRU[string, *models.User](accountCacheSize, accountCacheTTL, nil),
 	}
 }
 
 // Register - регистрация нового пользователя
 func (s *AuthService) Register(ctx context.Context, req *models.CreateUserRequest) (*models.UserResponse, string, error) {
 	// Проверка существования пользователя
-	existingUser, err := s.userRepo.GetByUsername(ctx, req.Username)
-	if err == nil && existingUser != nil {
-		return nil, "", fmt.Errorf("user with username %s already exists", req.Username)
+	_, err := s.userRepo.GetByUsername(ctx, req.Username)
+	if err == nil {
+		return nil, "", ErrUsernameTaken.WithField("username", "already taken")
+	}
+	if !errors.Is(err, interfaces.ErrUserNotFound) {
+		return nil, "", err
 	}
 
 	// Создание нового пользователя
@@ -50,6 +78,7 @@ func (s *AuthService) Register(ctx context.Context, req *models.CreateUserReques
 		return nil, "", fmt.Errorf("failed to generate token: %w", err)
 	}
 
+	metrics.SessionsStarted.WithLabelValues("register").Inc()
 	return user.ToResponse(), token, nil
 }
 
@@ -58,7 +87,13 @@ func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 	// Проверка пароля и получение пользователя
 	user, err := s.userRepo.VerifyPassword(ctx, req.Username, req.Password)
 	if err != nil {
-		return nil, "", fmt.Errorf("invalid credentials: %w", err)
+		if errors.Is(err, interfaces.ErrInvalidCredentials) {
+			metrics.AuthFailures.WithLabelValues(metrics.AuthInvalidCredentials).Inc()
+		}
+		return nil, "", err
+	}
+	if err := checkSuspension(user); err != nil {
+		return nil, "", err
 	}
 
 	// Генерация JWT токена
@@ -67,13 +102,30 @@ func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*mod
 		return nil, "", fmt.Errorf("failed to generate token: %w", err)
 	}
 
+	metrics.SessionsStarted.WithLabelValues("login").Inc()
 	return user.ToResponse(), token, nil
 }
 
-// RefreshToken - обновление токена
-func (s *AuthService) RefreshToken(tokenString string) (string, error) {
-	// Валидация и обновление токена
-	newToken, err := s.jwtUtils.RefreshToken(tokenString)
+// RefreshToken - обновление токена.
+// Новый токен выпускается по текущей записи пользователя: удаленные и заблокированные аккаунты его не получают.
+func (s *AuthService) RefreshToken(ctx context.Context, tokenString string) (string, error) {
+	claims, err := s.jwtUtils.ValidateToken(tokenString)
+	if err != nil {
+		return "", apperrors.Wrap(apperrors.CodeUnauthorized, "invalid or expired token", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if errors.Is(err, interfaces.ErrUserNotFound) {
+		return "", ErrAccountDeleted
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := checkSuspension(user); err != nil {
+		return "", err
+	}
+
+	newToken, err := s.jwtUtils.GenerateToken(user)
 	if err != nil {
 		return "", fmt.Errorf("failed to refresh token: %w", err)
 	}
@@ -81,21 +133,51 @@ func (s *AuthService) RefreshToken(tokenString string) (string, error) {
 	return newToken, nil
 }
 
-// ValidateToken - валидация токена
-func (s *AuthService) ValidateToken(tokenString string) (*utils.Claims, error) {
+// ValidateToken - валидация токена и аккаунта его владельца: токен удаленного или
+// заблокированного пользователя отклоняется, роль и имя берутся из базы, а не из токена
+func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (*utils.Claims, error) {
 	claims, err := s.jwtUtils.ValidateToken(tokenString)
 	if err != nil {
-		return nil, fmt.Errorf("invalid token: %w", err)
+		return nil, apperrors.Wrap(apperrors.CodeUnauthorized, "invalid token", err)
 	}
 
+	user, err := s.account(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSuspension(user); err != nil {
+		return nil, err
+	}
+
+	claims.Username = user.Username
+	claims.Role = user.Role
 	return claims, nil
 }
 
+// account - пользователь из кэша аккаунтов или из базы данных
+func (s *AuthService) account(ctx context.Context, userID string) (*models.User, error) {
+	if user, ok := s.accounts.Get(userID); ok {
+		metrics.CacheRequests.WithLabelValues("accounts", "hit").Inc()
+		return user, nil
+	}
+	metrics.CacheRequests.WithLabelValues("accounts", "miss").Inc()
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, interfaces.ErrUserNotFound) {
+		return nil, ErrAccountDeleted
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.accounts.Add(userID, user)
+	return user, nil
+}
+
 // GetProfile - получение профиля пользователя
 func (s *AuthService) GetProfile(ctx context.Context, userID string) (*models.UserResponse, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, err
 	}
 
 	return user.ToResponse(), nil
@@ -106,7 +188,7 @@ func (s *AuthService) UpdateProfile(ctx context.Context, userID string, req *mod
 	// Получение текущего пользователя
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, err
 	}
 
 	// Обновление полей если они указаны
@@ -116,8 +198,17 @@ func (s *AuthService) UpdateProfile(ctx context.Context, userID string, req *mod
 	if req.AvatarURL != nil {
 		user.AvatarURL = req.AvatarURL
 	}
-	if req.Role != nil {
-		user.Role = *req.Role
+	if req.ProfileVisibility != nil {
+		if !req.ProfileVisibility.IsValid() {
+			return nil, ErrInvalidVisibility.WithField("profile_visibility", "must be one of: public, followers, private")
+		}
+		user.ProfileVisibility = *req.ProfileVisibility
+	}
+	if req.ActivityVisibility != nil {
+		if !req.ActivityVisibility.IsValid() {
+			return nil, ErrInvalidVisibility.WithField("activity_visibility", "must be one of: public, followers, private")
+		}
+		user.ActivityVisibility = *req.ActivityVisibility
 	}
 
 	err = s.userRepo.Update(ctx, user)
@@ -127,3 +218,11 @@ func (s *AuthService) UpdateProfile(ctx context.Context, userID string, req *mod
 
 	return user.ToResponse(), nil
 }
+
+// checkSuspension - ошибка с датой окончания, если аккаунт сейчас заблокирован
+func checkSuspension(user *models.User) error {
+	if !user.IsSuspended(time.Now()) {
+		return nil
+	}
+	return ErrAccountSuspended.WithDetail("until " + user.SuspendedUntil.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/exporter"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// exportBatchSize - размер страницы при выгрузке данных пользователя
+const exportBatchSize = 500
+
+// ExportService - сервис выгрузки книг и данных пользователя
+type ExportService struct {
+	bookRepo     interfaces.BookRepository
+	userRepo     interfaces.UserRepository
+	reviewRepo   interfaces.ReviewRepository
+	quoteRepo    interfaces.QuoteRepository
+	readingRepo  interfaces.ReadingRepository
+	mediaService *MediaService
+}
+
+// NewExportService - создание нового ExportService
+func NewExportService(
+	bookRepo interfaces.BookRepository,
+	userRepo interfaces.UserRepository,
+	reviewRepo interfaces.ReviewRepository,
+	quoteRepo interfaces.QuoteRepository,
+	readingRepo interfaces.ReadingRepository,
+	mediaService *MediaService,
+) *ExportService {
+	return &ExportService{
+		bookRepo:     bookRepo,
+		userRepo:     userRepo,
+		reviewRepo:   reviewRepo,
+		quoteRepo:    quoteRepo,
+		readingRepo:  readingRepo,
+		mediaService: mediaService,
+	}
+}
+
+// ExportBook - выгрузка книги с частями в формате epub, md или json
+func (s *ExportService) ExportBook(ctx context.Context, bookID string, format exporter.Format) (*exporter.File, error) {
+	book, err := s.bookRepo.GetByID(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := s.bookRepo.GetParts(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	exportedAt := time.Now().UTC()
+	switch format {
+	case exporter.FormatEPUB:
+		return exporter.BookEPUB(book, parts, s.cover(ctx, book), exportedAt)
+	case exporter.FormatMarkdown:
+		return exporter.BookMarkdown(book, parts)
+	case exporter.FormatJSON:
+		return exporter.BookJSON(book, parts, exportedAt)
+	default:
+		return nil, exporter.ErrUnsupportedFormat
+	}
+}
+
+// cover - обложка книги из хранилища файлов (nil, если ее нет или она хранится вне хранилища)
+func (s *ExportService) cover(ctx context.Context, book *models.Book) *exporter.Image {
+	if book.CoverURL == nil {
+		return nil
+	}
+	contentType, data, err := s.mediaService.ReadURL(ctx, *book.CoverURL)
+	if err != nil {
+		return nil
+	}
+	return &exporter.Image{ContentType: contentType, Data: data}
+}
+
+// ExportUserData - выгрузка отзывов, цитат и прогресса чтения пользователя в формате json или csv
+func (s *ExportService) ExportUserData(ctx context.Context, userID string, format exporter.Format) (*exporter.File, error) {
+	if format != exporter.FormatJSON && format != exporter.FormatCSV {
+		return nil, exporter.ErrUnsupportedFormat
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &models.UserDataExport{
+		ExportedAt: time.Now().UTC(),
+		User:       user.ToResponse(),
+		Reviews:    []*models.ReviewResponse{},
+		Quotes:     []*models.QuoteResponse{},
+		Progress:   []*models.UserBookProgressResponse{},
+	}
+
+	for offset := 0; ; offset += exportBatchSize {
+		reviews, err := s.reviewRepo.ListByUser(ctx, userID, true, exportBatchSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, review := range reviews {
+			export.Reviews = append(export.Reviews, review.ToResponse())
+		}
+		if len(reviews) < exportBatchSize {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += exportBatchSize {
+		quotes, err := s.quoteRepo.ListByUser(ctx, userID, true, exportBatchSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, quote := range quotes {
+			export.Quotes = append(export.Quotes, quote.ToResponse())
+		}
+		if len(quotes) < exportBatchSize {
+			break
+		}
+	}
+
+	for offset := 0; ; offset += exportBatchSize {
+		progress, err := s.readingRepo.ListProgressByUser(ctx, userID, exportBatchSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range progress {
+			export.Progress = append(export.Progress, p.ToResponse())
+		}
+		if len(progress) < exportBatchSize {
+			break
+		}
+	}
+
+	if format == exporter.FormatCSV {
+		return exporter.UserDataCSV(export)
+	}
+	return exporter.UserDataJSON(export)
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/importer"
+	"github.com/tukembaev/bookVisionGo/internal/metrics"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// ErrInvalidImportFile - файл не удалось разобрать как EPUB или FB2
+var ErrInvalidImportFile = apperrors.Validation("invalid import file")
+
+// importExcerptLength - длина отрывка главы в предпросмотре (в символах)
+const importExcerptLength = 200
+
+// ImportService - сервис импорта книг из EPUB и FB2
+type ImportService struct {
+	bookRepo     interfaces.BookRepository
+	mediaService *MediaService
+	auditService *AuditService
+	txManager    interfaces.TxManager
+}
+
+// NewImportService - создание нового ImportService
+func NewImportService(bookRepo interfaces.BookRepository, mediaService *MediaService, auditService *AuditService, txManager interfaces.TxManager) *ImportService {
+	return &ImportService{
+		bookRepo:     bookRepo,
+		mediaService: mediaService,
+		auditService: auditService,
+		txManager:    txManager,
+	}
+}
+
+// Preview - разбор файла без записи в базу данных
+func (s *ImportService) Preview(filename string, data []byte) (*models.ImportResponse, error) {
+	result, err := s.parse(filename, data)
+	if err != nil {
+		return nil, err
+	}
+	return importResponse(result, true), nil
+}
+
+// Import - разбор файла и создание книги с частями и записью в журнал аудита в одной транзакции.
+// Обложка загружается в хранилище после создания книги; ошибка загрузки попадает в предупреждения.
+func (s *ImportService) Import(ctx context.Context, filename string, data []byte, createdBy *string) (*models.ImportResponse, error) {
+	result, err := s.parse(filename, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Book.CreatedBy = createdBy
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.bookRepo.CreateWithParts(ctx, result.Book, result.Parts); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, models.AuditBookImport, models.AuditTargetBook, result.Book.ID, nil, map[string]any{
+			"file":  filename,
+			"book":  result.Book.ToResponse(),
+			"parts": len(result.Parts),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	metrics.BooksCreated.WithLabelValues("import").Inc()
+
+	if cover := result.Cover; cover != nil {
+		upload, err := s.mediaService.UploadBookCover(ctx, result.Book.ID, cover.Data)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("cover skipped: %v", err))
+		} else {
+			result.Book.CoverURL = &upload.URL
+		}
+	}
+	return importResponse(result, false), nil
+}
+
+// parse - разбор файла
+func (s *ImportService) parse(filename string, data []byte) (*importer.Result, error) {
+	result, err := importer.Parse(filename, data)
+	if err != nil {
+		return nil, ErrInvalidImportFile.WithDetail(err.Error())
+	}
+	return result, nil
+}
+
+// importResponse - конвертация результата разбора в DTO
+func importResponse(result *importer.Result, dryRun bool) *models.ImportResponse {
+	response := &models.ImportResponse{
+		DryRun:   dryRun,
+		Format:   string(result.Format),
+		Book:     result.Book.ToResponse(),
+		Parts:    make([]*models.ImportPartPreview, len(result.Parts)),
+		Warnings: result.Warnings,
+	}
+	if response.Warnings == nil {
+		response.Warnings = []string{}
+	}
+	if result.Cover != nil {
+		response.Cover = &models.ImportCoverPreview{
+			ContentType: result.Cover.ContentType,
+			Size:        len(result.Cover.Data),
+		}
+	}
+
+	for i, part := range result.Parts {
+		excerpt := part.Content
+		if utf8.RuneCountInString(excerpt) > importExcerptLength {
+			excerpt = string([]rune(excerpt)[:importExcerptLength]) + "…"
+		}
+		response.Parts[i] = &models.ImportPartPreview{
+			ID:         part.ID,
+			OrderNum:   part.OrderNum,
+			Title:      part.Title,
+			PageStart:  part.PageStart,
+			PageEnd:    part.PageEnd,
+			Characters: utf8.RuneCountInString(part.Content),
+			Excerpt:    excerpt,
+		}
+	}
+	return response
+}
@@ -0,0 +1,422 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/imaging"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/storage"
+)
+
+var (
+	// ErrMediaTooLarge - файл превышает лимит размера для данного вида изображений
+	ErrMediaTooLarge = apperrors.New(apperrors.CodeTooLarge, "file is too large")
+	// ErrUnsupportedMedia - содержимое файла не является JPEG, PNG, GIF или WebP
+	ErrUnsupportedMedia = apperrors.New(apperrors.CodeUnsupportedMedia, "unsupported media type: expected JPEG, PNG, GIF or WebP")
+	// ErrInvalidImage - изображение повреждено или имеет слишком большое разрешение
+	ErrInvalidImage = apperrors.New(apperrors.CodeUnprocessable, "invalid image")
+)
+
+// defaultMediaURL - префикс ссылок на файлы, отдаваемые самим приложением
+const defaultMediaURL = "/media"
+
+// MediaKind - вид загружаемого изображения
+type MediaKind string
+
+const (
+	MediaBookCover    MediaKind = "book_cover"
+	MediaArticleCover MediaKind = "article_cover"
+	MediaAvatar       MediaKind = "avatar"
+	MediaIllustration MediaKind = "illustration"
+)
+
+// mediaSpec - правила хранения вида изображений
+type mediaSpec struct {
+	dir     string
+	name    string
+	maxSize int64
+	sizes   []imaging.Size
+}
+
+// mediaSpecs - лимиты и размеры уменьшенных копий по видам изображений
+var mediaSpecs = map[MediaKind]mediaSpec{
+	MediaBookCover: {
+		dir: "books", name: "cover", maxSize: 5 << 20,
+		sizes: []imaging.Size{{Name: "thumb", Width: 200, Height: 300}, {Name: "medium", Width: 600}},
+	},
+	MediaArticleCover: {
+		dir: "articles", name: "cover", maxSize: 5 << 20,
+		sizes: []imaging.Size{{Name: "thumb", Width: 400, Height: 225}, {Name: "medium", Width: 1200}},
+	},
+	MediaAvatar: {
+		dir: "avatars", name: "avatar", maxSize: 2 << 20,
+		sizes: []imaging.Size{{Name: "thumb", Width: 64, Height: 64}, {Name: "medium", Width: 256, Height: 256}},
+	},
+	MediaIllustration: {
+		dir: "illustrations", name: "illustration", maxSize: 10 << 20,
+		sizes: []imaging.Size{{Name: "thumb", Width: 300}, {Name: "medium", Width: 1200}},
+	},
+}
+
+// MaxMediaSize - наибольший лимит размера загружаемого изображения
+func MaxMediaSize() int64 {
+	var size int64
+	for _, spec := range mediaSpecs {
+		size = max(size, spec.maxSize)
+	}
+	return size
+}
+
+// MediaService - сервис загрузки обложек, аватаров и иллюстраций в BlobStore
+type MediaService struct {
+	store         storage.BlobStore
+	bookRepo      interfaces.BookRepository
+	articleRepo   interfaces.ArticleRepository
+	userRepo      interfaces.UserRepository
+	characterRepo interfaces.CharacterRepository
+	auditService  *AuditService
+	txManager     interfaces.TxManager
+	baseURL       string
+	signedURLTTL  time.Duration
+}
+
+// NewMediaService - создание нового MediaService.
+// publicURL - внешний адрес файлов (CDN или публичный бакет); пустое значение - файлы отдает приложение через /media.
+func NewMediaService(
+	store storage.BlobStore,
+	bookRepo interfaces.BookRepository,
+	articleRepo interfaces.ArticleRepository,
+	userRepo interfaces.UserRepository,
+	characterRepo interfaces.CharacterRepository,
+	auditService *AuditService,
+	txManager interfaces.TxManager,
+	publicURL string,
+	signedURLTTL time.Duration,
+) *MediaService {
+	baseURL := strings.TrimSuffix(publicURL, "/")
+	if baseURL == "" {
+		baseURL = defaultMediaURL
+	}
+	return &MediaService{
+		store:         store,
+		bookRepo:      bookRepo,
+		articleRepo:   articleRepo,
+		userRepo:      userRepo,
+		characterRepo: characterRepo,
+		auditService:  auditService,
+		txManager:     txManager,
+		baseURL:       baseURL,
+		signedURLTTL:  signedURLTTL,
+	}
+}
+
+// UploadBookCover - загрузка обложки книги; предыдущая обложка удаляется из хранилища.
+// Замена ссылки записывается в журнал аудита в той же транзакции.
+func (s *MediaService) UploadBookCover(ctx context.Context, bookID string, data []byte) (*models.UploadResponse, error) {
+	book, err := s.bookRepo.GetByID(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := s.put(ctx, MediaBookCover, book.ID, data)
+	if err != nil {
+		return nil, err
+	}
+	previous := book.CoverURL
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.bookRepo.UpdateCoverURL(ctx, book.ID, &upload.URL); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, models.AuditBookCover, models.AuditTargetBook, book.ID,
+			coverSnapshot(previous), coverSnapshot(&upload.URL))
+	})
+	if err != nil {
+		s.discard(ctx, MediaBookCover, upload.URL, previous)
+		return nil, err
+	}
+
+	s.replaced(ctx, MediaBookCover, previous, upload.URL)
+	return upload, nil
+}
+
+// UploadArticleCover - загрузка обложки статьи; предыдущая обложка удаляется из хранилища.
+// Замена ссылки записывается в журнал аудита в той же транзакции.
+func (s *MediaService) UploadArticleCover(ctx context.Context, articleID string, data []byte) (*models.UploadResponse, error) {
+	article, err := s.articleRepo.GetByID(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := s.put(ctx, MediaArticleCover, article.ID, data)
+	if err != nil {
+		return nil, err
+	}
+	previous := article.CoverURL
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.articleRepo.UpdateCoverURL(ctx, article.ID, &upload.URL); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, models.AuditArticleCover, models.AuditTargetArticle, article.ID,
+			coverSnapshot(previous), coverSnapshot(&upload.URL))
+	})
+	if err != nil {
+		s.discard(ctx, MediaArticleCover, upload.URL, previous)
+		return nil, err
+	}
+
+	s.replaced(ctx, MediaArticleCover, previous, upload.URL)
+	return upload, nil
+}
+
+// UploadAvatar - загрузка аватара пользователя; предыдущий аватар удаляется из хранилища
+func (s *MediaService) UploadAvatar(ctx context.Context, userID string, data []byte) (*models.UploadResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	upload, err := s.put(ctx, MediaAvatar, user.ID, data)
+	if err != nil {
+		return nil, err
+	}
+	previous := user.AvatarURL
+	if err := s.userRepo.UpdateAvatarURL(ctx, user.ID, &upload.URL); err != nil {
+		s.discard(ctx, MediaAvatar, upload.URL, previous)
+		return nil, err
+	}
+
+	s.replaced(ctx, MediaAvatar, previous, upload.URL)
+	return upload, nil
+}
+
+// DeleteAvatar - удаление аватара пользователя
+func (s *MediaService) DeleteAvatar(ctx context.Context, userID string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.userRepo.UpdateAvatarURL(ctx, user.ID, nil); err != nil {
+		return err
+	}
+
+	s.replaced(ctx, MediaAvatar, user.AvatarURL, "")
+	return nil
+}
+
+// AddIllustration - загрузка иллюстрации и привязка ее к профилю персонажа
+func (s *MediaService) AddIllustration(ctx context.Context, characterID, authorName string, data []byte) (*models.CharacterIllustration, *models.UploadResponse, error) {
+	if !storage.ValidKey(characterID) {
+		return nil, nil, interfaces.ErrCharacterNotFound
+	}
+
+	upload, err := s.put(ctx, MediaIllustration, characterID, data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	illustration := &models.CharacterIllustration{
+		CharacterID: characterID,
+		ImageURL:    upload.URL,
+		AuthorName:  authorName,
+	}
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.characterRepo.CreateIllustration(ctx, illustration); err != nil {
+			return err
+		}
+		return s.auditService.Record(ctx, models.AuditCharacterIllustration, models.AuditTargetCharacterProfile, characterID,
+			nil, illustration)
+	})
+	if err != nil {
+		s.remove(ctx, MediaIllustration, upload.URL)
+		return nil, nil, err
+	}
+	return illustration, upload, nil
+}
+
+// Open - чтение объекта из хранилища
+func (s *MediaService) Open(ctx context.Context, key string) (io.ReadCloser, *storage.ObjectInfo, error) {
+	return s.store.Get(ctx, key)
+}
+
+// SignedURL - подписанная ссылка на объект или storage.ErrPresignNotSupported
+func (s *MediaService) SignedURL(ctx context.Context, key string) (string, time.Duration, error) {
+	url, err := s.store.PresignGet(ctx, key, s.signedURLTTL)
+	return url, s.signedURLTTL, err
+}
+
+// ReadURL - содержимое изображения по ссылке, выданной этим сервисом
+func (s *MediaService) ReadURL(ctx context.Context, url string) (contentType string, data []byte, err error) {
+	key, ok := s.keyFromURL(url)
+	if !ok {
+		return "", nil, storage.ErrNotFound
+	}
+
+	reader, info, err := s.store.Get(ctx, key)
+	if err != nil {
+		return "", nil, err
+	}
+	defer reader.Close()
+
+	data, err = io.ReadAll(reader)
+	if err != nil {
+		return "", nil, err
+	}
+	return info.ContentType, data, nil
+}
+
+// put - проверка изображения, построение вариантов и запись в хранилище
+func (s *MediaService) put(ctx context.Context, kind MediaKind, ownerID string, data []byte) (*models.UploadResponse, error) {
+	spec := mediaSpecs[kind]
+	if int64(len(data)) > spec.maxSize {
+		return nil, ErrMediaTooLarge.WithDetail(fmt.Sprintf("limit is %d bytes", spec.maxSize))
+	}
+	if !storage.ValidKey(ownerID) {
+		return nil, fmt.Errorf("invalid owner id %q", ownerID)
+	}
+
+	contentType, ext, err := imaging.Sniff(data)
+	if err != nil {
+		return nil, ErrUnsupportedMedia
+	}
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return nil, ErrInvalidImage.WithDetail(err.Error())
+	}
+
+	// ключ зависит от содержимого, поэтому файлы можно кэшировать навсегда
+	sum := sha256.Sum256(data)
+	base := path.Join(spec.dir, ownerID, spec.name+"-"+hex.EncodeToString(sum[:8]))
+
+	var written []string
+	write := func(key, contentType string, body []byte) error {
+		if err := s.store.Put(ctx, key, bytes.NewReader(body), int64(len(body)), contentType); err != nil {
+			return err
+		}
+		written = append(written, key)
+		return nil
+	}
+	cleanup := func() {
+		for _, key := range written {
+			if err := s.store.Delete(ctx, key); err != nil {
+				slog.WarnContext(ctx, "failed to clean up blob", "key", key, "error", err)
+			}
+		}
+	}
+
+	original := base + "." + ext
+	if err := write(original, contentType, data); err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	upload := &models.UploadResponse{
+		URL:         s.url(original),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+		Variants:    make([]*models.MediaVariant, 0, len(spec.sizes)*2),
+	}
+
+	for _, size := range spec.sizes {
+		resized := imaging.Resize(img, size)
+		width, height := resized.Bounds().Dx(), resized.Bounds().Dy()
+
+		jpegData, err := imaging.EncodeJPEG(resized)
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		webpData, err := imaging.EncodeWebP(resized)
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+
+		for _, variant := range []struct {
+			name, ext, contentType string
+			data                   []byte
+		}{
+			{size.Name, "jpg", "image/jpeg", jpegData},
+			{size.Name + "_webp", "webp", "image/webp", webpData},
+		} {
+			key := base + "_" + size.Name + "." + variant.ext
+			if err := write(key, variant.contentType, variant.data); err != nil {
+				cleanup()
+				return nil, err
+			}
+			upload.Variants = append(upload.Variants, &models.MediaVariant{
+				Name:        variant.name,
+				URL:         s.url(key),
+				ContentType: variant.contentType,
+				Width:       width,
+				Height:      height,
+			})
+		}
+	}
+
+	return upload, nil
+}
+
+// coverSnapshot - состояние обложки для журнала аудита
+func coverSnapshot(url *string) map[string]*string {
+	return map[string]*string{"cover_url": url}
+}
+
+// replaced - удаление прежнего файла после успешной замены
+func (s *MediaService) replaced(ctx context.Context, kind MediaKind, previous *string, current string) {
+	if previous != nil && *previous != current {
+		s.remove(ctx, kind, *previous)
+	}
+}
+
+// discard - удаление только что загруженного файла, если он не совпадает с текущим
+func (s *MediaService) discard(ctx context.Context, kind MediaKind, url string, current *string) {
+	if current == nil || *current != url {
+		s.remove(ctx, kind, url)
+	}
+}
+
+// remove - удаление оригинала и вариантов; ссылки не из хранилища игнорируются
+func (s *MediaService) remove(ctx context.Context, kind MediaKind, url string) {
+	key, ok := s.keyFromURL(url)
+	if !ok {
+		return
+	}
+
+	base := strings.TrimSuffix(key, path.Ext(key))
+	keys := []string{key}
+	for _, size := range mediaSpecs[kind].sizes {
+		keys = append(keys, base+"_"+size.Name+".jpg", base+"_"+size.Name+".webp")
+	}
+	for _, k := range keys {
+		if err := s.store.Delete(ctx, k); err != nil {
+			slog.WarnContext(ctx, "failed to delete blob", "key", k, "error", err)
+		}
+	}
+}
+
+// url - ссылка на объект
+func (s *MediaService) url(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// keyFromURL - ключ объекта по ссылке, выданной этим сервисом
+func (s *MediaService) keyFromURL(url string) (string, bool) {
+	key, ok := strings.CutPrefix(url, s.baseURL+"/")
+	if !ok || !storage.ValidKey(key) {
+		return "", false
+	}
+	return key, true
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// ReadingService - сервис прогресса чтения
+type ReadingService struct {
+	readingRepo   interfaces.ReadingRepository
+	bookRepo      interfaces.BookRepository
+	socialService *SocialService
+}
+
+// NewReadingService - создание нового ReadingService
+func NewReadingService(readingRepo interfaces.ReadingRepository, bookRepo interfaces.BookRepository, socialService *SocialService) *ReadingService {
+	return &ReadingService{
+		readingRepo:   readingRepo,
+		bookRepo:      bookRepo,
+		socialService: socialService,
+	}
+}
+
+// UpdateProgress - обновление прогресса чтения книги пользователем
+func (s *ReadingService) UpdateProgress(ctx context.Context, userID, bookID string, req *models.UpdateBookProgressRequest) (*models.UserBookProgress, error) {
+	// Книга в корзине не принимает прогресс: внешний ключ видит удаленную строку
+	if _, err := s.bookRepo.GetByID(ctx, bookID); err != nil {
+		return nil, err
+	}
+
+	progress, err := s.loadProgress(ctx, userID, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CompletedPartIDs != nil {
+		progress.CompletedPartIDs = req.CompletedPartIDs
+	}
+	if req.CurrentPartID != nil {
+		progress.CurrentPartID = req.CurrentPartID
+	}
+
+	return progress, s.save(ctx, progress, req.IsCompleted)
+}
+
+// loadProgress - получение прогресса или заготовки для новой книги
+func (s *ReadingService) loadProgress(ctx context.Context, userID, bookID string) (*models.UserBookProgress, error) {
+	progress, err := s.readingRepo.GetProgress(ctx, userID, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if progress == nil {
+		progress = &models.UserBookProgress{
+			UserID: userID,
+			BookID: bookID,
+		}
+	}
+	return progress, nil
+}
+
+// ApplyShelfStatus - прогресс чтения для книги, перемещенной на встроенную полку:
+// "reading" создает прогресс, "read" отмечает книгу прочитанной, "reading" и "want_to_read"
+// снимают отметку о прочтении. Возвращает true, если книга только что дочитана;
+// событие book_finished публикует вызывающий код после фиксации транзакции.
+func (s *ReadingService) ApplyShelfStatus(ctx context.Context, userID, bookID string, status models.ShelfStatus) (bool, error) {
+	progress, err := s.loadProgress(ctx, userID, bookID)
+	if err != nil {
+		return false, err
+	}
+
+	switch status {
+	case models.ShelfStatusRead:
+		return s.store(ctx, progress, true)
+	case models.ShelfStatusReading:
+		if progress.ID != "" && !progress.IsCompleted {
+			return false, nil
+		}
+		return s.store(ctx, progress, false)
+	case models.ShelfStatusWantToRead:
+		if progress.ID == "" || !progress.IsCompleted {
+			return false, nil
+		}
+		return s.store(ctx, progress, false)
+	}
+	return false, nil
+}
+
+// PublishFinished - публикация события book_finished; ошибка только логируется
+func (s *ReadingService) PublishFinished(ctx context.Context, userID, bookID string) {
+	if err := s.socialService.Publish(ctx, userID, models.ActivityTypeBookFinished, bookID, &bookID, nil); err != nil {
+		slog.WarnContext(ctx, "failed to publish activity", "error", err)
+	}
+}
+
+// save - сохранение прогресса; при переходе в "прочитано" публикует book_finished
+func (s *ReadingService) save(ctx context.Context, progress *models.UserBookProgress, completed bool) error {
+	justFinished, err := s.store(ctx, progress, completed)
+	if err != nil {
+		return err
+	}
+	if justFinished {
+		s.PublishFinished(ctx, progress.UserID, progress.BookID)
+	}
+	return nil
+}
+
+// store - сохранение прогресса; при переходе в "прочитано" фиксирует дату и возвращает true
+func (s *ReadingService) store(ctx context.Context, progress *models.UserBookProgress, completed bool) (bool, error) {
+	justFinished := completed && !progress.IsCompleted
+
+	progress.IsCompleted = completed
+	if justFinished {
+		now := time.Now()
+		progress.CompletedAt = &now
+	} else if !completed {
+		progress.CompletedAt = nil
+	}
+
+	if err := s.readingRepo.UpsertProgress(ctx, progress); err != nil {
+		return false, err
+	}
+	return justFinished, nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/tracing"
+)
+
+// RecommendationService - сервис рекомендаций книг
+type RecommendationService struct {
+	recRepo  interfaces.RecommendationRepository
+	bookRepo interfaces.BookRepository
+	topN     int
+}
+
+// NewRecommendationService - создание нового RecommendationService
+func NewRecommendationService(recRepo interfaces.RecommendationRepository, bookRepo interfaces.BookRepository, topN int) *RecommendationService {
+	if topN <= 0 {
+		topN = 20
+	}
+	return &RecommendationService{
+		recRepo:  recRepo,
+		bookRepo: bookRepo,
+		topN:     topN,
+	}
+}
+
+// Run - периодический пересчет таблицы похожести книг до отмены контекста
+func (s *RecommendationService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// Каждый пересчет - отдельная корневая трассировка со спанами SQL запросов
+		jobCtx, span := tracing.Start(ctx, "recommendations.recompute")
+		err := s.Recompute(jobCtx)
+		tracing.End(span, err)
+		if err != nil {
+			slog.ErrorContext(jobCtx, "failed to recompute recommendations", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Recompute - пересчет и сохранение похожести книг
+func (s *RecommendationService) Recompute(ctx context.Context) error {
+	started := time.Now()
+
+	features, err := s.recRepo.LoadBookFeatures(ctx)
+	if err != nil {
+		return err
+	}
+	signals, err := s.recRepo.LoadUserSignals(ctx)
+	if err != nil {
+		return err
+	}
+
+	similarities := computeSimilarities(features, signals, s.topN, started)
+	if err := s.recRepo.ReplaceSimilarities(ctx, similarities); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "recommendations recomputed",
+		"books", len(features), "signals", len(signals), "pairs", len(similarities), "duration", time.Since(started))
+	return nil
+}
+
+// SimilarBooks - похожие книги для книги
+func (s *RecommendationService) SimilarBooks(ctx context.Context, bookID string, limit int) ([]*models.RecommendedBook, error) {
+	if _, err := s.bookRepo.GetByID(ctx, bookID); err != nil {
+		return nil, err
+	}
+	return s.recRepo.ListSimilar(ctx, bookID, limit)
+}
+
+// ForUser - рекомендации для пользователя.
+// Без положительных сигналов возвращаются популярные книги.
+func (s *RecommendationService) ForUser(ctx context.Context, userID string, limit int) ([]*models.RecommendedBook, error) {
+	books, err := s.recRepo.ListForUser(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(books) > 0 {
+		return books, nil
+	}
+	return s.recRepo.ListPopularForUser(ctx, userID, limit)
+}
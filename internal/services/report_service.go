@@ -0,0 +1,361 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/utils"
+)
+
+const (
+	defaultReportsLimit = 20
+	maxReportsLimit     = 100
+)
+
+var (
+	// ErrReportOwnContent - на собственный контент пожаловаться нельзя
+	ErrReportOwnContent = apperrors.Forbidden("you cannot report your own content")
+	// ErrReportClosed - по жалобе уже принято решение
+	ErrReportClosed = apperrors.Conflict("report is no longer open")
+	// ErrReportClaimed - жалобу взял в работу другой модератор
+	ErrReportClaimed = apperrors.Conflict("report is claimed by another moderator")
+)
+
+// ReportOptions - правила автоматического скрытия и блокировки за нарушения
+type ReportOptions struct {
+	// AutoHideReports - число жалоб разных пользователей, после которого контент скрывается до решения модератора (0 - не скрывать)
+	AutoHideReports int
+	// StrikesToSuspend - каждое такое число предупреждений блокирует аккаунт на SuspensionDuration (0 - не блокировать)
+	StrikesToSuspend   int
+	SuspensionDuration time.Duration
+}
+
+// ReportService - сервис жалоб на пользовательский контент: подача жалоб, очередь модераторов,
+// решения по жалобам и предупреждения авторам
+type ReportService struct {
+	reportRepo interfaces.ReportRepository
+	userRepo   interfaces.UserRepository
+	audit      *AuditService
+	txManager  interfaces.TxManager
+	opts       ReportOptions
+}
+
+// NewReportService - создание нового ReportService
+func NewReportService(
+	reportRepo interfaces.ReportRepository,
+	userRepo interfaces.UserRepository,
+	audit *AuditService,
+	txManager interfaces.TxManager,
+	opts ReportOptions,
+) *ReportService {
+	return &ReportService{
+		reportRepo: reportRepo,
+		userRepo:   userRepo,
+		audit:      audit,
+		txManager:  txManager,
+		opts:       opts,
+	}
+}
+
+// Report - жалоба на контент. Когда открытые жалобы подали AutoHideReports разных пользователей,
+// контент скрывается из публичных ответов до решения модератора.
+func (s *ReportService) Report(ctx context.Context, reporterID string, req *models.CreateReportRequest) (*models.ContentReport, error) {
+	report := &models.ContentReport{
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		ReporterID: reporterID,
+		Reason:     req.Reason,
+		Details:    req.Details,
+	}
+
+	// Строка объекта блокируется до подсчета жалоб: параллельные жалобы на один объект
+	// не пропустят порог скрытия, видя друг друга незафиксированными
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		target, err := s.reportRepo.LockTarget(ctx, req.TargetType, req.TargetID)
+		if err != nil {
+			return err
+		}
+		if target.AuthorID != nil && *target.AuthorID == reporterID {
+			return ErrReportOwnContent
+		}
+
+		if err := s.reportRepo.Create(ctx, report); err != nil {
+			return err
+		}
+		if s.opts.AutoHideReports <= 0 || target.Hidden {
+			return nil
+		}
+
+		reporters, err := s.reportRepo.CountOpenReporters(ctx, target.Type, target.ID)
+		if err != nil {
+			return err
+		}
+		if reporters < s.opts.AutoHideReports {
+			return nil
+		}
+		if err := s.reportRepo.SetTargetHidden(ctx, target.Type, target.ID, true); err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "reported content hidden", "target_type", target.Type, "target_id", target.ID, "reporters", reporters)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.reportRepo.GetByID(ctx, report.ID)
+}
+
+// GetReport - жалоба по ID
+func (s *ReportService) GetReport(ctx context.Context, id string) (*models.ContentReport, error) {
+	return s.reportRepo.GetByID(ctx, id)
+}
+
+// Queue - очередь модераторов: жалобы по фильтру от давних к новым (по умолчанию открытые)
+func (s *ReportService) Queue(ctx context.Context, filter *models.ReportFilter, limit, offset int) ([]*models.ContentReport, error) {
+	if filter.Status == nil {
+		status := models.ReportStatusOpen
+		filter.Status = &status
+	}
+	limit, offset = reportsPage(limit, offset)
+	return s.reportRepo.List(ctx, filter, limit, offset)
+}
+
+// Claim - взятие открытой жалобы в работу; повторное взятие своей жалобы ничего не меняет
+func (s *ReportService) Claim(ctx context.Context, moderator *utils.Claims, id string) (*models.ContentReport, error) {
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		report, err := s.reportRepo.Lock(ctx, id)
+		if err != nil {
+			return err
+		}
+		if report.Status != models.ReportStatusOpen {
+			return ErrReportClosed
+		}
+		if report.ClaimedBy != nil {
+			if *report.ClaimedBy != moderator.UserID {
+				return ErrReportClaimed
+			}
+			return nil
+		}
+		return s.reportRepo.SetClaim(ctx, id, &moderator.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.reportRepo.GetByID(ctx, id)
+}
+
+// Release - возврат жалобы в общую очередь; чужую жалобу может отпустить только администратор
+func (s *ReportService) Release(ctx context.Context, moderator *utils.Claims, id string) (*models.ContentReport, error) {
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		report, err := s.reportRepo.Lock(ctx, id)
+		if err != nil {
+			return err
+		}
+		if report.Status != models.ReportStatusOpen {
+			return ErrReportClosed
+		}
+		if report.ClaimedBy == nil {
+			return nil
+		}
+		if !canOverrideClaim(moderator, report.ClaimedBy) {
+			return ErrReportClaimed
+		}
+		return s.reportRepo.SetClaim(ctx, id, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.reportRepo.GetByID(ctx, id)
+}
+
+// Resolve - жалоба обоснована: все открытые жалобы на объект закрываются, объект скрывается,
+// автор получает предупреждение (и блокировку, если предупреждений набралось StrikesToSuspend)
+func (s *ReportService) Resolve(ctx context.Context, moderator *utils.Claims, id string, note *string) (*models.ContentReport, error) {
+	return s.decide(ctx, moderator, id, models.ReportStatusResolved, note)
+}
+
+// Dismiss - жалоба отклонена: все открытые жалобы на объект закрываются, автоматически скрытый
+// объект возвращается, если раньше жалобы на него не признавались обоснованными
+func (s *ReportService) Dismiss(ctx context.Context, moderator *utils.Claims, id string, note *string) (*models.ContentReport, error) {
+	return s.decide(ctx, moderator, id, models.ReportStatusDismissed, note)
+}
+
+// Bulk - одно действие над несколькими жалобами. Каждая жалоба обрабатывается в своей транзакции:
+// ошибка по одной жалобе не отменяет остальные и попадает в Failed.
+func (s *ReportService) Bulk(ctx context.Context, moderator *utils.Claims, req *models.BulkReportRequest) *models.BulkReportResult {
+	result := &models.BulkReportResult{
+		Succeeded: []*models.ContentReport{},
+		Failed:    []*models.BulkReportFailure{},
+	}
+
+	for _, id := range req.IDs {
+		var report *models.ContentReport
+		var err error
+		switch req.Action {
+		case models.ReportActionClaim:
+			report, err = s.Claim(ctx, moderator, id)
+		case models.ReportActionRelease:
+			report, err = s.Release(ctx, moderator, id)
+		case models.ReportActionResolve:
+			report, err = s.Resolve(ctx, moderator, id, req.Note)
+		case models.ReportActionDismiss:
+			report, err = s.Dismiss(ctx, moderator, id, req.Note)
+		}
+
+		if err != nil {
+			appErr := apperrors.As(err)
+			if appErr.Code == apperrors.CodeInternal {
+				slog.ErrorContext(ctx, "bulk report action failed", "report_id", id, "action", req.Action, "error", err)
+			}
+			result.Failed = append(result.Failed, &models.BulkReportFailure{
+				ID:      id,
+				Code:    string(appErr.Code),
+				Message: appErr.Message,
+			})
+			continue
+		}
+		result.Succeeded = append(result.Succeeded, report)
+	}
+	return result
+}
+
+// decide - решение модератора по объекту жалобы (закрывает все открытые жалобы на него)
+func (s *ReportService) decide(ctx context.Context, moderator *utils.Claims, id string, status models.ReportStatus, note *string) (*models.ContentReport, error) {
+	report, err := s.reportRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		// Объект блокируется первым, как в Report: новые жалобы на него ждут решения.
+		// Объект могли удалить после жалобы: тогда жалобы просто закрываются
+		target, err := s.reportRepo.LockTarget(ctx, report.TargetType, report.TargetID)
+		if err != nil && !errors.Is(err, interfaces.ErrReportTargetNotFound) {
+			return err
+		}
+
+		claims, err := s.reportRepo.LockOpenByTarget(ctx, report.TargetType, report.TargetID)
+		if err != nil {
+			return err
+		}
+		if _, open := claims[id]; !open {
+			return ErrReportClosed
+		}
+		for _, claimedBy := range claims {
+			if claimedBy != nil && !canOverrideClaim(moderator, claimedBy) {
+				return ErrReportClaimed
+			}
+		}
+
+		before, err := s.reportRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		action := models.AuditReportDismiss
+		if status == models.ReportStatusResolved {
+			action = models.AuditReportResolve
+			if target != nil {
+				if err := s.uphold(ctx, target); err != nil {
+					return err
+				}
+			}
+		} else if target != nil && target.Hidden {
+			if err := s.restore(ctx, target); err != nil {
+				return err
+			}
+		}
+
+		if err := s.reportRepo.ResolveOpenByTarget(ctx, report.TargetType, report.TargetID, status, moderator.UserID, note); err != nil {
+			return err
+		}
+		after, err := s.reportRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, action, models.AuditTargetContentReport, id, before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.reportRepo.GetByID(ctx, id)
+}
+
+// uphold - скрытие объекта обоснованной жалобы и предупреждение автору
+// (за один объект автор получает предупреждение один раз)
+func (s *ReportService) uphold(ctx context.Context, target *models.ReportTarget) error {
+	if !target.Hidden {
+		if err := s.reportRepo.SetTargetHidden(ctx, target.Type, target.ID, true); err != nil {
+			return err
+		}
+	}
+	if target.AuthorID == nil {
+		return nil
+	}
+	resolved, err := s.reportRepo.HasResolved(ctx, target.Type, target.ID)
+	if err != nil || resolved {
+		return err
+	}
+	return s.strike(ctx, *target.AuthorID)
+}
+
+// restore - возврат автоматически скрытого объекта; скрытый по обоснованной жалобе объект остается скрытым
+func (s *ReportService) restore(ctx context.Context, target *models.ReportTarget) error {
+	resolved, err := s.reportRepo.HasResolved(ctx, target.Type, target.ID)
+	if err != nil || resolved {
+		return err
+	}
+	return s.reportRepo.SetTargetHidden(ctx, target.Type, target.ID, false)
+}
+
+// strike - предупреждение автору; каждое StrikesToSuspend-е предупреждение блокирует аккаунт
+// на SuspensionDuration (действующая более долгая блокировка не сокращается)
+func (s *ReportService) strike(ctx context.Context, userID string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, interfaces.ErrUserNotFound) {
+		// Удаленный аккаунт предупреждение не получает
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	before := user.ToResponse()
+	if user.Strikes, err = s.userRepo.AddStrike(ctx, userID); err != nil {
+		return err
+	}
+	if s.opts.StrikesToSuspend > 0 && user.Strikes%s.opts.StrikesToSuspend == 0 {
+		until := time.Now().Add(s.opts.SuspensionDuration)
+		if user.SuspendedUntil == nil || user.SuspendedUntil.Before(until) {
+			if err := s.userRepo.SetSuspension(ctx, userID, &until); err != nil {
+				return err
+			}
+			user.SuspendedUntil = &until
+		}
+	}
+	return s.audit.Record(ctx, models.AuditUserStrike, models.AuditTargetUser, userID, before, user.ToResponse())
+}
+
+// canOverrideClaim - жалобу, взятую в работу, решает тот же модератор или администратор
+func canOverrideClaim(moderator *utils.Claims, claimedBy *string) bool {
+	return *claimedBy == moderator.UserID || moderator.Role == models.UserRoleAdmin
+}
+
+// reportsPage - лимит и смещение в допустимых границах
+func reportsPage(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultReportsLimit
+	}
+	if limit > maxReportsLimit {
+		limit = maxReportsLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+const (
+	defaultRevisionsLimit = 20
+	maxRevisionsLimit     = 100
+)
+
+// untrackedFields - редактируемые поля, которые не попадают в историю.
+// Замененная обложка удаляется из хранилища, поэтому откат к старой ссылке дал бы битую картинку.
+var untrackedFields = map[models.RevisionEntity][]string{
+	models.RevisionEntityBook: {"cover_url"},
+}
+
+// editMetaFields - поля запроса правки, которые не являются полями сущности
+var editMetaFields = []string{"version", "comment"}
+
+// RevisionService - сервис истории правок книг, частей и профилей персонажей
+type RevisionService struct {
+	revisionRepo interfaces.RevisionRepository
+}
+
+// NewRevisionService - создание нового RevisionService
+func NewRevisionService(revisionRepo interfaces.RevisionRepository) *RevisionService {
+	return &RevisionService{
+		revisionRepo: revisionRepo,
+	}
+}
+
+// Record - запись ревизии после правки; вызывается в транзакции правки, чтобы правка без ревизии
+// не сохранилась. before и after - редактируемые поля (Edit()) до и после правки, fromVersion
+// и toVersion - версии строки. Перед первой ревизией сущности сохраняется исходное состояние,
+// чтобы к нему можно было откатиться. Правка без изменений отслеживаемых полей не записывается.
+func (s *RevisionService) Record(
+	ctx context.Context,
+	entity models.RevisionEntity,
+	entityID string,
+	fromVersion, toVersion int,
+	before, after any,
+	editorID string,
+	comment *string,
+) error {
+	beforeSnapshot, err := snapshot(entity, before)
+	if err != nil {
+		return err
+	}
+	afterSnapshot, err := snapshot(entity, after)
+	if err != nil {
+		return err
+	}
+
+	changes := diffSnapshots(beforeSnapshot, afterSnapshot)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	exists, err := s.revisionRepo.Exists(ctx, entity, entityID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		err := s.revisionRepo.CreateBaseline(ctx, &models.Revision{
+			EntityType: entity,
+			EntityID:   entityID,
+			Version:    fromVersion,
+			Snapshot:   beforeSnapshot,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.revisionRepo.Create(ctx, &models.Revision{
+		EntityType: entity,
+		EntityID:   entityID,
+		Version:    toVersion,
+		EditorID:   &editorID,
+		Comment:    comment,
+		Changes:    changes,
+		Snapshot:   afterSnapshot,
+	})
+}
+
+// List - ревизии сущности от новых к старым
+func (s *RevisionService) List(ctx context.Context, entity models.RevisionEntity, entityID string, limit, offset int) ([]*models.RevisionResponse, error) {
+	if limit <= 0 {
+		limit = defaultRevisionsLimit
+	}
+	if limit > maxRevisionsLimit {
+		limit = maxRevisionsLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	revisions, err := s.revisionRepo.List(ctx, entity, entityID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.RevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		responses[i] = revision.ToResponse()
+	}
+	return responses, nil
+}
+
+// Diff - отличия состояния сущности в ревизии to от состояния в ревизии from
+func (s *RevisionService) Diff(ctx context.Context, entity models.RevisionEntity, entityID string, from, to int) (*models.RevisionDiffResponse, error) {
+	fromRevision, err := s.revisionRepo.Get(ctx, entity, entityID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.revisionRepo.Get(ctx, entity, entityID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RevisionDiffResponse{
+		From:    from,
+		To:      to,
+		Changes: diffSnapshots(fromRevision.Snapshot, toRevision.Snapshot),
+	}, nil
+}
+
+// Restore - заполнение target (результат Edit() текущего состояния) полями из ревизии.
+// Неотслеживаемые поля остаются текущими.
+func (s *RevisionService) Restore(ctx context.Context, entity models.RevisionEntity, entityID string, version int, target any) error {
+	revision, err := s.revisionRepo.Get(ctx, entity, entityID, version)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(revision.Snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode revision snapshot: %w", err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to restore revision %d: %w", version, err)
+	}
+	return nil
+}
+
+// snapshot - отслеживаемые поля сущности в виде JSON объекта
+func snapshot(entity models.RevisionEntity, edit any) (map[string]any, error) {
+	data, err := json.Marshal(edit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode revision snapshot: %w", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode revision snapshot: %w", err)
+	}
+
+	for _, field := range editMetaFields {
+		delete(fields, field)
+	}
+	for _, field := range untrackedFields[entity] {
+		delete(fields, field)
+	}
+	return fields, nil
+}
+
+// diffSnapshots - поля, значения которых различаются в двух снимках
+func diffSnapshots(from, to map[string]any) map[string]models.FieldChange {
+	changes := make(map[string]models.FieldChange)
+	for field, oldValue := range from {
+		if newValue := to[field]; !reflect.DeepEqual(oldValue, newValue) {
+			changes[field] = models.FieldChange{Old: oldValue, New: newValue}
+		}
+	}
+	for field, newValue := range to {
+		if _, ok := from[field]; !ok && newValue != nil {
+			changes[field] = models.FieldChange{Old: nil, New: newValue}
+		}
+	}
+	return changes
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+)
+
+// ErrInvalidShelfStatus - неизвестная встроенная полка
+var ErrInvalidShelfStatus = apperrors.Validation("status must be one of: want_to_read, reading, read, abandoned")
+
+// ShelfService - сервис полок и избранного пользователя
+type ShelfService struct {
+	shelfRepo      interfaces.ShelfRepository
+	bookRepo       interfaces.BookRepository
+	readingRepo    interfaces.ReadingRepository
+	readingService *ReadingService
+	txManager      interfaces.TxManager
+}
+
+// NewShelfService - создание нового ShelfService
+func NewShelfService(
+	shelfRepo interfaces.ShelfRepository,
+	bookRepo interfaces.BookRepository,
+	readingRepo interfaces.ReadingRepository,
+	readingService *ReadingService,
+	txManager interfaces.TxManager,
+) *ShelfService {
+	return &ShelfService{
+		shelfRepo:      shelfRepo,
+		bookRepo:       bookRepo,
+		readingRepo:    readingRepo,
+		readingService: readingService,
+		txManager:      txManager,
+	}
+}
+
+// Overview - количество книг на встроенных полках и список пользовательских полок
+func (s *ShelfService) Overview(ctx context.Context, userID string) (*models.ShelvesOverviewResponse, error) {
+	statuses, err := s.shelfRepo.CountByStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	shelves, err := s.shelfRepo.ListShelves(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ShelvesOverviewResponse{
+		Statuses: statuses,
+		Shelves:  shelves,
+	}, nil
+}
+
+// SetStatus - перемещение книги на встроенную полку.
+// Полка и прогресс чтения меняются в одной транзакции: "reading" создает прогресс,
+// "read" отмечает книгу прочитанной, "reading" и "want_to_read" снимают эту отметку.
+func (s *ShelfService) SetStatus(ctx context.Context, userID, bookID string, req *models.SetShelfStatusRequest) (*models.ShelfBook, error) {
+	if !req.Status.IsValid() {
+		return nil, ErrInvalidShelfStatus
+	}
+	if err := s.ensureBook(ctx, bookID); err != nil {
+		return nil, err
+	}
+
+	entry := &models.ShelfBook{
+		UserID: userID,
+		BookID: bookID,
+		Status: req.Status,
+		Note:   req.Note,
+	}
+	var justFinished bool
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.shelfRepo.SetStatus(ctx, entry); err != nil {
+			return err
+		}
+		var err error
+		justFinished, err = s.readingService.ApplyShelfStatus(ctx, userID, bookID, req.Status)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if justFinished {
+		s.readingService.PublishFinished(ctx, userID, bookID)
+	}
+
+	return s.shelfRepo.GetStatus(ctx, userID, bookID)
+}
+
+// RemoveStatus - удаление книги со встроенной полки
+func (s *ShelfService) RemoveStatus(ctx context.Context, userID, bookID string) error {
+	return s.shelfRepo.RemoveStatus(ctx, userID, bookID)
+}
+
+// ListByStatus - книги пользователя на встроенной полке
+func (s *ShelfService) ListByStatus(ctx context.Context, userID string, status models.ShelfStatus, limit, offset int) ([]*models.ShelfBook, error) {
+	if !status.IsValid() {
+		return nil, ErrInvalidShelfStatus
+	}
+	return s.shelfRepo.ListByStatus(ctx, userID, status, limit, offset)
+}
+
+// CreateShelf - создание пользовательской полки
+func (s *ShelfService) CreateShelf(ctx context.Context, userID string, req *models.CreateShelfRequest) (*models.Shelf, error) {
+	shelf := &models.Shelf{
+		UserID: userID,
+		Name:   req.Name,
+	}
+	if err := s.shelfRepo.CreateShelf(ctx, shelf); err != nil {
+		return nil, err
+	}
+	return shelf, nil
+}
+
+// UpdateShelf - переименование или перемещение пользовательской полки
+func (s *ShelfService) UpdateShelf(ctx context.Context, userID, shelfID string, req *models.UpdateShelfRequest) (*models.Shelf, error) {
+	shelf, err := s.ownedShelf(ctx, userID, shelfID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		shelf.Name = *req.Name
+	}
+	if req.Position != nil {
+		shelf.Position = *req.Position
+	}
+
+	if err := s.shelfRepo.UpdateShelf(ctx, shelf); err != nil {
+		return nil, err
+	}
+	return shelf, nil
+}
+
+// DeleteShelf - удаление пользовательской полки
+func (s *ShelfService) DeleteShelf(ctx context.Context, userID, shelfID string) error {
+	if _, err := s.ownedShelf(ctx, userID, shelfID); err != nil {
+		return err
+	}
+	return s.shelfRepo.DeleteShelf(ctx, shelfID)
+}
+
+// ListItems - книги на пользовательской полке
+func (s *ShelfService) ListItems(ctx context.Context, userID, shelfID string, limit, offset int) ([]*models.ShelfItem, error) {
+	if _, err := s.ownedShelf(ctx, userID, shelfID); err != nil {
+		return nil, err
+	}
+	return s.shelfRepo.ListItems(ctx, shelfID, limit, offset)
+}
+
+// AddItem - добавление книги на пользовательскую полку
+func (s *ShelfService) AddItem(ctx context.Context, userID, shelfID string, req *models.AddShelfItemRequest) (*models.ShelfItem, error) {
+	if _, err := s.ownedShelf(ctx, userID, shelfID); err != nil {
+		return nil, err
+	}
+	if err := s.ensureBook(ctx, req.BookID); err != nil {
+		return nil, err
+	}
+
+	item := &models.ShelfItem{
+		ShelfID: shelfID,
+		BookID:  req.BookID,
+		Note:    req.Note,
+	}
+	if err := s.shelfRepo.AddItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	if req.Position != nil {
+		item.Position = *req.Position
+		if err := s.shelfRepo.UpdateItem(ctx, item); err != nil {
+			return nil, err
+		}
+	}
+	return s.shelfRepo.GetItem(ctx, shelfID, req.BookID)
+}
+
+// UpdateItem - изменение заметки или позиции книги на полке
+func (s *ShelfService) UpdateItem(ctx context.Context, userID, shelfID, bookID string, req *models.UpdateShelfItemRequest) (*models.ShelfItem, error) {
+	if _, err := s.ownedShelf(ctx, userID, shelfID); err != nil {
+		return nil, err
+	}
+
+	item, err := s.shelfRepo.GetItem(ctx, shelfID, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Note != nil {
+		item.Note = req.Note
+	}
+	if req.Position != nil {
+		item.Position = *req.Position
+	}
+
+	if err := s.shelfRepo.UpdateItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// RemoveItem - удаление книги с пользовательской полки
+func (s *ShelfService) RemoveItem(ctx context.Context, userID, shelfID, bookID string) error {
+	if _, err := s.ownedShelf(ctx, userID, shelfID); err != nil {
+		return err
+	}
+	return s.shelfRepo.RemoveItem(ctx, shelfID, bookID)
+}
+
+// AddFavorite - добавление книги в избранное
+func (s *ShelfService) AddFavorite(ctx context.Context, userID, bookID string) (*models.UserFavorite, error) {
+	if err := s.ensureBook(ctx, bookID); err != nil {
+		return nil, err
+	}
+	return s.readingRepo.AddFavorite(ctx, userID, bookID)
+}
+
+// RemoveFavorite - удаление книги из избранного
+func (s *ShelfService) RemoveFavorite(ctx context.Context, userID, bookID string) error {
+	return s.readingRepo.RemoveFavorite(ctx, userID, bookID)
+}
+
+// ListFavorites - избранные книги текущего пользователя
+func (s *ShelfService) ListFavorites(ctx context.Context, userID string, limit, offset int) ([]*models.UserFavorite, error) {
+	return s.readingRepo.ListFavoritesByUser(ctx, userID, limit, offset)
+}
+
+// ownedShelf - загрузка полки с проверкой владельца.
+// Чужая полка неотличима от несуществующей.
+func (s *ShelfService) ownedShelf(ctx context.Context, userID, shelfID string) (*models.Shelf, error) {
+	shelf, err := s.shelfRepo.GetShelf(ctx, shelfID)
+	if err != nil {
+		return nil, err
+	}
+	if shelf.UserID != userID {
+		return nil, interfaces.ErrShelfNotFound
+	}
+	return shelf, nil
+}
+
+// ensureBook - проверка существования книги
+func (s *ShelfService) ensureBook(ctx context.Context, bookID string) error {
+	if _, err := s.bookRepo.GetByID(ctx, bookID); err != nil {
+		return err
+	}
+	return nil
+}
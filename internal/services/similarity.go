@@ -0,0 +1,215 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// Веса составляющих похожести книг
+const (
+	coReadersWeight = 0.5
+	genresWeight    = 0.2
+	tagsWeight      = 0.2
+	moodTagsWeight  = 0.1
+)
+
+// maxPostingBooks - предел числа книг в списке, из которого строятся пары: признак, общий
+// для большего числа книг (популярный жанр), не порождает пар, а у читателя с большим числом
+// книг учитываются только самые сильные сигналы. Иначе один такой список дает O(N²) пар.
+const maxPostingBooks = 500
+
+// featureKind - вид признака книги
+type featureKind int
+
+const (
+	featureGenre featureKind = iota
+	featureTag
+	featureMood
+	featureKindsCount
+)
+
+// bookPair - неупорядоченная пара индексов книг (a < b)
+type bookPair struct {
+	a, b int
+}
+
+// pairStats - накопленные сигналы похожести для пары книг
+type pairStats struct {
+	coReaders    float64
+	intersection [featureKindsCount]int
+}
+
+// computeSimilarities - расчет похожести книг по совместным положительным сигналам
+// читателей и пересечению жанров, тегов и mood_tags частей.
+// Пары-кандидаты строятся через общих читателей и признаки не чаще maxPostingBooks книг,
+// поэтому число пар ограничено и не растет квадратично с каталогом; пересечение признаков
+// для кандидата считается по всем его признакам, включая популярные.
+func computeSimilarities(features []*models.BookFeatures, signals []*models.UserBookSignal, topN int, now time.Time) []*models.BookSimilarity {
+	index := make(map[string]int, len(features))
+	sets := make([][featureKindsCount]map[string]struct{}, len(features))
+	for i, f := range features {
+		index[f.BookID] = i
+		sets[i][featureGenre] = normalizeSet(f.Genres)
+		sets[i][featureTag] = normalizeSet(f.Tags)
+		sets[i][featureMood] = normalizeSet(f.MoodTags)
+	}
+
+	stats := make(map[bookPair]*pairStats)
+	statsFor := func(x, y int) *pairStats {
+		if x > y {
+			x, y = y, x
+		}
+		key := bookPair{x, y}
+		s, ok := stats[key]
+		if !ok {
+			s = &pairStats{}
+			stats[key] = s
+		}
+		return s
+	}
+
+	// Кандидаты через общие редкие признаки (инвертированный индекс)
+	for kind := featureKind(0); kind < featureKindsCount; kind++ {
+		inverted := make(map[string][]int)
+		for i := range sets {
+			for value := range sets[i][kind] {
+				inverted[value] = append(inverted[value], i)
+			}
+		}
+		for _, books := range inverted {
+			if len(books) > maxPostingBooks {
+				continue
+			}
+			for x := 0; x < len(books); x++ {
+				for y := x + 1; y < len(books); y++ {
+					statsFor(books[x], books[y])
+				}
+			}
+		}
+	}
+
+	// Совместные положительные сигналы читателей
+	type weightedBook struct {
+		index  int
+		weight float64
+	}
+	byUser := make(map[string][]weightedBook)
+	readerWeight := make([]float64, len(features))
+	for _, s := range signals {
+		i, ok := index[s.BookID]
+		if !ok {
+			continue
+		}
+		byUser[s.UserID] = append(byUser[s.UserID], weightedBook{i, s.Weight})
+		readerWeight[i] += s.Weight
+	}
+	for _, books := range byUser {
+		if len(books) > maxPostingBooks {
+			sort.Slice(books, func(i, j int) bool { return books[i].weight > books[j].weight })
+			books = books[:maxPostingBooks]
+		}
+		for x := 0; x < len(books); x++ {
+			for y := x + 1; y < len(books); y++ {
+				// Повторный сигнал читателя по той же книге не делает книгу похожей на саму себя
+				if books[x].index == books[y].index {
+					continue
+				}
+				statsFor(books[x].index, books[y].index).coReaders += math.Min(books[x].weight, books[y].weight)
+			}
+		}
+	}
+
+	// Итоговый score и причины для каждой пары
+	candidates := make([][]*models.BookSimilarity, len(features))
+	for pair, s := range stats {
+		for kind := featureKind(0); kind < featureKindsCount; kind++ {
+			s.intersection[kind] = intersectionSize(sets[pair.a][kind], sets[pair.b][kind])
+		}
+
+		var score float64
+		var reasons []string
+
+		if s.coReaders > 0 {
+			score += coReadersWeight * s.coReaders / math.Sqrt(readerWeight[pair.a]*readerWeight[pair.b])
+			reasons = append(reasons, models.SimilarityReasonCoReaders)
+		}
+		for kind, weight := range [featureKindsCount]float64{genresWeight, tagsWeight, moodTagsWeight} {
+			inter := s.intersection[kind]
+			if inter == 0 {
+				continue
+			}
+			union := len(sets[pair.a][kind]) + len(sets[pair.b][kind]) - inter
+			score += weight * float64(inter) / float64(union)
+			reasons = append(reasons, featureReason(featureKind(kind)))
+		}
+
+		if score <= 0 {
+			continue
+		}
+
+		a, b := features[pair.a].BookID, features[pair.b].BookID
+		candidates[pair.a] = append(candidates[pair.a], &models.BookSimilarity{
+			BookID: a, SimilarBookID: b, Score: score, Reasons: reasons, ComputedAt: now,
+		})
+		candidates[pair.b] = append(candidates[pair.b], &models.BookSimilarity{
+			BookID: b, SimilarBookID: a, Score: score, Reasons: reasons, ComputedAt: now,
+		})
+	}
+
+	var result []*models.BookSimilarity
+	for _, list := range candidates {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Score != list[j].Score {
+				return list[i].Score > list[j].Score
+			}
+			return list[i].SimilarBookID < list[j].SimilarBookID
+		})
+		if len(list) > topN {
+			list = list[:topN]
+		}
+		result = append(result, list...)
+	}
+	return result
+}
+
+// normalizeSet - множество значений в нижнем регистре без пустых строк
+func normalizeSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v != "" {
+			set[v] = struct{}{}
+		}
+	}
+	return set
+}
+
+// intersectionSize - число общих значений двух множеств
+func intersectionSize(a, b map[string]struct{}) int {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	n := 0
+	for v := range a {
+		if _, ok := b[v]; ok {
+			n++
+		}
+	}
+	return n
+}
+
+// featureReason - причина похожести для вида признака
+func featureReason(kind featureKind) string {
+	switch kind {
+	case featureGenre:
+		return models.SimilarityReasonGenres
+	case featureTag:
+		return models.SimilarityReasonTags
+	default:
+		return models.SimilarityReasonMoodTags
+	}
+}
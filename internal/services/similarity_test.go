@@ -0,0 +1,129 @@
+package services
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+func TestComputeSimilarities(t *testing.T) {
+	tests := []struct {
+		name     string
+		features []*models.BookFeatures
+		signals  []*models.UserBookSignal
+		topN     int
+		// want - "книга>похожая score [причины]" в порядке результата
+		want []string
+	}{
+		{
+			name: "genre overlap",
+			features: []*models.BookFeatures{
+				{BookID: "a", Genres: []string{"Fantasy", "Adventure"}},
+				{BookID: "b", Genres: []string{" fantasy "}},
+				{BookID: "c", Genres: []string{"horror"}},
+			},
+			topN: 10,
+			// Жаккар по жанрам 1/2, регистр и пробелы не важны
+			want: []string{
+				"a>b 0.100 [genres]",
+				"b>a 0.100 [genres]",
+			},
+		},
+		{
+			name: "genre and tag overlap add up",
+			features: []*models.BookFeatures{
+				{BookID: "a", Genres: []string{"fantasy"}, Tags: []string{"dragons", "magic"}},
+				{BookID: "b", Genres: []string{"fantasy"}, Tags: []string{"dragons"}},
+			},
+			topN: 10,
+			// 0.2 * 1/1 + 0.2 * 1/2
+			want: []string{
+				"a>b 0.300 [genres tags]",
+				"b>a 0.300 [genres tags]",
+			},
+		},
+		{
+			name: "co-readers and mood tags",
+			features: []*models.BookFeatures{
+				{BookID: "a", MoodTags: []string{"dark"}},
+				{BookID: "b", MoodTags: []string{"dark"}},
+			},
+			signals: []*models.UserBookSignal{
+				{UserID: "u1", BookID: "a", Weight: 1},
+				{UserID: "u1", BookID: "b", Weight: 1},
+			},
+			topN: 10,
+			// 0.5 * 1/sqrt(1*1) + 0.1 * 1/1
+			want: []string{
+				"a>b 0.600 [co_readers mood_tags]",
+				"b>a 0.600 [co_readers mood_tags]",
+			},
+		},
+		{
+			name: "book is never similar to itself",
+			features: []*models.BookFeatures{
+				{BookID: "a", Genres: []string{"fantasy"}},
+				{BookID: "b"},
+			},
+			signals: []*models.UserBookSignal{
+				{UserID: "u1", BookID: "a", Weight: 1},
+				{UserID: "u1", BookID: "a", Weight: 0.5},
+				{UserID: "u2", BookID: "a", Weight: 1},
+			},
+			topN: 10,
+			want: nil,
+		},
+		{
+			name: "signals for unknown books are ignored",
+			features: []*models.BookFeatures{
+				{BookID: "a"},
+				{BookID: "b"},
+			},
+			signals: []*models.UserBookSignal{
+				{UserID: "u1", BookID: "a", Weight: 1},
+				{UserID: "u1", BookID: "deleted", Weight: 1},
+			},
+			topN: 10,
+			want: nil,
+		},
+		{
+			name: "top-N keeps best scores and breaks ties by id",
+			features: []*models.BookFeatures{
+				{BookID: "a", Genres: []string{"fantasy"}, Tags: []string{"dragons"}},
+				{BookID: "b", Genres: []string{"fantasy"}},
+				{BookID: "c", Genres: []string{"fantasy"}},
+				{BookID: "d", Genres: []string{"fantasy"}, Tags: []string{"dragons"}},
+			},
+			topN: 2,
+			want: []string{
+				"a>d 0.400 [genres tags]",
+				"a>b 0.200 [genres]",
+				"b>a 0.200 [genres]",
+				"b>c 0.200 [genres]",
+				"c>a 0.200 [genres]",
+				"c>b 0.200 [genres]",
+				"d>a 0.400 [genres tags]",
+				"d>b 0.200 [genres]",
+			},
+		},
+	}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, s := range computeSimilarities(tt.features, tt.signals, tt.topN, now) {
+				if !s.ComputedAt.Equal(now) {
+					t.Errorf("%s>%s computed_at = %v, want %v", s.BookID, s.SimilarBookID, s.ComputedAt, now)
+				}
+				got = append(got, fmt.Sprintf("%s>%s %.3f %v", s.BookID, s.SimilarBookID, s.Score, s.Reasons))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("computeSimilarities() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/utils"
+)
+
+var (
+	// ErrCannotFollowSelf - попытка подписаться на самого себя
+	ErrCannotFollowSelf = apperrors.Validation("cannot follow yourself")
+	// ErrInvalidCursor - некорректный курсор ленты
+	ErrInvalidCursor = apperrors.Validation("invalid feed cursor")
+)
+
+// SocialService - сервис подписок и ленты активности
+type SocialService struct {
+	userRepo   interfaces.UserRepository
+	followRepo interfaces.FollowRepository
+	feedRepo   interfaces.FeedRepository
+	policy     *VisibilityPolicy
+}
+
+// NewSocialService - создание нового SocialService
+func NewSocialService(
+	userRepo interfaces.UserRepository,
+	followRepo interfaces.FollowRepository,
+	feedRepo interfaces.FeedRepository,
+	policy *VisibilityPolicy,
+) *SocialService {
+	return &SocialService{
+		userRepo:   userRepo,
+		followRepo: followRepo,
+		feedRepo:   feedRepo,
+		policy:     policy,
+	}
+}
+
+// Follow - подписка текущего пользователя на пользователя username
+func (s *SocialService) Follow(ctx context.Context, followerID, username string) error {
+	target, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if target.ID == followerID {
+		return ErrCannotFollowSelf
+	}
+
+	return s.followRepo.Follow(ctx, followerID, target.ID)
+}
+
+// Unfollow - отписка текущего пользователя от пользователя username
+func (s *SocialService) Unfollow(ctx context.Context, followerID, username string) error {
+	target, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	return s.followRepo.Unfollow(ctx, followerID, target.ID)
+}
+
+// ListFollowers - подписчики пользователя с учетом profile_visibility
+func (s *SocialService) ListFollowers(ctx context.Context, viewer *utils.Claims, username string, limit, offset int) ([]*models.PublicUserResponse, error) {
+	owner, err := s.visibleProfile(ctx, viewer, username)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.followRepo.ListFollowers(ctx, owner.ID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return toPublicResponses(users), nil
+}
+
+// ListFollowing - подписки пользователя с учетом profile_visibility
+func (s *SocialService) ListFollowing(ctx context.Context, viewer *utils.Claims, username string, limit, offset int) ([]*models.PublicUserResponse, error) {
+	owner, err := s.visibleProfile(ctx, viewer, username)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.followRepo.ListFollowing(ctx, owner.ID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return toPublicResponses(users), nil
+}
+
+// Publish - запись события активности и раскладка по лентам подписчиков.
+// Если активность автора приватна, событие сохраняется, но в ленты не попадает.
+func (s *SocialService) Publish(ctx context.Context, actorID string, activityType models.ActivityType, targetID string, bookID *string, payload map[string]interface{}) error {
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return fmt.Errorf("failed to load activity actor: %w", err)
+	}
+
+	event := &models.ActivityEvent{
+		ActorID:  actorID,
+		Type:     activityType,
+		TargetID: targetID,
+		BookID:   bookID,
+		Payload:  payload,
+	}
+
+	if err := s.feedRepo.Publish(ctx, event, s.policy.SharesActivity(actor)); err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", activityType, err)
+	}
+	return nil
+}
+
+// GetFeed - страница ленты пользователя, начиная после курсора
+func (s *SocialService) GetFeed(ctx context.Context, userID, cursor string, limit int) (*models.FeedPageResponse, error) {
+	var after *interfaces.FeedCursor
+	if cursor != "" {
+		decoded, err := decodeFeedCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = decoded
+	}
+
+	items, err := s.feedRepo.ListForUser(ctx, userID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.FeedPageResponse{
+		Items: make([]*models.FeedItemResponse, len(items)),
+	}
+	for i, item := range items {
+		page.Items[i] = item.ToResponse()
+	}
+
+	if len(items) == limit {
+		last := items[len(items)-1]
+		next := encodeFeedCursor(&interfaces.FeedCursor{CreatedAt: last.CreatedAt, EventID: last.ID})
+		page.NextCursor = &next
+	}
+
+	return page, nil
+}
+
+// visibleProfile - загрузка пользователя и проверка доступа к его профилю
+func (s *SocialService) visibleProfile(ctx context.Context, viewer *utils.Claims, username string) (*models.User, error) {
+	owner, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.policy.CheckProfile(ctx, viewer, owner); err != nil {
+		return nil, err
+	}
+	return owner, nil
+}
+
+// toPublicResponses - конвертация списка пользователей в публичные DTO
+func toPublicResponses(users []*models.User) []*models.PublicUserResponse {
+	responses := make([]*models.PublicUserResponse, len(users))
+	for i, user := range users {
+		responses[i] = user.ToPublicResponse()
+	}
+	return responses
+}
+
+// encodeFeedCursor - кодирование позиции ленты в непрозрачную строку
+func encodeFeedCursor(cursor *interfaces.FeedCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.EventID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeFeedCursor - разбор курсора ленты
+func decodeFeedCursor(cursor string) (*interfaces.FeedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, eventID, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	if _, err := uuid.Parse(eventID); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &interfaces.FeedCursor{CreatedAt: ts, EventID: eventID}, nil
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/tracing"
+)
+
+const (
+	defaultTrashLimit = 20
+	maxTrashLimit     = 100
+)
+
+// ErrInvalidTrashType - недопустимый тип сущности корзины
+var ErrInvalidTrashType = apperrors.Validation("type must be one of: book, article, comment, user")
+
+// TrashService - сервис корзины: просмотр, восстановление и окончательное удаление
+type TrashService struct {
+	trashRepo interfaces.TrashRepository
+	audit     *AuditService
+	txManager interfaces.TxManager
+	retention time.Duration
+}
+
+// NewTrashService - создание нового TrashService
+func NewTrashService(trashRepo interfaces.TrashRepository, audit *AuditService, txManager interfaces.TxManager, retentionDays int) *TrashService {
+	return &TrashService{
+		trashRepo: trashRepo,
+		audit:     audit,
+		txManager: txManager,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+	}
+}
+
+// List - содержимое корзины с временем окончательного удаления (пустой kind - все типы)
+func (s *TrashService) List(ctx context.Context, kind models.TrashKind, limit, offset int) ([]*models.TrashItem, error) {
+	if kind != "" && !kind.IsValid() {
+		return nil, ErrInvalidTrashType
+	}
+	if limit <= 0 {
+		limit = defaultTrashLimit
+	}
+	if limit > maxTrashLimit {
+		limit = maxTrashLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	items, err := s.trashRepo.List(ctx, kind, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		item.PurgeAt = item.DeletedAt.Add(s.retention)
+	}
+	return items, nil
+}
+
+// Restore - восстановление сущности из корзины
+func (s *TrashService) Restore(ctx context.Context, kind models.TrashKind, id string) error {
+	if !kind.IsValid() {
+		return ErrInvalidTrashType
+	}
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.trashRepo.Lock(ctx, kind, id)
+		if err != nil {
+			return err
+		}
+		after, err := s.trashRepo.Restore(ctx, kind, id)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditTrashRestore, models.AuditTarget(kind), id, before, after)
+	})
+}
+
+// Purge - окончательное удаление сущности из корзины, не дожидаясь срока хранения.
+// Снимок строки в журнале остается единственной записью об удаленной сущности.
+func (s *TrashService) Purge(ctx context.Context, kind models.TrashKind, id string) error {
+	if !kind.IsValid() {
+		return ErrInvalidTrashType
+	}
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		before, err := s.trashRepo.Lock(ctx, kind, id)
+		if err != nil {
+			return err
+		}
+		if err := s.trashRepo.Purge(ctx, kind, id); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditTrashPurge, models.AuditTarget(kind), id, before, nil)
+	})
+}
+
+// Run - периодическая очистка корзины от сущностей старше срока хранения до отмены контекста
+func (s *TrashService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		jobCtx, span := tracing.Start(ctx, "trash.purge")
+		err := s.PurgeExpired(jobCtx)
+		tracing.End(span, err)
+		if err != nil {
+			slog.ErrorContext(jobCtx, "failed to purge trash", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeExpired - окончательное удаление сущностей, пролежавших в корзине дольше срока хранения
+func (s *TrashService) PurgeExpired(ctx context.Context) error {
+	before := time.Now().Add(-s.retention)
+
+	var purged int64
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		purged, err = s.trashRepo.PurgeDeletedBefore(ctx, before)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		slog.InfoContext(ctx, "trash purged", "rows", purged, "deleted_before", before)
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/utils"
+)
+
+var (
+	// ErrProfileHidden - профиль скрыт настройками видимости
+	ErrProfileHidden = apperrors.Forbidden("profile is hidden")
+	// ErrActivityHidden - активность скрыта настройками видимости
+	ErrActivityHidden = apperrors.Forbidden("activity is hidden")
+	// ErrDeleteSelf - администратор не может удалить собственный аккаунт
+	ErrDeleteSelf = apperrors.Forbidden("cannot delete your own account")
+	// ErrChangeOwnRole - администратор не может сменить собственную роль
+	ErrChangeOwnRole = apperrors.Forbidden("cannot change your own role")
+	// ErrSuspendSelf - администратор не может заблокировать собственный аккаунт
+	ErrSuspendSelf = apperrors.Forbidden("cannot suspend your own account")
+)
+
+// UserService - сервис публичных профилей пользователей
+type UserService struct {
+	userRepo    interfaces.UserRepository
+	reviewRepo  interfaces.ReviewRepository
+	quoteRepo   interfaces.QuoteRepository
+	readingRepo interfaces.ReadingRepository
+	policy      *VisibilityPolicy
+	audit       *AuditService
+	txManager   interfaces.TxManager
+}
+
+// NewUserService - создание нового UserService
+func NewUserService(
+	userRepo interfaces.UserRepository,
+	reviewRepo interfaces.ReviewRepository,
+	quoteRepo interfaces.QuoteRepository,
+	readingRepo interfaces.ReadingRepository,
+	policy *VisibilityPolicy,
+	audit *AuditService,
+	txManager interfaces.TxManager,
+) *UserService {
+	return &UserService{
+		userRepo:    userRepo,
+		reviewRepo:  reviewRepo,
+		quoteRepo:   quoteRepo,
+		readingRepo: readingRepo,
+		policy:      policy,
+		audit:       audit,
+		txManager:   txManager,
+	}
+}
+
+// GetPublicProfile - получение публичного профиля с учетом profile_visibility
+func (s *UserService) GetPublicProfile(ctx context.Context, viewer *utils.Claims, username string) (*models.PublicUserResponse, error) {
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.policy.CheckProfile(ctx, viewer, user); err != nil {
+		return nil, err
+	}
+
+	return user.ToPublicResponse(), nil
+}
+
+// GetUserReviews - отзывы пользователя с учетом activity_visibility
+func (s *UserService) GetUserReviews(ctx context.Context, viewer *utils.Claims, username string, limit, offset int) ([]*models.ReviewResponse, error) {
+	owner, err := s.activityOwner(ctx, viewer, username)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.reviewRepo.ListByUser(ctx, owner.ID, false, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+
+	responses := make([]*models.ReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = review.ToResponse()
+	}
+	return responses, nil
+}
+
+// GetUserQuotes - цитаты пользователя с учетом activity_visibility
+func (s *UserService) GetUserQuotes(ctx context.Context, viewer *utils.Claims, username string, limit, offset int) ([]*models.QuoteResponse, error) {
+	owner, err := s.activityOwner(ctx, viewer, username)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes, err := s.quoteRepo.ListByUser(ctx, owner.ID, false, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quotes: %w", err)
+	}
+
+	responses := make([]*models.QuoteResponse, len(quotes))
+	for i, quote := range quotes {
+		responses[i] = quote.ToResponse()
+	}
+	return responses, nil
+}
+
+// GetUserProgress - прогресс чтения пользователя с учетом activity_visibility
+func (s *UserService) GetUserProgress(ctx context.Context, viewer *utils.Claims, username string, limit, offset int) ([]*models.UserBookProgressResponse, error) {
+	owner, err := s.activityOwner(ctx, viewer, username)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := s.readingRepo.ListProgressByUser(ctx, owner.ID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get progress: %w", err)
+	}
+
+	responses := make([]*models.UserBookProgressResponse, len(progress))
+	for i, p := range progress {
+		responses[i] = p.ToResponse()
+	}
+	return responses, nil
+}
+
+// GetUserSessions - сессии чтения пользователя с учетом activity_visibility
+func (s *UserService) GetUserSessions(ctx context.Context, viewer *utils.Claims, username string, limit, offset int) ([]*models.ReadingSessionResponse, error) {
+	owner, err := s.activityOwner(ctx, viewer, username)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.readingRepo.ListSessionsByUser(ctx, owner.ID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reading sessions: %w", err)
+	}
+
+	responses := make([]*models.ReadingSessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = session.ToResponse()
+	}
+	return responses, nil
+}
+
+// GetUserFavorites - избранные книги пользователя с учетом activity_visibility
+func (s *UserService) GetUserFavorites(ctx context.Context, viewer *utils.Claims, username string, limit, offset int) ([]*models.UserFavoriteResponse, error) {
+	owner, err := s.activityOwner(ctx, viewer, username)
+	if err != nil {
+		return nil, err
+	}
+
+	favorites, err := s.readingRepo.ListFavoritesByUser(ctx, owner.ID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get favorites: %w", err)
+	}
+
+	responses := make([]*models.UserFavoriteResponse, len(favorites))
+	for i, favorite := range favorites {
+		responses[i] = favorite.ToResponse()
+	}
+	return responses, nil
+}
+
+// DeleteUser - перемещение пользователя в корзину (восстановление через корзину администратора)
+func (s *UserService) DeleteUser(ctx context.Context, actorID, username string) error {
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		user, err := s.userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			return err
+		}
+		if user.ID == actorID {
+			return ErrDeleteSelf
+		}
+
+		if err := s.userRepo.Delete(ctx, user.ID); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditUserDelete, models.AuditTargetUser, user.ID, user.ToResponse(), nil)
+	})
+}
+
+// ChangeRole - смена роли пользователя администратором
+func (s *UserService) ChangeRole(ctx context.Context, actorID, username string, role models.UserRole) (*models.UserResponse, error) {
+	var updated *models.UserResponse
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		user, err := s.userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			return err
+		}
+		if user.ID == actorID {
+			return ErrChangeOwnRole
+		}
+
+		before := user.ToResponse()
+		user.Role = role
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+		updated = user.ToResponse()
+		return s.audit.Record(ctx, models.AuditUserRole, models.AuditTargetUser, user.ID, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// ListUsers - список пользователей для администратора: роли, предупреждения и блокировки
+func (s *UserService) ListUsers(ctx context.Context, limit, offset int) ([]*models.UserResponse, int, error) {
+	users, err := s.userRepo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.userRepo.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]*models.UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = user.ToResponse()
+	}
+	return responses, total, nil
+}
+
+// Suspend - блокировка пользователя администратором на days дней (заменяет действующую блокировку)
+func (s *UserService) Suspend(ctx context.Context, actorID, username string, days int) (*models.UserResponse, error) {
+	until := time.Now().AddDate(0, 0, days)
+	return s.setSuspension(ctx, actorID, username, &until, models.AuditUserSuspend)
+}
+
+// Unsuspend - снятие блокировки; число предупреждений не меняется
+func (s *UserService) Unsuspend(ctx context.Context, actorID, username string) (*models.UserResponse, error) {
+	return s.setSuspension(ctx, actorID, username, nil, models.AuditUserUnsuspend)
+}
+
+// setSuspension - смена срока блокировки с записью в журнал аудита
+func (s *UserService) setSuspension(ctx context.Context, actorID, username string, until *time.Time, action models.AuditAction) (*models.UserResponse, error) {
+	var updated *models.UserResponse
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		user, err := s.userRepo.GetByUsername(ctx, username)
+		if err != nil {
+			return err
+		}
+		if user.ID == actorID {
+			return ErrSuspendSelf
+		}
+
+		before := user.ToResponse()
+		if err := s.userRepo.SetSuspension(ctx, user.ID, until); err != nil {
+			return err
+		}
+		user.SuspendedUntil = until
+		updated = user.ToResponse()
+		return s.audit.Record(ctx, action, models.AuditTargetUser, user.ID, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// activityOwner - загрузка владельца активности и проверка видимости
+func (s *UserService) activityOwner(ctx context.Context, viewer *utils.Claims, username string) (*models.User, error) {
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.policy.CheckActivity(ctx, viewer, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/apperrors"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/verification"
+)
+
+const (
+	defaultProposalsLimit = 20
+	maxProposalsLimit     = 100
+)
+
+var (
+	// ErrInvalidProposalStatus - недопустимый статус предложения в фильтре
+	ErrInvalidProposalStatus = apperrors.Validation("status must be one of: pending, needs_review, accepted, rejected")
+	// ErrProposalClosed - по предложению уже принято решение (или оно ждет модератора и голосование закрыто)
+	ErrProposalClosed = apperrors.Conflict("proposal is no longer open")
+	// ErrVoteOwnProposal - автор не голосует за свое предложение
+	ErrVoteOwnProposal = apperrors.Forbidden("you cannot vote on your own proposal")
+	// ErrBookAlreadyVerified - подтверждать без исправлений уже верифицированную книгу не нужно
+	ErrBookAlreadyVerified = apperrors.Conflict("book is already verified")
+	// ErrVerifierDisabled - автоматическая проверка не настроена (VERIFICATION_VERIFIER=none)
+	ErrVerifierDisabled = apperrors.New(apperrors.CodeUnprocessable, "automatic verification is not configured")
+)
+
+// VerificationOptions - правила голосования и автоматической проверки
+type VerificationOptions struct {
+	// VoteThreshold - перевес доверенных голосов для принятия или отклонения предложения
+	VoteThreshold int
+	// TrustedMinAccountAge и TrustedMinReviews - условия доверия к голосу обычного пользователя
+	TrustedMinAccountAge time.Duration
+	TrustedMinReviews    int
+	// AIMinConfidence - минимальная уверенность проверки для верификации типа AI
+	AIMinConfidence float64
+}
+
+// VerificationService - сервис верификации книг: предложения исправлений, голосование,
+// решения модераторов и автоматическая проверка
+type VerificationService struct {
+	proposalRepo    interfaces.ProposalRepository
+	bookRepo        interfaces.BookRepository
+	verifier        verification.Verifier
+	revisionService *RevisionService
+	audit           *AuditService
+	txManager       interfaces.TxManager
+	opts            VerificationOptions
+}
+
+// NewVerificationService - создание нового VerificationService; verifier может быть nil (проверка отключена)
+func NewVerificationService(
+	proposalRepo interfaces.ProposalRepository,
+	bookRepo interfaces.BookRepository,
+	verifier verification.Verifier,
+	revisionService *RevisionService,
+	audit *AuditService,
+	txManager interfaces.TxManager,
+	opts VerificationOptions,
+) *VerificationService {
+	return &VerificationService{
+		proposalRepo:    proposalRepo,
+		bookRepo:        bookRepo,
+		verifier:        verifier,
+		revisionService: revisionService,
+		audit:           audit,
+		txManager:       txManager,
+		opts:            opts,
+	}
+}
+
+// CreateProposal - предложение исправлений книги. Если проверка настроена, она сразу оценивает
+// книгу с исправлениями; сбой проверки не мешает отправить предложение.
+func (s *VerificationService) CreateProposal(ctx context.Context, userID, bookID string, req *models.CreateProposalRequest) (*models.BookProposal, error) {
+	book, err := s.bookRepo.GetByID(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Changes == (models.BookChanges{}) && book.Verified {
+		return nil, ErrBookAlreadyVerified
+	}
+
+	proposal := &models.BookProposal{
+		BookID:      book.ID,
+		ProposerID:  &userID,
+		Changes:     req.Changes,
+		Comment:     req.Comment,
+		BookVersion: book.Version,
+	}
+
+	if s.verifier != nil {
+		proposed := *book
+		req.Changes.Apply(&proposed)
+		verdict, err := s.verifier.Verify(ctx, &proposed)
+		if err != nil {
+			slog.WarnContext(ctx, "book verification failed", "book_id", book.ID, "error", err)
+		}
+		proposal.Verdict = verdict
+	}
+
+	if err := s.proposalRepo.Create(ctx, proposal); err != nil {
+		return nil, err
+	}
+	return proposal, nil
+}
+
+// GetProposal - предложение с итогами голосования
+func (s *VerificationService) GetProposal(ctx context.Context, id string) (*models.BookProposal, error) {
+	return s.proposalRepo.GetByID(ctx, id)
+}
+
+// ListBookProposals - предложения книги от новых к старым (пустой status - все)
+func (s *VerificationService) ListBookProposals(ctx context.Context, bookID string, status models.ProposalStatus, limit, offset int) ([]*models.BookProposal, error) {
+	if status != "" && !status.IsValid() {
+		return nil, ErrInvalidProposalStatus
+	}
+	if _, err := s.bookRepo.GetByID(ctx, bookID); err != nil {
+		return nil, err
+	}
+	limit, offset = proposalsPage(limit, offset)
+	return s.proposalRepo.ListByBook(ctx, bookID, status, limit, offset)
+}
+
+// Queue - очередь модераторов: предложения в статусе status от давних к новым (по умолчанию needs_review)
+func (s *VerificationService) Queue(ctx context.Context, status models.ProposalStatus, limit, offset int) ([]*models.BookProposal, error) {
+	if status == "" {
+		status = models.ProposalStatusNeedsReview
+	}
+	if !status.IsValid() {
+		return nil, ErrInvalidProposalStatus
+	}
+	limit, offset = proposalsPage(limit, offset)
+	return s.proposalRepo.ListByStatus(ctx, status, limit, offset)
+}
+
+// Vote - голос пользователя за предложение или против него (повторный голос заменяет прежний).
+// Когда перевес доверенных голосов достигает порога, предложение принимается и книга становится
+// верифицированной сообществом, либо отклоняется. Если книгу изменили после предложения
+// или проверка его отклонила, решение передается модераторам (needs_review).
+func (s *VerificationService) Vote(ctx context.Context, userID, proposalID string, approve bool) (*models.BookProposal, error) {
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		status, err := s.proposalRepo.Lock(ctx, proposalID)
+		if err != nil {
+			return err
+		}
+		if status != models.ProposalStatusPending {
+			return ErrProposalClosed
+		}
+
+		proposal, err := s.proposalRepo.GetByID(ctx, proposalID)
+		if err != nil {
+			return err
+		}
+		if proposal.ProposerID != nil && *proposal.ProposerID == userID {
+			return ErrVoteOwnProposal
+		}
+
+		voter, err := s.proposalRepo.GetVoter(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if err := s.proposalRepo.Vote(ctx, proposalID, userID, approve, s.trusted(voter)); err != nil {
+			return err
+		}
+
+		proposal, err = s.proposalRepo.GetByID(ctx, proposalID)
+		if err != nil {
+			return err
+		}
+		return s.tally(ctx, proposal)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.proposalRepo.GetByID(ctx, proposalID)
+}
+
+// Accept - принятие предложения модератором независимо от голосов: исправления применяются
+// к текущей версии книги, книга становится верифицированной сообществом
+func (s *VerificationService) Accept(ctx context.Context, moderatorID, proposalID string, note *string) (*models.BookProposal, error) {
+	return s.resolve(ctx, moderatorID, proposalID, models.ProposalStatusAccepted, note)
+}
+
+// Reject - отклонение предложения модератором
+func (s *VerificationService) Reject(ctx context.Context, moderatorID, proposalID string, note *string) (*models.BookProposal, error) {
+	return s.resolve(ctx, moderatorID, proposalID, models.ProposalStatusRejected, note)
+}
+
+// RunVerifier - автоматическая проверка книги. Одобренная с достаточной уверенностью
+// и еще не верифицированная книга получает верификацию типа AI; иначе книга не меняется.
+func (s *VerificationService) RunVerifier(ctx context.Context, bookID string) (*models.Book, *models.VerificationVerdict, error) {
+	if s.verifier == nil {
+		return nil, nil, ErrVerifierDisabled
+	}
+
+	book, err := s.bookRepo.GetByID(ctx, bookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	verdict, err := s.verifier.Verify(ctx, book)
+	if err != nil {
+		return nil, nil, apperrors.Wrap(apperrors.CodeUnprocessable, "automatic verification failed", err)
+	}
+	if !verdict.Approved || verdict.Confidence < s.opts.AIMinConfidence || book.Verified {
+		return book, verdict, nil
+	}
+
+	var verified models.Book
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.bookRepo.GetByID(ctx, bookID)
+		if err != nil {
+			return err
+		}
+		if current.Version != book.Version {
+			// Проверялась не та версия книги, которая сохранена сейчас
+			return interfaces.ErrBookVersionConflict
+		}
+
+		verified = *current
+		verificationType := models.VerificationTypeAI
+		verified.Verified = true
+		verified.VerificationType = &verificationType
+		if err := s.bookRepo.Update(ctx, &verified); err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, models.AuditBookVerify, models.AuditTargetBook, bookID, current.ToResponse(), verified.ToResponse())
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &verified, verdict, nil
+}
+
+// resolve - решение модератора по открытому предложению (pending или needs_review)
+func (s *VerificationService) resolve(ctx context.Context, moderatorID, proposalID string, status models.ProposalStatus, note *string) (*models.BookProposal, error) {
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.proposalRepo.Lock(ctx, proposalID)
+		if err != nil {
+			return err
+		}
+		if !current.IsOpen() {
+			return ErrProposalClosed
+		}
+
+		proposal, err := s.proposalRepo.GetByID(ctx, proposalID)
+		if err != nil {
+			return err
+		}
+		action := models.AuditProposalReject
+		if status == models.ProposalStatusAccepted {
+			action = models.AuditProposalAccept
+			if err := s.apply(ctx, proposal); err != nil {
+				return err
+			}
+		}
+		if err := s.proposalRepo.Resolve(ctx, proposalID, status, &moderatorID, note); err != nil {
+			return err
+		}
+
+		resolved, err := s.proposalRepo.GetByID(ctx, proposalID)
+		if err != nil {
+			return err
+		}
+		return s.audit.Record(ctx, action, models.AuditTargetBookProposal, proposalID, proposal, resolved)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.proposalRepo.GetByID(ctx, proposalID)
+}
+
+// tally - решение по итогам голосования (вызывается в транзакции голоса)
+func (s *VerificationService) tally(ctx context.Context, proposal *models.BookProposal) error {
+	margin := proposal.Votes.TrustedApprovals - proposal.Votes.TrustedRejections
+	switch {
+	case margin <= -s.opts.VoteThreshold:
+		return s.proposalRepo.Resolve(ctx, proposal.ID, models.ProposalStatusRejected, nil, nil)
+
+	case margin >= s.opts.VoteThreshold:
+		if proposal.Verdict != nil && !proposal.Verdict.Approved {
+			return s.proposalRepo.SetStatus(ctx, proposal.ID, models.ProposalStatusNeedsReview)
+		}
+		book, err := s.bookRepo.GetByID(ctx, proposal.BookID)
+		if err != nil {
+			return err
+		}
+		if book.Version != proposal.BookVersion {
+			return s.proposalRepo.SetStatus(ctx, proposal.ID, models.ProposalStatusNeedsReview)
+		}
+		if err := s.apply(ctx, proposal); err != nil {
+			return err
+		}
+		return s.proposalRepo.Resolve(ctx, proposal.ID, models.ProposalStatusAccepted, nil, nil)
+	}
+	return nil
+}
+
+// apply - применение исправлений к книге и верификация сообществом: правка попадает в историю ревизий
+// от имени автора предложения и в журнал аудита
+func (s *VerificationService) apply(ctx context.Context, proposal *models.BookProposal) error {
+	current, err := s.bookRepo.GetByID(ctx, proposal.BookID)
+	if err != nil {
+		return err
+	}
+
+	book := *current
+	proposal.Changes.Apply(&book)
+	verificationType := models.VerificationTypeCommunity
+	book.Verified = true
+	book.VerificationType = &verificationType
+	if err := s.bookRepo.Update(ctx, &book); err != nil {
+		return err
+	}
+
+	// Автор удаленного аккаунта не известен - правку записывает тот, кто принял решение
+	editorID := ""
+	if proposal.ProposerID != nil {
+		editorID = *proposal.ProposerID
+	} else if actor := AuditActorFrom(ctx); actor != nil {
+		editorID = actor.UserID
+	}
+	if editorID != "" {
+		comment := "proposal " + proposal.ID
+		err := s.revisionService.Record(ctx, models.RevisionEntityBook, book.ID, current.Version, book.Version,
+			current.Edit(), book.Edit(), editorID, &comment)
+		if err != nil {
+			return err
+		}
+	}
+	return s.audit.Record(ctx, models.AuditBookVerify, models.AuditTargetBook, book.ID, current.ToResponse(), book.ToResponse())
+}
+
+// trusted - голос модератора, администратора или пользователя с достаточно старым аккаунтом и рецензиями
+func (s *VerificationService) trusted(voter *models.ProposalVoter) bool {
+	if voter.Role == models.UserRoleModerator || voter.Role == models.UserRoleAdmin {
+		return true
+	}
+	if voter.CreatedAt == nil {
+		return false
+	}
+	return time.Since(*voter.CreatedAt) >= s.opts.TrustedMinAccountAge && voter.Reviews >= s.opts.TrustedMinReviews
+}
+
+// proposalsPage - лимит и смещение в допустимых границах
+func proposalsPage(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultProposalsLimit
+	}
+	if limit > maxProposalsLimit {
+		limit = maxProposalsLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
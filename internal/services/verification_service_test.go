@@ -0,0 +1,350 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/verification"
+)
+
+// memProposals - ProposalRepository в памяти
+type memProposals struct {
+	proposals map[string]*models.BookProposal
+}
+
+func (r *memProposals) Create(ctx context.Context, proposal *models.BookProposal) error {
+	proposal.ID = "proposal-1"
+	proposal.Status = models.ProposalStatusPending
+	proposal.CreatedAt = time.Now()
+	stored := *proposal
+	r.proposals[proposal.ID] = &stored
+	return nil
+}
+
+func (r *memProposals) GetByID(ctx context.Context, id string) (*models.BookProposal, error) {
+	proposal, ok := r.proposals[id]
+	if !ok {
+		return nil, interfaces.ErrProposalNotFound
+	}
+	copied := *proposal
+	return &copied, nil
+}
+
+func (r *memProposals) Lock(ctx context.Context, id string) (models.ProposalStatus, error) {
+	proposal, err := r.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return proposal.Status, nil
+}
+
+func (r *memProposals) ListByBook(ctx context.Context, bookID string, status models.ProposalStatus, limit, offset int) ([]*models.BookProposal, error) {
+	return nil, nil
+}
+
+func (r *memProposals) ListByStatus(ctx context.Context, status models.ProposalStatus, limit, offset int) ([]*models.BookProposal, error) {
+	return nil, nil
+}
+
+func (r *memProposals) Vote(ctx context.Context, proposalID, userID string, approve, trusted bool) error {
+	return nil
+}
+
+func (r *memProposals) SetStatus(ctx context.Context, id string, status models.ProposalStatus) error {
+	r.proposals[id].Status = status
+	return nil
+}
+
+func (r *memProposals) Resolve(ctx context.Context, id string, status models.ProposalStatus, resolvedBy, note *string) error {
+	now := time.Now()
+	r.proposals[id].Status = status
+	r.proposals[id].ResolvedBy = resolvedBy
+	r.proposals[id].ResolutionNote = note
+	r.proposals[id].ResolvedAt = &now
+	return nil
+}
+
+func (r *memProposals) GetVoter(ctx context.Context, userID string) (*models.ProposalVoter, error) {
+	return nil, interfaces.ErrVoterNotFound
+}
+
+// memBooks - BookRepository в памяти: только чтение и обновление с проверкой версии
+type memBooks struct {
+	interfaces.BookRepository
+	books map[string]*models.Book
+}
+
+func (r *memBooks) GetByID(ctx context.Context, id string) (*models.Book, error) {
+	book, ok := r.books[id]
+	if !ok {
+		return nil, interfaces.ErrBookNotFound
+	}
+	copied := *book
+	return &copied, nil
+}
+
+func (r *memBooks) Update(ctx context.Context, book *models.Book) error {
+	stored, ok := r.books[book.ID]
+	if !ok {
+		return interfaces.ErrBookNotFound
+	}
+	if stored.Version != book.Version {
+		return interfaces.ErrBookVersionConflict
+	}
+	book.Version++
+	copied := *book
+	r.books[book.ID] = &copied
+	return nil
+}
+
+// memRevisions - RevisionRepository в памяти: только запись ревизий
+type memRevisions struct {
+	interfaces.RevisionRepository
+	revisions []*models.Revision
+}
+
+func (r *memRevisions) Create(ctx context.Context, revision *models.Revision) error {
+	r.revisions = append(r.revisions, revision)
+	return nil
+}
+
+func (r *memRevisions) CreateBaseline(ctx context.Context, revision *models.Revision) error {
+	return r.Create(ctx, revision)
+}
+
+func (r *memRevisions) Exists(ctx context.Context, entity models.RevisionEntity, entityID string) (bool, error) {
+	return len(r.revisions) > 0, nil
+}
+
+// memAudit - AuditRepository в памяти: только запись
+type memAudit struct {
+	interfaces.AuditRepository
+	entries []*models.AuditEntry
+}
+
+func (r *memAudit) Create(ctx context.Context, entry *models.AuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// inlineTx - TxManager без базы данных: fn выполняется сразу
+type inlineTx struct{}
+
+func (inlineTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (inlineTx) WithinSerializableTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// verificationFixture - сервис верификации поверх репозиториев в памяти и FakeVerifier
+type verificationFixture struct {
+	service   *VerificationService
+	proposals *memProposals
+	books     *memBooks
+	revisions *memRevisions
+	audit     *memAudit
+}
+
+func newVerificationFixture(opts VerificationOptions) *verificationFixture {
+	f := &verificationFixture{
+		proposals: &memProposals{proposals: make(map[string]*models.BookProposal)},
+		books:     &memBooks{books: make(map[string]*models.Book)},
+		revisions: &memRevisions{},
+		audit:     &memAudit{},
+	}
+	f.service = NewVerificationService(f.proposals, f.books, verification.NewFakeVerifier(),
+		NewRevisionService(f.revisions), NewAuditService(f.audit), inlineTx{}, opts)
+	return f
+}
+
+func testBook() *models.Book {
+	return &models.Book{
+		ID:          "book-1",
+		Title:       "Мастер и Маргарита",
+		Author:      "Михаил Булгаков",
+		Description: "Роман о визите дьявола в Москву 1930-х годов",
+		PagesCount:  480,
+		Genres:      []string{},
+		Tags:        []string{},
+		Version:     1,
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestVerificationTally(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes models.BookChanges
+		// staleBy - на сколько версий книга ушла вперед после создания предложения
+		staleBy      int
+		votes        models.ProposalTally
+		wantStatus   models.ProposalStatus
+		wantVerified bool
+		wantTitle    string
+	}{
+		{
+			name:         "approvals reach threshold",
+			changes:      models.BookChanges{Title: ptr("Мастер и Маргарита (полная версия)")},
+			votes:        models.ProposalTally{Approvals: 3, TrustedApprovals: 2},
+			wantStatus:   models.ProposalStatusAccepted,
+			wantVerified: true,
+			wantTitle:    "Мастер и Маргарита (полная версия)",
+		},
+		{
+			name:       "trusted rejections reach threshold",
+			changes:    models.BookChanges{Title: ptr("Мастер")},
+			votes:      models.ProposalTally{Rejections: 2, TrustedRejections: 2},
+			wantStatus: models.ProposalStatusRejected,
+			wantTitle:  "Мастер и Маргарита",
+		},
+		{
+			name:       "margin below threshold keeps proposal open",
+			changes:    models.BookChanges{Title: ptr("Мастер")},
+			votes:      models.ProposalTally{Approvals: 5, Rejections: 1, TrustedApprovals: 2, TrustedRejections: 1},
+			wantStatus: models.ProposalStatusPending,
+			wantTitle:  "Мастер и Маргарита",
+		},
+		{
+			name:       "untrusted approvals do not count",
+			changes:    models.BookChanges{Title: ptr("Мастер")},
+			votes:      models.ProposalTally{Approvals: 10},
+			wantStatus: models.ProposalStatusPending,
+			wantTitle:  "Мастер и Маргарита",
+		},
+		{
+			name:       "rejecting verdict sends approved proposal to moderators",
+			changes:    models.BookChanges{Description: ptr("Коротко")},
+			votes:      models.ProposalTally{Approvals: 2, TrustedApprovals: 2},
+			wantStatus: models.ProposalStatusNeedsReview,
+			wantTitle:  "Мастер и Маргарита",
+		},
+		{
+			name:       "stale book version sends approved proposal to moderators",
+			changes:    models.BookChanges{Title: ptr("Мастер")},
+			staleBy:    1,
+			votes:      models.ProposalTally{Approvals: 2, TrustedApprovals: 2},
+			wantStatus: models.ProposalStatusNeedsReview,
+			wantTitle:  "Мастер и Маргарита",
+		},
+		{
+			name:       "rejections close proposal regardless of verdict",
+			changes:    models.BookChanges{Description: ptr("Коротко")},
+			votes:      models.ProposalTally{Rejections: 2, TrustedRejections: 2},
+			wantStatus: models.ProposalStatusRejected,
+			wantTitle:  "Мастер и Маргарита",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newVerificationFixture(VerificationOptions{VoteThreshold: 2})
+			f.books.books["book-1"] = testBook()
+
+			proposal, err := f.service.CreateProposal(ctx, "user-1", "book-1", &models.CreateProposalRequest{Changes: tt.changes})
+			if err != nil {
+				t.Fatalf("CreateProposal: %v", err)
+			}
+			if proposal.Verdict == nil {
+				t.Fatal("CreateProposal did not store the verifier verdict")
+			}
+			f.books.books["book-1"].Version += tt.staleBy
+
+			proposal.Votes = tt.votes
+			if err := f.service.tally(ctx, proposal); err != nil {
+				t.Fatalf("tally: %v", err)
+			}
+
+			stored, _ := f.proposals.GetByID(ctx, proposal.ID)
+			if stored.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", stored.Status, tt.wantStatus)
+			}
+
+			book, _ := f.books.GetByID(ctx, "book-1")
+			if book.Verified != tt.wantVerified {
+				t.Errorf("book verified = %v, want %v", book.Verified, tt.wantVerified)
+			}
+			if book.Title != tt.wantTitle {
+				t.Errorf("book title = %q, want %q", book.Title, tt.wantTitle)
+			}
+
+			if !tt.wantVerified {
+				if len(f.audit.entries) != 0 || len(f.revisions.revisions) != 0 {
+					t.Errorf("unapplied proposal recorded %d audit entries and %d revisions", len(f.audit.entries), len(f.revisions.revisions))
+				}
+				return
+			}
+			if book.VerificationType == nil || *book.VerificationType != models.VerificationTypeCommunity {
+				t.Errorf("verification type = %v, want %s", book.VerificationType, models.VerificationTypeCommunity)
+			}
+			if book.Version != 2 {
+				t.Errorf("book version = %d, want 2", book.Version)
+			}
+			if len(f.audit.entries) != 1 || f.audit.entries[0].Action != models.AuditBookVerify {
+				t.Errorf("audit entries = %+v, want one %s", f.audit.entries, models.AuditBookVerify)
+			}
+			if len(f.revisions.revisions) == 0 {
+				t.Error("applied proposal recorded no revision")
+			}
+		})
+	}
+}
+
+func TestVerificationTrusted(t *testing.T) {
+	now := time.Now()
+	opts := VerificationOptions{TrustedMinAccountAge: 30 * 24 * time.Hour, TrustedMinReviews: 3}
+
+	tests := []struct {
+		name  string
+		voter *models.ProposalVoter
+		want  bool
+	}{
+		{
+			name:  "moderator",
+			voter: &models.ProposalVoter{Role: models.UserRoleModerator, CreatedAt: ptr(now)},
+			want:  true,
+		},
+		{
+			name:  "admin without reviews",
+			voter: &models.ProposalVoter{Role: models.UserRoleAdmin},
+			want:  true,
+		},
+		{
+			name:  "old account with enough reviews",
+			voter: &models.ProposalVoter{Role: models.UserRoleUser, CreatedAt: ptr(now.Add(-60 * 24 * time.Hour)), Reviews: 3},
+			want:  true,
+		},
+		{
+			name:  "old account with too few reviews",
+			voter: &models.ProposalVoter{Role: models.UserRoleUser, CreatedAt: ptr(now.Add(-60 * 24 * time.Hour)), Reviews: 2},
+			want:  false,
+		},
+		{
+			name:  "new account with many reviews",
+			voter: &models.ProposalVoter{Role: models.UserRoleUser, CreatedAt: ptr(now.Add(-24 * time.Hour)), Reviews: 50},
+			want:  false,
+		},
+		{
+			name:  "unknown account age",
+			voter: &models.ProposalVoter{Role: models.UserRoleUser, Reviews: 50},
+			want:  false,
+		},
+	}
+
+	f := newVerificationFixture(opts)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.service.trusted(tt.voter); got != tt.want {
+				t.Errorf("trusted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
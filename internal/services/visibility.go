@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/repositories/interfaces"
+	"github.com/tukembaev/bookVisionGo/internal/utils"
+)
+
+// VisibilityPolicy - правила доступа к профилю и активности пользователя
+type VisibilityPolicy struct {
+	followRepo interfaces.FollowRepository
+}
+
+// NewVisibilityPolicy - создание новой VisibilityPolicy
+func NewVisibilityPolicy(followRepo interfaces.FollowRepository) *VisibilityPolicy {
+	return &VisibilityPolicy{
+		followRepo: followRepo,
+	}
+}
+
+// CheckProfile - проверка доступа зрителя к профилю владельца
+func (p *VisibilityPolicy) CheckProfile(ctx context.Context, viewer *utils.Claims, owner *models.User) error {
+	allowed, err := p.canView(ctx, viewer, owner, owner.ProfileVisibility)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrProfileHidden
+	}
+	return nil
+}
+
+// CheckActivity - проверка доступа зрителя к активности владельца.
+// Скрытый профиль скрывает и активность, даже если activity_visibility шире.
+func (p *VisibilityPolicy) CheckActivity(ctx context.Context, viewer *utils.Claims, owner *models.User) error {
+	if err := p.CheckProfile(ctx, viewer, owner); err != nil {
+		return err
+	}
+
+	allowed, err := p.canView(ctx, viewer, owner, owner.ActivityVisibility)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrActivityHidden
+	}
+	return nil
+}
+
+// SharesActivity - попадает ли активность владельца в ленты подписчиков
+func (p *VisibilityPolicy) SharesActivity(owner *models.User) bool {
+	return owner.ProfileVisibility != models.VisibilityPrivate &&
+		owner.ActivityVisibility != models.VisibilityPrivate
+}
+
+// canView - проверка доступа зрителя к данным владельца с заданной видимостью
+func (p *VisibilityPolicy) canView(ctx context.Context, viewer *utils.Claims, owner *models.User, visibility models.Visibility) (bool, error) {
+	if viewer != nil && viewer.Role == models.UserRoleAdmin {
+		return true, nil
+	}
+
+	isOwner := viewer != nil && viewer.UserID == owner.ID
+	if isOwner || visibility != models.VisibilityFollowers {
+		return visibility.Allows(isOwner, false), nil
+	}
+
+	// Анонимный зритель не может быть подписчиком
+	if viewer == nil {
+		return false, nil
+	}
+
+	isFollower, err := p.followRepo.IsFollowing(ctx, viewer.UserID, owner.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check follow: %w", err)
+	}
+	return visibility.Allows(false, isFollower), nil
+}
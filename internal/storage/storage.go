@@ -0,0 +1,77 @@
+// Package storage - хранилища бинарных объектов (обложки, аватары, иллюстрации)
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/config"
+)
+
+var (
+	// ErrNotFound - объект не найден
+	ErrNotFound = errors.New("blob not found")
+	// ErrInvalidKey - недопустимый ключ объекта
+	ErrInvalidKey = errors.New("invalid blob key")
+	// ErrPresignNotSupported - хранилище не выдает подписанные ссылки
+	ErrPresignNotSupported = errors.New("presigned urls are not supported")
+)
+
+// ObjectInfo - метаданные объекта
+type ObjectInfo struct {
+	Key         string
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+	ETag        string
+}
+
+// BlobStore - хранилище бинарных объектов по ключу
+type BlobStore interface {
+	// Put - запись объекта (существующий объект перезаписывается)
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+
+	// Get - чтение объекта; вызывающий закрывает reader
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+
+	// Delete - удаление объекта (отсутствующий объект не считается ошибкой)
+	Delete(ctx context.Context, key string) error
+
+	// PresignGet - подписанная ссылка на чтение объекта или ErrPresignNotSupported
+	PresignGet(ctx context.Context, key string, ttl time.Duration) (string, error)
+
+	// Ping - проверка доступности хранилища (для /readyz)
+	Ping(ctx context.Context) error
+}
+
+// keyPattern - допустимые ключи: сегменты из латиницы, цифр, '.', '_' и '-' через '/'
+var keyPattern = regexp.MustCompile(`^[a-zA-Z0-9_-][a-zA-Z0-9._-]*(/[a-zA-Z0-9_-][a-zA-Z0-9._-]*)*$`)
+
+// ValidKey - проверка ключа объекта (без '..', абсолютных путей и пустых сегментов)
+func ValidKey(key string) bool {
+	return len(key) <= 512 && keyPattern.MatchString(key) && !strings.Contains(key, "..")
+}
+
+// New - создание хранилища по конфигурации (STORAGE_DRIVER=local|s3)
+func New(ctx context.Context, cfg config.StorageConfig) (BlobStore, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStore(cfg.LocalDir)
+	case "s3":
+		return NewS3Store(ctx, S3Options{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			UseSSL:    cfg.S3UseSSL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
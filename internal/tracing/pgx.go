@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PgxTracer - трассировка pgx: каждый запрос, batch и COPY становится дочерним спаном
+// текущего контекста. В спан пишется очищенный текст запроса (SanitizeSQL);
+// значения аргументов не записываются.
+type PgxTracer struct {
+	tracer trace.Tracer
+}
+
+// NewPgxTracer - создание нового PgxTracer (подключается через pgx.ConnConfig.Tracer)
+func NewPgxTracer() *PgxTracer {
+	return &PgxTracer{tracer: otel.Tracer(instrumentationName + "/pgx")}
+}
+
+var (
+	_ pgx.QueryTracer    = (*PgxTracer)(nil)
+	_ pgx.BatchTracer    = (*PgxTracer)(nil)
+	_ pgx.CopyFromTracer = (*PgxTracer)(nil)
+)
+
+// TraceQueryStart - спан запроса; имя - имя запроса sqlc или SQL операция
+func (t *PgxTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, span := t.start(ctx, conn, "query")
+	if !span.IsRecording() {
+		return ctx
+	}
+
+	sanitized := SanitizeSQL(data.SQL)
+	operation := operationName(sanitized)
+	name := queryName(data.SQL)
+	if name == "" {
+		name = operation
+	}
+	span.SetName(name)
+	span.SetAttributes(semconv.DBQueryText(sanitized), semconv.DBOperationName(operation))
+	return ctx
+}
+
+// TraceQueryEnd - завершение спана запроса
+func (t *PgxTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if data.Err == nil {
+		span.SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	endSpan(span, data.Err)
+}
+
+// TraceBatchStart - спан batch; запросы batch записываются событиями
+func (t *PgxTracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	ctx, span := t.start(ctx, conn, "batch")
+	if data.Batch != nil {
+		span.SetAttributes(semconv.DBOperationBatchSize(data.Batch.Len()))
+	}
+	return ctx
+}
+
+// TraceBatchQuery - событие с очищенным текстом запроса из batch
+func (t *PgxTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	attrs := []attribute.KeyValue{semconv.DBQueryText(SanitizeSQL(data.SQL))}
+	if data.Err != nil {
+		attrs = append(attrs, attribute.String("error", data.Err.Error()))
+	}
+	span.AddEvent("query", trace.WithAttributes(attrs...))
+}
+
+// TraceBatchEnd - завершение спана batch
+func (t *PgxTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	endSpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+// TraceCopyFromStart - спан COPY FROM
+func (t *PgxTracer) TraceCopyFromStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	ctx, span := t.start(ctx, conn, "COPY "+data.TableName.Sanitize())
+	span.SetAttributes(semconv.DBOperationName("COPY"), semconv.DBCollectionName(data.TableName.Sanitize()))
+	return ctx
+}
+
+// TraceCopyFromEnd - завершение спана COPY FROM
+func (t *PgxTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	span := trace.SpanFromContext(ctx)
+	if data.Err == nil {
+		span.SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	endSpan(span, data.Err)
+}
+
+// start - клиентский спан с общими атрибутами PostgreSQL
+func (t *PgxTracer) start(ctx context.Context, conn *pgx.Conn, name string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{semconv.DBSystemNamePostgreSQL}
+	if conn != nil {
+		attrs = append(attrs, semconv.DBNamespace(conn.Config().Database))
+	}
+	return t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// endSpan - завершение спана с записью ошибки
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
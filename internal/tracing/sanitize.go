@@ -0,0 +1,153 @@
+package tracing
+
+import (
+	"regexp"
+	"strings"
+)
+
+// sqlcNamePattern - имя запроса из комментария sqlc ("-- name: GetBookByID :one")
+var sqlcNamePattern = regexp.MustCompile(`^\s*--\s*name:\s*(\w+)`)
+
+// SanitizeSQL - текст запроса для спана: комментарии удаляются, строковые (в том числе E'...'
+// и $tag$...$tag$) и числовые литералы заменяются на ?, пробельные символы схлопываются в один пробел.
+// Плейсхолдеры ($1, $2, ...) и идентификаторы остаются как есть. Незакрытый литерал
+// или комментарий поглощает остаток текста.
+func SanitizeSQL(sql string) string {
+	var b strings.Builder
+	b.Grow(len(sql))
+
+	var prev byte
+	space := false
+	write := func(s string) {
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteString(s)
+		prev = s[len(s)-1]
+	}
+
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = len(sql) - i
+			}
+			i += end
+			space, prev = true, ' '
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += end + 4
+			}
+			space, prev = true, ' '
+		case c == '\'':
+			i = skipString(sql, i+1, false)
+			write("?")
+		case (c == 'E' || c == 'e') && !isIdentByte(prev) && i+1 < len(sql) && sql[i+1] == '\'':
+			i = skipString(sql, i+2, true)
+			write("?")
+		case c == '$' && !isIdentByte(prev) && dollarTag(sql[i:]) != "":
+			tag := dollarTag(sql[i:])
+			end := strings.Index(sql[i+len(tag):], tag)
+			if end < 0 {
+				i = len(sql)
+			} else {
+				i += len(tag) + end + len(tag)
+			}
+			write("?")
+		case c == '"':
+			end := strings.IndexByte(sql[i+1:], '"')
+			if end < 0 {
+				end = len(sql) - i - 2
+			}
+			write(sql[i : i+end+2])
+			i += end + 2
+		case isDigit(c) && !isIdentByte(prev):
+			for i < len(sql) && (isDigit(sql[i]) || sql[i] == '.') {
+				i++
+			}
+			// Экспонента: 1e10, 2.5E-3
+			if i < len(sql) && (sql[i] == 'e' || sql[i] == 'E') {
+				j := i + 1
+				if j < len(sql) && (sql[j] == '+' || sql[j] == '-') {
+					j++
+				}
+				if j < len(sql) && isDigit(sql[j]) {
+					for i = j; i < len(sql) && isDigit(sql[i]); i++ {
+					}
+				}
+			}
+			write("?")
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			space, prev = true, ' '
+		default:
+			write(sql[i : i+1])
+			i++
+		}
+	}
+	return b.String()
+}
+
+// skipString - позиция после строкового литерала, начинающегося с i (после открывающей кавычки).
+// Кавычка внутри литерала экранируется удвоением, в E'...' также обратной косой чертой.
+func skipString(sql string, i int, backslash bool) int {
+	for i < len(sql) {
+		switch {
+		case backslash && sql[i] == '\\':
+			i += 2
+		case sql[i] == '\'' && i+1 < len(sql) && sql[i+1] == '\'':
+			i += 2
+		case sql[i] == '\'':
+			return i + 1
+		default:
+			i++
+		}
+	}
+	return len(sql)
+}
+
+// dollarTag - открывающий разделитель строки в долларовых кавычках ($$ или $tag$) в начале s;
+// пустая строка, если s начинается с плейсхолдера или одиночного $
+func dollarTag(s string) string {
+	for j := 1; j < len(s); j++ {
+		c := s[j]
+		switch {
+		case c == '$':
+			return s[:j+1]
+		case c == '_' || (c|0x20 >= 'a' && c|0x20 <= 'z') || c >= 0x80:
+		case isDigit(c) && j > 1:
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// queryName - имя запроса sqlc, если оно есть в тексте
+func queryName(sql string) string {
+	if m := sqlcNamePattern.FindStringSubmatch(sql); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// operationName - первое ключевое слово очищенного запроса (SELECT, INSERT, WITH, ...)
+func operationName(sanitized string) string {
+	op, _, _ := strings.Cut(sanitized, " ")
+	return strings.ToUpper(strings.TrimLeft(op, "("))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isIdentByte - символ, после которого цифра - часть имени или плейсхолдера ($1, col2)
+func isIdentByte(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || (c|0x20 >= 'a' && c|0x20 <= 'z') || c >= 0x80
+}
@@ -0,0 +1,110 @@
+package tracing
+
+import "testing"
+
+func TestSanitizeSQL(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{
+			name: "placeholders and identifiers",
+			sql:  "SELECT id, col2 FROM books WHERE id = $1 AND version = $12",
+			want: "SELECT id, col2 FROM books WHERE id = $1 AND version = $12",
+		},
+		{
+			name: "sqlc name comment",
+			sql:  "-- name: GetBook :one\nSELECT * FROM books WHERE id = $1",
+			want: "SELECT * FROM books WHERE id = $1",
+		},
+		{
+			name: "line comment in the middle",
+			sql:  "SELECT 1 -- secret 'token'\nFROM t",
+			want: "SELECT ? FROM t",
+		},
+		{
+			name: "block comment",
+			sql:  "SELECT /* password='x' */ id FROM users",
+			want: "SELECT id FROM users",
+		},
+		{
+			name: "unterminated block comment",
+			sql:  "SELECT id /* trailing secret",
+			want: "SELECT id",
+		},
+		{
+			name: "string literal",
+			sql:  "SELECT * FROM users WHERE email = 'a@b.c'",
+			want: "SELECT * FROM users WHERE email = ?",
+		},
+		{
+			name: "doubled quote inside string",
+			sql:  "SELECT 'it''s secret', x",
+			want: "SELECT ?, x",
+		},
+		{
+			name: "backslash escape in E string",
+			sql:  `SELECT E'it\'s secret', x`,
+			want: "SELECT ?, x",
+		},
+		{
+			name: "backslash is literal in standard string",
+			sql:  `SELECT 'C:\', x`,
+			want: "SELECT ?, x",
+		},
+		{
+			name: "identifier ending with e before string",
+			sql:  "SELECT name'x'",
+			want: "SELECT name?",
+		},
+		{
+			name: "unterminated string",
+			sql:  "SELECT * FROM t WHERE a = 'secret",
+			want: "SELECT * FROM t WHERE a = ?",
+		},
+		{
+			name: "empty dollar quotes",
+			sql:  "SELECT $$secret 'text'$$, x",
+			want: "SELECT ?, x",
+		},
+		{
+			name: "tagged dollar quotes",
+			sql:  "DO $body$ BEGIN PERFORM '$$'; END $body$",
+			want: "DO ?",
+		},
+		{
+			name: "unterminated dollar quotes",
+			sql:  "SELECT $tag$secret",
+			want: "SELECT ?",
+		},
+		{
+			name: "dollar inside identifier",
+			sql:  "SELECT a$b$ FROM t",
+			want: "SELECT a$b$ FROM t",
+		},
+		{
+			name: "quoted identifier keeps digits and quotes",
+			sql:  `SELECT "col 1", "it's" FROM t`,
+			want: `SELECT "col 1", "it's" FROM t`,
+		},
+		{
+			name: "numbers",
+			sql:  "SELECT 42, 3.14, 1e10, 2.5E-3 LIMIT 10",
+			want: "SELECT ?, ?, ?, ? LIMIT ?",
+		},
+		{
+			name: "whitespace collapsed",
+			sql:  "SELECT\n\tid\r\n  FROM   t",
+			want: "SELECT id FROM t",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeSQL(tt.sql); got != tt.want {
+				t.Errorf("SanitizeSQL(%q) = %q, want %q", tt.sql, got, tt.want)
+			}
+		})
+	}
+}
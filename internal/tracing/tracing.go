@@ -0,0 +1,110 @@
+// Package tracing - трассировка OpenTelemetry: настройка экспортера (OTLP, stdout или без экспорта),
+// спаны SQL запросов pgx с очищенным текстом и вспомогательные функции для сервисов.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Экспортеры спанов
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// instrumentationName - имя библиотеки инструментирования в спанах приложения
+const instrumentationName = "github.com/tukembaev/bookVisionGo"
+
+// ErrUnknownExporter - неизвестное значение TRACING_EXPORTER
+var ErrUnknownExporter = errors.New("unknown tracing exporter")
+
+// Options - параметры трассировки
+type Options struct {
+	// Exporter - none, stdout или otlp
+	Exporter    string
+	ServiceName string
+	// OTLPEndpoint - URL коллектора (OTLP/HTTP), например http://localhost:4318;
+	// пустое значение - OTEL_EXPORTER_OTLP_ENDPOINT или localhost:4318
+	OTLPEndpoint string
+	// SampleRatio - доля корневых трассировок, которые записываются (0..1)
+	SampleRatio float64
+}
+
+// Setup - установка глобального TracerProvider и W3C propagation (traceparent, baggage).
+// Возвращает функцию остановки, которая выгружает накопленные спаны.
+// С экспортером none спаны не записываются, но контекст трассировки все равно передается дальше.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporter, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(opts.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// newExporter - экспортер спанов по имени; nil для none
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, error) {
+	switch opts.Exporter {
+	case "", ExporterNone:
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New()
+	case ExporterOTLP:
+		var httpOpts []otlptracehttp.Option
+		if opts.OTLPEndpoint != "" {
+			httpOpts = append(httpOpts, otlptracehttp.WithEndpointURL(opts.OTLPEndpoint))
+		}
+		return otlptracehttp.New(ctx, httpOpts...)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownExporter, opts.Exporter)
+	}
+}
+
+// Tracer - трассировщик приложения из глобального TracerProvider.
+// Можно получать до Setup: глобальный провайдер подменяется прозрачно.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start - дочерний спан текущего контекста (для сервисов и фоновых задач)
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End - завершение спана с записью ошибки (если она есть)
+func End(span trace.Span, err error) {
+	endSpan(span, err)
+}
@@ -34,8 +34,6 @@ func NewJWTUtils(cfg *config.Config) *JWTUtils {
 // GenerateToken - генерация JWT токена
 func (j *JWTUtils) GenerateToken(user *models.User) (string, error) {
 	// Создание claims
-	fmt.Println("DEBUG: expiresIn value is:", j.expiresIn)
-
 	claims := &Claims{
 		UserID:   user.ID,
 		Username: user.Username,
@@ -63,12 +61,8 @@ func (j *JWTUtils) GenerateToken(user *models.User) (string, error) {
 
 // ValidateToken - валидация JWT токена
 func (j *JWTUtils) ValidateToken(tokenString string) (*Claims, error) {
-	fmt.Printf("DEBUG: Validating token: %s\n", tokenString)
-	fmt.Printf("DEBUG: Using secret key: %s\n", j.secretKey)
-
 	// Парсинг токена
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		fmt.Printf("DEBUG: Token method: %v\n", token.Method)
 		// Проверка метода подписи
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -77,24 +71,20 @@ func (j *JWTUtils) ValidateToken(tokenString string) (*Claims, error) {
 	})
 
 	if err != nil {
-		fmt.Printf("DEBUG: Parse error: %v\n", err)
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
 
 	// Проверка валидности токена
 	if !token.Valid {
-		fmt.Printf("DEBUG: Token is invalid\n")
 		return nil, fmt.Errorf("invalid token")
 	}
 
 	// Извлечение claims
 	claims, ok := token.Claims.(*Claims)
 	if !ok {
-		fmt.Printf("DEBUG: Invalid claims\n")
 		return nil, fmt.Errorf("invalid token claims")
 	}
 
-	fmt.Printf("DEBUG: Token validated successfully for user: %s\n", claims.Username)
 	return claims, nil
 }
 
@@ -133,7 +123,6 @@ func ExtractTokenFromHeader(authHeader string) (string, error) {
 	if authHeader == "" {
 		return "", fmt.Errorf("authorization header is required")
 	}
-	fmt.Println("Authorization header:", authHeader)
 	// Проверка формата "Bearer <token>"
 	const bearerPrefix = "Bearer "
 	if len(authHeader) <= len(bearerPrefix) || authHeader[:len(bearerPrefix)] != bearerPrefix {
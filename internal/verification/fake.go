@@ -0,0 +1,67 @@
+package verification
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+const (
+	fakeConfidence       = 0.9
+	fakeMinDescription   = 20
+	fakeMaxPages         = 20000
+	fakeEarliestBookYear = -3000
+)
+
+// FakeVerifier - локальная проверка простыми правилами без внешних сервисов (для разработки и тестов).
+// Вердикт детерминирован: одна и та же книга всегда получает один и тот же ответ.
+type FakeVerifier struct {
+	now func() time.Time
+}
+
+// NewFakeVerifier - создание нового FakeVerifier
+func NewFakeVerifier() *FakeVerifier {
+	return &FakeVerifier{now: time.Now}
+}
+
+// Verify - книга одобряется, если метаданные правдоподобны
+func (v *FakeVerifier) Verify(ctx context.Context, book *models.Book) (*models.VerificationVerdict, error) {
+	var problems []string
+	if strings.TrimSpace(book.Title) == "" {
+		problems = append(problems, "title is empty")
+	}
+	if strings.TrimSpace(book.Author) == "" {
+		problems = append(problems, "author is empty")
+	}
+	if book.Year != nil && (*book.Year < fakeEarliestBookYear || *book.Year > v.now().Year()+1) {
+		problems = append(problems, "year is out of range")
+	}
+	if book.PagesCount < 1 || book.PagesCount > fakeMaxPages {
+		problems = append(problems, "pages_count is out of range")
+	}
+	if len([]rune(strings.TrimSpace(book.Description))) < fakeMinDescription {
+		problems = append(problems, "description is too short")
+	}
+	if book.AgeRating != nil {
+		switch *book.AgeRating {
+		case models.AgeRating6, models.AgeRating12, models.AgeRating16, models.AgeRating18:
+		default:
+			problems = append(problems, "age_rating is invalid")
+		}
+	}
+
+	if len(problems) > 0 {
+		return &models.VerificationVerdict{
+			Approved:   false,
+			Confidence: fakeConfidence,
+			Notes:      strings.Join(problems, "; "),
+		}, nil
+	}
+	return &models.VerificationVerdict{
+		Approved:   true,
+		Confidence: fakeConfidence,
+		Notes:      "metadata looks consistent",
+	}, nil
+}
@@ -0,0 +1,69 @@
+package verification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// maxVerdictBytes - предел размера ответа сервиса проверки
+const maxVerdictBytes = 64 << 10
+
+// HTTPVerifier - проверка внешним сервисом: POST {"book": ...} на URL,
+// в ответ JSON {"approved": bool, "confidence": 0..1, "notes": "..."}
+type HTTPVerifier struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewHTTPVerifier - создание нового HTTPVerifier; token передается как Bearer, если задан
+func NewHTTPVerifier(url, token string, timeout time.Duration) *HTTPVerifier {
+	return &HTTPVerifier{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Verify - запрос вердикта у сервиса проверки
+func (v *HTTPVerifier) Verify(ctx context.Context, book *models.Book) (*models.VerificationVerdict, error) {
+	body, err := json.Marshal(map[string]any{"book": book.ToResponse()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode verification request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build verification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if v.token != "" {
+		req.Header.Set("Authorization", "Bearer "+v.token)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("verification request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("verification service returned %s", resp.Status)
+	}
+
+	var verdict models.VerificationVerdict
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxVerdictBytes)).Decode(&verdict); err != nil {
+		return nil, fmt.Errorf("failed to decode verification verdict: %w", err)
+	}
+	if verdict.Confidence < 0 || verdict.Confidence > 1 {
+		return nil, fmt.Errorf("verification confidence %v is out of range", verdict.Confidence)
+	}
+	return &verdict, nil
+}
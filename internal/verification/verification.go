@@ -0,0 +1,31 @@
+// Package verification - автоматическая проверка метаданных книг (верификация типа AI)
+package verification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tukembaev/bookVisionGo/internal/config"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+)
+
+// Verifier - проверка метаданных книги: название, автор, год, объем, описание
+type Verifier interface {
+	// Verify - вердикт по книге в том виде, в каком она будет сохранена
+	Verify(ctx context.Context, book *models.Book) (*models.VerificationVerdict, error)
+}
+
+// New - проверка по конфигурации (VERIFICATION_VERIFIER=none|fake|http); для none возвращает nil
+func New(cfg config.VerificationConfig) (Verifier, error) {
+	switch cfg.Verifier {
+	case "", "none":
+		return nil, nil
+	case "fake":
+		return NewFakeVerifier(), nil
+	case "http":
+		return NewHTTPVerifier(cfg.VerifierURL, cfg.VerifierToken, time.Duration(cfg.VerifierTimeoutSeconds)*time.Second), nil
+	default:
+		return nil, fmt.Errorf("unknown verifier %q", cfg.Verifier)
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tukembaev/bookVisionGo/internal/metrics"
+	"github.com/tukembaev/bookVisionGo/internal/middleware"
+	"github.com/tukembaev/bookVisionGo/internal/models"
+	"github.com/tukembaev/bookVisionGo/internal/services"
+)
+
+// SetupMetrics - эндпоинт метрик Prometheus на основном роутере
+func SetupMetrics(r *gin.Engine, path string) {
+	r.GET(path, gin.WrapH(metrics.Handler()))
+}
+
+// NewMetricsServer - отдельный HTTP сервер метрик, чтобы не открывать /metrics на публичном порту API
+func NewMetricsServer(port, path string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle(path, metrics.Handler())
+	return &http.Server{
+		Addr:              ":" + port,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+// SetupPprof - профилирование net/http/pprof под /debug/pprof, доступное только администраторам
+func SetupPprof(r *gin.Engine, authService *services.AuthService) {
+	debug := r.Group("/debug/pprof",
+		middleware.AuthMiddleware(authService),
+		middleware.RequireRole(models.UserRoleAdmin),
+	)
+	debug.GET("/*profile", pprofHandler)
+	debug.POST("/symbol", gin.WrapF(pprof.Symbol))
+}
+
+// pprofHandler - выбор обработчика pprof по имени профиля;
+// именованные профили (heap, goroutine, allocs, ...) отдает pprof.Index
+func pprofHandler(c *gin.Context) {
+	switch strings.TrimPrefix(c.Param("profile"), "/") {
+	case "cmdline":
+		pprof.Cmdline(c.Writer, c.Request)
+	case "profile":
+		pprof.Profile(c.Writer, c.Request)
+	case "symbol":
+		pprof.Symbol(c.Writer, c.Request)
+	case "trace":
+		pprof.Trace(c.Writer, c.Request)
+	default:
+		pprof.Index(c.Writer, c.Request)
+	}
+}
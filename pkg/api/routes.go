@@ -1,6 +1,8 @@
 package api
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/tukembaev/bookVisionGo/internal/handlers"
 	"github.com/tukembaev/bookVisionGo/internal/middleware"
@@ -13,28 +15,54 @@ func SetupRoutes(
 	r *gin.Engine,
 	authHandler *handlers.AuthHandler,
 	bookHandler *handlers.BookHandler,
+	characterHandler *handlers.CharacterHandler,
 	articleHandler *handlers.ArticleHandler,
+	userHandler *handlers.UserHandler,
+	socialHandler *handlers.SocialHandler,
+	reviewHandler *handlers.ReviewHandler,
+	quoteHandler *handlers.QuoteHandler,
+	readingHandler *handlers.ReadingHandler,
+	challengeHandler *handlers.ChallengeHandler,
+	shelfHandler *handlers.ShelfHandler,
+	recommendationHandler *handlers.RecommendationHandler,
+	importHandler *handlers.ImportHandler,
+	exportHandler *handlers.ExportHandler,
+	mediaHandler *handlers.MediaHandler,
+	trashHandler *handlers.TrashHandler,
+	auditHandler *handlers.AuditHandler,
+	verificationHandler *handlers.VerificationHandler,
+	reportHandler *handlers.ReportHandler,
+	healthHandler *handlers.HealthHandler,
 
 	authService *services.AuthService,
+	rateLimits *middleware.RateLimits,
+	uploadTimeout time.Duration,
 ) {
 	// Debug: проверим что handler не nil
 	if bookHandler == nil {
 		panic("bookHandler is nil in SetupRoutes!")
 	}
-	// Health check endpoint
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
-	})
+	// Пробы: живость процесса и готовность зависимостей; /health оставлен для старых мониторингов
+	r.GET("/livez", healthHandler.Livez)
+	r.GET("/readyz", healthHandler.Readyz)
+	r.GET("/health", healthHandler.Readyz)
+
+	// Загруженные изображения (обложки, аватары, иллюстрации)
+	r.GET("/media/*key", mediaHandler.ServeMedia)
+	r.HEAD("/media/*key", mediaHandler.ServeMedia)
+
+	// Загрузки файлов получают собственный таймаут вместо общего read/write таймаута сервера
+	upload := middleware.ExtendDeadline(uploadTimeout)
 
-	// API v1 group
-	v1 := r.Group("/api")
+	// API v1 group (общий лимит частоты по IP)
+	v1 := r.Group("/api", rateLimits.Default)
 	{
 		// Auth routes (публичные)
 		auth := v1.Group("/auth")
 		{
-			auth.POST("/register", authHandler.Register)
-			auth.POST("/login", authHandler.Login)
-			auth.POST("/refresh", authHandler.RefreshToken)
+			auth.POST("/register", rateLimits.Auth, authHandler.Register)
+			auth.POST("/login", rateLimits.Auth, authHandler.Login)
+			auth.POST("/refresh", rateLimits.Auth, authHandler.RefreshToken)
 
 			// Требуют аутентификации
 			authGroup := auth.Group("", middleware.AuthMiddleware(authService))
@@ -49,65 +77,166 @@ func SetupRoutes(
 		books := v1.Group("/books")
 		{
 			// Сначала более конкретные маршруты
+			books.GET("/:id/parts/:partId/revisions/diff", bookHandler.DiffBookPartRevisions)
+			books.GET("/:id/parts/:partId/revisions", bookHandler.GetBookPartRevisions)
 			books.GET("/:id/parts/:partId", bookHandler.GetBookPart)
 			books.GET("/:id/parts", bookHandler.GetBookParts)
+			books.GET("/:id/revisions/diff", bookHandler.DiffBookRevisions)
+			books.GET("/:id/revisions", bookHandler.GetBookRevisions)
+			books.GET("/:id/similar", recommendationHandler.GetSimilarBooks)
+			books.GET("/:id/proposals", verificationHandler.GetBookProposals)
+			books.GET("/:id/export", exportHandler.ExportBook)
 
 			// Затем общие маршруты
-			books.GET("", bookHandler.GetBooks)
+			books.GET("", rateLimits.Search, bookHandler.GetBooks)
 			books.GET("/:id", bookHandler.GetBook)
 
 			// Защищенные маршруты
 			booksGroup := books.Group("", middleware.AuthMiddleware(authService))
 			{
 				// Создание и обновление (требуют прав moderator+)
-				moderatorGroup := booksGroup.Group("")
+				moderatorGroup := booksGroup.Group("", middleware.RequireRole(models.UserRoleModerator))
 				{
 					moderatorGroup.POST("", bookHandler.CreateBook)
+					moderatorGroup.POST("/import", upload, importHandler.ImportBook)
 					moderatorGroup.PUT("/:id", bookHandler.UpdateBook)
+					moderatorGroup.PATCH("/:id", bookHandler.PatchBook)
+					moderatorGroup.PATCH("/:id/parts/:partId", bookHandler.PatchBookPart)
+					moderatorGroup.POST("/:id/revisions/:version/revert", bookHandler.RevertBook)
+					moderatorGroup.POST("/:id/parts/:partId/revisions/:version/revert", bookHandler.RevertBookPart)
+					moderatorGroup.POST("/:id/cover", upload, mediaHandler.UploadBookCover)
+					moderatorGroup.PUT("/:id/verification", bookHandler.SetBookVerification)
+					moderatorGroup.POST("/:id/verification/ai", verificationHandler.RunAIVerification)
 				}
 
 				// Удаление (требует прав admin)
-				adminGroup := booksGroup.Group("")
+				adminGroup := booksGroup.Group("", middleware.RequireRole(models.UserRoleAdmin))
 				{
 					adminGroup.DELETE("/:id", bookHandler.DeleteBook)
 				}
+
+				// Предложение исправлений метаданных (голосование сообщества)
+				booksGroup.POST("/:id/proposals", rateLimits.Write, verificationHandler.CreateProposal)
+
+				// Прогресс чтения текущего пользователя
+				booksGroup.PUT("/:id/progress", readingHandler.UpdateProgress)
+
+				// Полки и избранное текущего пользователя
+				booksGroup.PUT("/:id/shelf", shelfHandler.SetBookShelfStatus)
+				booksGroup.DELETE("/:id/shelf", shelfHandler.RemoveBookShelfStatus)
+				booksGroup.POST("/:id/favorite", shelfHandler.AddFavorite)
+				booksGroup.DELETE("/:id/favorite", shelfHandler.RemoveFavorite)
 			}
 		}
 
 		// Articles
 		articles := v1.Group("/articles")
 		{
-			articles.GET("", articleHandler.GetArticles)
-			articles.GET("/:id", articleHandler.GetArticleById)
+			articles.GET("", rateLimits.Search, articleHandler.GetArticles)
+			articles.GET("/:id", middleware.OptionalAuth(authService), articleHandler.GetArticleById)
 
-			articles.POST("", middleware.RequireRole(models.UserRoleModerator), func(c *gin.Context) {
-				c.JSON(201, gin.H{"message": "Article created"})
-			})
+			articles.POST("", middleware.AuthMiddleware(authService), middleware.RequireRole(models.UserRoleModerator), articleHandler.CreateArticle)
+			articles.POST("/:id/cover", middleware.AuthMiddleware(authService), middleware.RequireRole(models.UserRoleModerator), upload, mediaHandler.UploadArticleCover)
+			articles.PUT("/:id", middleware.AuthMiddleware(authService), middleware.RequireRole(models.UserRoleModerator), articleHandler.UpdateArticle)
+			articles.PATCH("/:id", middleware.AuthMiddleware(authService), middleware.RequireRole(models.UserRoleModerator), articleHandler.PatchArticle)
+			articles.DELETE("/:id", middleware.AuthMiddleware(authService), middleware.RequireRole(models.UserRoleAdmin), articleHandler.DeleteArticle)
 		}
 
-		// Users routes (защищенные)
-		users := v1.Group("/users", middleware.AuthMiddleware(authService))
+		// Users routes
+		users := v1.Group("/users")
 		{
-			users.GET("/me", func(c *gin.Context) {
-				currentUser := middleware.GetCurrentUser(c)
-				c.JSON(200, gin.H{
-					"user": currentUser,
+			// Требуют аутентификации
+			usersAuth := users.Group("", middleware.AuthMiddleware(authService))
+			{
+				usersAuth.GET("/me", func(c *gin.Context) {
+					currentUser := middleware.GetCurrentUser(c)
+					c.JSON(200, gin.H{
+						"user": currentUser,
+					})
 				})
-			})
+				usersAuth.GET("/me/export", exportHandler.ExportMyData)
+				usersAuth.PUT("/me/avatar", upload, mediaHandler.UploadAvatar)
+				usersAuth.DELETE("/me/avatar", mediaHandler.DeleteAvatar)
 
-			// Admin только
-			adminGroup := users.Group("", middleware.RequireRole(models.UserRoleAdmin))
+				// Admin только
+				adminGroup := usersAuth.Group("", middleware.RequireRole(models.UserRoleAdmin))
+				{
+					adminGroup.GET("", userHandler.ListUsers)
+					adminGroup.DELETE("/:username", userHandler.DeleteUser)
+					adminGroup.PUT("/:username/role", userHandler.UpdateUserRole)
+					adminGroup.PUT("/:username/suspension", userHandler.SuspendUser)
+					adminGroup.DELETE("/:username/suspension", userHandler.UnsuspendUser)
+				}
+			}
+
+			// Публичные профили (видимость проверяется в UserService)
+			profiles := users.Group("/:username", middleware.OptionalAuth(authService))
 			{
-				adminGroup.GET("", func(c *gin.Context) {
-					// Заглушка для получения списка пользователей
-					c.JSON(200, gin.H{"message": "Admin users list"})
-				})
+				profiles.GET("", userHandler.GetUserProfile)
+				profiles.GET("/reviews", userHandler.GetUserReviews)
+				profiles.GET("/quotes", userHandler.GetUserQuotes)
+				profiles.GET("/progress", userHandler.GetUserProgress)
+				profiles.GET("/sessions", userHandler.GetUserSessions)
+				profiles.GET("/favorites", userHandler.GetUserFavorites)
+				profiles.GET("/followers", socialHandler.GetFollowers)
+				profiles.GET("/following", socialHandler.GetFollowing)
+
+				// Подписка требует аутентификации
+				profiles.POST("/follow", middleware.AuthMiddleware(authService), rateLimits.Write, socialHandler.FollowUser)
+				profiles.DELETE("/follow", middleware.AuthMiddleware(authService), socialHandler.UnfollowUser)
 			}
 		}
 
+		// Предложения исправлений книг: просмотр и голосование
+		proposals := v1.Group("/proposals")
+		{
+			proposals.GET("/:id", verificationHandler.GetProposal)
+			proposals.POST("/:id/votes", middleware.AuthMiddleware(authService), rateLimits.Write, verificationHandler.VoteProposal)
+		}
+
+		// Жалобы на пользовательский контент
+		v1.POST("/reports", middleware.AuthMiddleware(authService), rateLimits.Write, reportHandler.CreateReport)
+
+		// Очередь модераторов: предложения и жалобы, по которым решение принимает модератор
+		moderation := v1.Group("/moderation", middleware.AuthMiddleware(authService), middleware.RequireRole(models.UserRoleModerator))
+		{
+			moderation.GET("/proposals", verificationHandler.GetModerationQueue)
+			moderation.POST("/proposals/:id/accept", verificationHandler.AcceptProposal)
+			moderation.POST("/proposals/:id/reject", verificationHandler.RejectProposal)
+
+			moderation.GET("/reports", reportHandler.GetReportQueue)
+			moderation.POST("/reports/bulk", reportHandler.BulkReports)
+			moderation.GET("/reports/:id", reportHandler.GetReport)
+			moderation.POST("/reports/:id/claim", reportHandler.ClaimReport)
+			moderation.DELETE("/reports/:id/claim", reportHandler.ReleaseReport)
+			moderation.POST("/reports/:id/resolve", reportHandler.ResolveReport)
+			moderation.POST("/reports/:id/dismiss", reportHandler.DismissReport)
+		}
+
+		// Корзина: просмотр, восстановление и окончательное удаление (admin)
+		trash := v1.Group("/admin/trash", middleware.AuthMiddleware(authService), middleware.RequireRole(models.UserRoleAdmin))
+		{
+			trash.GET("", trashHandler.GetTrash)
+			trash.POST("/:type/:id/restore", trashHandler.RestoreTrashItem)
+			trash.DELETE("/:type/:id", trashHandler.PurgeTrashItem)
+		}
+
+		// Журнал аудита действий модераторов и администраторов (admin)
+		audit := v1.Group("/admin/audit", middleware.AuthMiddleware(authService), middleware.RequireRole(models.UserRoleAdmin))
+		{
+			audit.GET("", auditHandler.GetAuditLog)
+			audit.GET("/export", auditHandler.ExportAuditLog)
+		}
+
 		// Protected routes для будущих модулей
 		protected := v1.Group("", middleware.AuthMiddleware(authService))
 		{
+			// Лента активности подписок
+			protected.GET("/feed", socialHandler.GetFeed)
+
+			// Персональные рекомендации
+			protected.GET("/recommendations", recommendationHandler.GetRecommendations)
+
 			// Characters
 			characters := protected.Group("/characters")
 			{
@@ -117,6 +246,12 @@ func SetupRoutes(
 				characters.POST("", middleware.RequireRole(models.UserRoleModerator), func(c *gin.Context) {
 					c.JSON(201, gin.H{"message": "Character created"})
 				})
+				characters.GET("/:id", characterHandler.GetCharacterProfile)
+				characters.GET("/:id/revisions/diff", characterHandler.DiffCharacterRevisions)
+				characters.GET("/:id/revisions", characterHandler.GetCharacterRevisions)
+				characters.PATCH("/:id", middleware.RequireRole(models.UserRoleModerator), characterHandler.PatchCharacterProfile)
+				characters.POST("/:id/revisions/:version/revert", middleware.RequireRole(models.UserRoleModerator), characterHandler.RevertCharacterProfile)
+				characters.POST("/:id/illustrations", middleware.RequireRole(models.UserRoleModerator), upload, mediaHandler.UploadIllustration)
 			}
 
 			// Reviews
@@ -125,9 +260,28 @@ func SetupRoutes(
 				reviews.GET("", func(c *gin.Context) {
 					c.JSON(200, gin.H{"message": "Reviews list"})
 				})
-				reviews.POST("", func(c *gin.Context) {
-					c.JSON(201, gin.H{"message": "Review created"})
-				})
+				reviews.POST("", rateLimits.Write, reviewHandler.CreateReview)
+			}
+
+			// Shelves
+			shelves := protected.Group("/shelves")
+			{
+				shelves.GET("", shelfHandler.GetShelves)
+				shelves.POST("", shelfHandler.CreateShelf)
+				shelves.GET("/favorites", shelfHandler.GetFavorites)
+				shelves.GET("/status/:status", shelfHandler.GetShelfStatusBooks)
+				shelves.PUT("/:id", shelfHandler.UpdateShelf)
+				shelves.DELETE("/:id", shelfHandler.DeleteShelf)
+				shelves.GET("/:id/books", shelfHandler.GetShelfBooks)
+				shelves.POST("/:id/books", shelfHandler.AddShelfBook)
+				shelves.PUT("/:id/books/:bookId", shelfHandler.UpdateShelfBook)
+				shelves.DELETE("/:id/books/:bookId", shelfHandler.RemoveShelfBook)
+			}
+
+			// Quotes
+			quotes := protected.Group("/quotes")
+			{
+				quotes.POST("", rateLimits.Write, quoteHandler.CreateQuote)
 			}
 
 			// Challenges
@@ -136,9 +290,7 @@ func SetupRoutes(
 				challenges.GET("", func(c *gin.Context) {
 					c.JSON(200, gin.H{"message": "Challenges list"})
 				})
-				challenges.POST("/:id/join", func(c *gin.Context) {
-					c.JSON(200, gin.H{"message": "Joined challenge"})
-				})
+				challenges.POST("/:id/join", challengeHandler.JoinChallenge)
 			}
 
 			// Playlists